@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/goverland-labs/goverland-analytics-api-protocol/protobuf/internalapi"
 	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
@@ -16,13 +17,40 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/bookmark"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/broadcast"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/cachepurge"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/export"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/feedstream"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+	internalproposal "github.com/goverland-labs/goverland-inbox-web-api/internal/proposal"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/revision"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/snooze"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/tracking"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/upgrade"
 	"github.com/goverland-labs/goverland-inbox-web-api/pkg/health"
 	"github.com/goverland-labs/goverland-inbox-web-api/pkg/prometheus"
 )
 
+// key-value buckets with the state of users shared by all instances
+const (
+	tokensBucket     = "inbox_web_tokens"
+	feedTokensBucket = "inbox_web_feed_tokens"
+	refreshBucket    = "inbox_web_refresh_tokens"
+	devicesBucket    = "inbox_web_devices"
+	snoozesBucket    = "inbox_web_snoozes"
+	exportsBucket    = "inbox_web_exports"
+	archivesBucket   = "inbox_web_export_archives"
+	broadcastsBucket = "inbox_web_broadcasts"
+	featuredBucket   = "inbox_web_featured"
+	bookmarksBucket  = "inbox_web_bookmarks"
+	revisionsBucket  = "inbox_web_revisions"
+	upgradesBucket   = "inbox_web_upgrades"
+	leasesBucket     = "inbox_web_leases"
+)
+
 type Application struct {
 	sigChan <-chan os.Signal
 	manager *process.Manager
@@ -31,6 +59,11 @@ type Application struct {
 	feedClient        inboxapi.FeedClient
 	achievementClient inboxapi.AchievementClient
 	pb                *natsclient.Publisher
+	js                nats.JetStreamContext
+	feedBroker        *feedstream.Broker
+	purgeBroker       *cachepurge.Broker
+	snoozes           *snooze.Storage
+	leases            kvstore.Bucket
 }
 
 func NewApplication(cfg config.App) (*Application, error) {
@@ -63,6 +96,8 @@ func (a *Application) bootstrap() error {
 
 		// Init Workers: Application
 		a.initRESTWorker,
+		a.initFeedStreamWorker,
+		a.initSnoozeWorker,
 
 		// Init Workers: System
 		a.initPrometheusWorker,
@@ -96,6 +131,27 @@ func (a *Application) initServices() error {
 
 	a.pb = pb
 
+	js, err := nc.JetStream()
+	if err != nil {
+		return fmt.Errorf("create jetstream context: %v", err)
+	}
+	a.js = js
+
+	a.feedBroker = feedstream.NewBroker(nc, feedstream.NewHub(a.cfg.FeedStream))
+	a.purgeBroker = cachepurge.NewBroker(nc)
+
+	snoozesKV, err := a.openBucket(snoozesBucket, 0)
+	if err != nil {
+		return fmt.Errorf("create snooze storage: %v", err)
+	}
+	a.snoozes = snooze.NewStorage(snoozesKV)
+
+	leasesKV, err := a.openBucket(leasesBucket, a.cfg.Nats.LeaseTTL)
+	if err != nil {
+		return fmt.Errorf("create lease storage: %v", err)
+	}
+	a.leases = leasesKV
+
 	return nil
 }
 
@@ -128,16 +184,132 @@ func (a *Application) initRESTWorker() error {
 
 	a.feedClient = inboxapi.NewFeedClient(feedConn)
 
-	authService := auth.NewService(ic)
+	tokensKV, err := a.openBucket(tokensBucket, 0)
+	if err != nil {
+		return fmt.Errorf("create token storage: %v", err)
+	}
+	tokens := auth.NewTokenStorage(tokensKV)
+
+	feedTokensKV, err := a.openBucket(feedTokensBucket, 0)
+	if err != nil {
+		return fmt.Errorf("create feed token storage: %v", err)
+	}
+	feedTokens := auth.NewFeedTokenStorage(feedTokensKV)
+
+	refreshKV, err := a.openBucket(refreshBucket, a.cfg.Auth.RefreshTokenTTL)
+	if err != nil {
+		return fmt.Errorf("create refresh token storage: %v", err)
+	}
+	refreshTokens := auth.NewRefreshTokenStorage(refreshKV)
+
+	devicesKV, err := a.openBucket(devicesBucket, auth.DeviceInfoTTL)
+	if err != nil {
+		return fmt.Errorf("create session device storage: %v", err)
+	}
+	devices := auth.NewDeviceStorage(devicesKV)
+
+	issuer, err := auth.NewAccessTokenIssuer(a.cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("create access token issuer: %v", err)
+	}
+
+	authService := auth.NewService(ic, auth.NewSessionCache(a.cfg.Auth.SessionCacheTTL, a.cfg.Auth.SessionCacheSize), devices, tokens, feedTokens, refreshTokens, issuer, a.purgeBroker, a.cfg.Auth.AdminAddresses)
+	a.purgeBroker.OnPurge(authService.PurgeCache)
 
 	uas := tracking.NewUserActivityService(ic)
 	a.manager.AddWorker(process.NewCallbackWorker("user-activity", uas.Start))
 
-	srv, err := rest.NewServer(a.cfg.REST, a.cfg.Chain, authService, cs, sc, settings, versions, a.feedClient, a.achievementClient, ac, ic, pc, dc, uas, a.pb, a.cfg.SiweTTL)
+	bookmarksKV, err := a.openBucket(bookmarksBucket, 0)
+	if err != nil {
+		return fmt.Errorf("create bookmark storage: %v", err)
+	}
+	bookmarks := bookmark.NewStorage(bookmarksKV)
+
+	upgrades, err := a.initUpgrades()
+	if err != nil {
+		return err
+	}
+
+	revisionsKV, err := a.openBucket(revisionsBucket, 0)
+	if err != nil {
+		return fmt.Errorf("create revision storage: %v", err)
+	}
+	revisions := revision.NewStorage(revisionsKV, a.cfg.Revisions)
+
+	exports, err := a.initExports()
+	if err != nil {
+		return err
+	}
+
+	broadcastsKV, err := a.openBucket(broadcastsBucket, a.cfg.Broadcast.Timeout+a.cfg.Broadcast.TTL)
+	if err != nil {
+		return fmt.Errorf("create push job storage: %v", err)
+	}
+	broadcasts := broadcast.NewJobs(a.cfg.Broadcast, broadcastsKV)
+
+	featuredKV, err := a.openBucket(featuredBucket, 0)
+	if err != nil {
+		return fmt.Errorf("create featured curation storage: %v", err)
+	}
+	curation := internalproposal.NewFeaturedStorage(featuredKV)
+
+	srv, err := rest.NewServer(a.cfg.REST, a.cfg.Chain, authService, cs, sc, settings, versions, a.feedClient, a.achievementClient, ac, ic, pc, dc, uas, a.pb, a.cfg.Siwe, a.cfg.FeedStream, a.cfg.Syndication, a.cfg.Calendar, a.cfg.Search, a.snoozes, bookmarks, upgrades, revisions, exports, broadcasts, curation, a.purgeBroker, a.feedBroker)
 	if err != nil {
 		return fmt.Errorf("create REST server: %v", err)
 	}
 	a.manager.AddWorker(process.NewServerWorker("rest", srv.GetHTTPServer()))
+	a.manager.AddWorker(a.purgeBroker)
+
+	return nil
+}
+
+// openBucket returns the key-value bucket shared by all instances, the service doesn't start without it
+func (a *Application) openBucket(name string, ttl time.Duration) (nats.KeyValue, error) {
+	return kvstore.Open(a.js, name, ttl, a.cfg.Nats.KVReplicas)
+}
+
+// initUpgrades runs the worker applying the guest feed state to upgraded accounts,
+// pending states are kept until they are applied or the TTL expires
+func (a *Application) initUpgrades() (*upgrade.Worker, error) {
+	upgradesKV, err := a.openBucket(upgradesBucket, a.cfg.Upgrade.StateTTL)
+	if err != nil {
+		return nil, fmt.Errorf("create upgrade storage: %v", err)
+	}
+
+	lease := kvstore.NewLease(a.leases, "upgrade")
+	worker := upgrade.NewWorker(upgrade.NewStorage(upgradesKV, a.cfg.Upgrade.StateTTL), a.snoozes, lease, a.feedClient, a.cfg.Upgrade)
+	a.manager.AddWorker(process.NewCallbackWorker("upgrade", worker.Start))
+
+	return worker, nil
+}
+
+// initExports keeps export jobs and archives until the ready archive expires, the job is finished within the timeout
+func (a *Application) initExports() (*export.Jobs, error) {
+	ttl := a.cfg.Export.Timeout + a.cfg.Export.TTL
+
+	jobsKV, err := a.openBucket(exportsBucket, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("create export job storage: %v", err)
+	}
+
+	archives, err := kvstore.OpenObjects(a.js, archivesBucket, ttl, a.cfg.Nats.KVReplicas)
+	if err != nil {
+		return nil, fmt.Errorf("create export archive storage: %v", err)
+	}
+
+	return export.NewJobs(a.cfg.Export, jobsKV, archives), nil
+}
+
+func (a *Application) initFeedStreamWorker() error {
+	a.manager.AddWorker(a.feedBroker)
+
+	return nil
+}
+
+func (a *Application) initSnoozeWorker() error {
+	lease := kvstore.NewLease(a.leases, "snooze")
+	worker := snooze.NewWorker(a.snoozes, lease, a.feedClient, a.pb, a.feedBroker, a.cfg.Snooze)
+	a.manager.AddWorker(process.NewCallbackWorker("snooze", worker.Start))
 
 	return nil
 }
@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
 )
 
 const (
 	UserSessionKey ContextKey = "session"
+	TokenScopesKey ContextKey = "token_scopes"
+	UserRoleKey    ContextKey = "user_role"
 )
 
 type ContextKey string
@@ -24,3 +27,31 @@ func ExtractUserSession(ctx context.Context) (session auth.Session, exist bool)
 func EnrichWithUserSession(ctx context.Context, session auth.Session) context.Context {
 	return context.WithValue(ctx, UserSessionKey, session)
 }
+
+// ExtractTokenScopes returns scopes of the personal token. It doesn't exist if the request is authorized by the session.
+func ExtractTokenScopes(ctx context.Context) (scopes []auth.Scope, exist bool) {
+	val := ctx.Value(TokenScopesKey)
+	if val == nil {
+		return nil, false
+	}
+
+	return val.([]auth.Scope), true
+}
+
+func EnrichWithTokenScopes(ctx context.Context, scopes []auth.Scope) context.Context {
+	return context.WithValue(ctx, TokenScopesKey, scopes)
+}
+
+// ExtractUserRole returns the role signed into the access token. It doesn't exist for other types of authorization.
+func ExtractUserRole(ctx context.Context) (role profile.Role, exist bool) {
+	val := ctx.Value(UserRoleKey)
+	if val == nil {
+		return profile.UnknownRole, false
+	}
+
+	return val.(profile.Role), true
+}
+
+func EnrichWithUserRole(ctx context.Context, role profile.Role) context.Context {
+	return context.WithValue(ctx, UserRoleKey, role)
+}
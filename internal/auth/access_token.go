@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
+)
+
+const (
+	accessTokenIssuer = "goverland-inbox-web-api"
+
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+)
+
+var (
+	ErrAccessTokensDisabled = errors.New("access tokens are disabled")
+	ErrInvalidAccessToken   = errors.New("invalid access token")
+)
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+
+	Type       string       `json:"typ"`
+	SessionID  string       `json:"sid"`
+	DeviceUUID string       `json:"did,omitempty"`
+	Role       profile.Role `json:"role,omitempty"`
+}
+
+type signingKey struct {
+	id     string
+	secret []byte
+}
+
+// AccessTokenIssuer signs short-lived access tokens which are verified without requests to the storage,
+// and long-lived refresh tokens for getting new access tokens. Both are HS256 JWT with the key id in the header.
+type AccessTokenIssuer struct {
+	keys       []signingKey
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewAccessTokenIssuer(cfg config.Auth) (*AccessTokenIssuer, error) {
+	keys := make([]signingKey, 0, len(cfg.AccessTokenKeys))
+	for _, raw := range cfg.AccessTokenKeys {
+		id, secret, ok := strings.Cut(strings.TrimSpace(raw), ":")
+		if !ok || id == "" || secret == "" {
+			return nil, fmt.Errorf("invalid access token key format, expected key_id:secret")
+		}
+
+		keys = append(keys, signingKey{id: id, secret: []byte(secret)})
+	}
+
+	return &AccessTokenIssuer{
+		keys:       keys,
+		accessTTL:  cfg.AccessTokenTTL,
+		refreshTTL: cfg.RefreshTokenTTL,
+	}, nil
+}
+
+func (i *AccessTokenIssuer) Enabled() bool {
+	return len(i.keys) > 0
+}
+
+func (i *AccessTokenIssuer) Issue(session Session, role profile.Role) (profile.AccessTokens, error) {
+	if !i.Enabled() {
+		return profile.AccessTokens{}, ErrAccessTokensDisabled
+	}
+
+	now := time.Now()
+	access, err := i.sign(session, role, tokenTypeAccess, now, i.accessTTL)
+	if err != nil {
+		return profile.AccessTokens{}, fmt.Errorf("sign access token: %w", err)
+	}
+
+	refresh, err := i.sign(session, "", tokenTypeRefresh, now, i.refreshTTL)
+	if err != nil {
+		return profile.AccessTokens{}, fmt.Errorf("sign refresh token: %w", err)
+	}
+
+	return profile.AccessTokens{
+		AccessToken:           access,
+		AccessTokenExpiresAt:  *common.NewTime(now.Add(i.accessTTL)),
+		RefreshToken:          refresh,
+		RefreshTokenExpiresAt: *common.NewTime(now.Add(i.refreshTTL)),
+	}, nil
+}
+
+// VerifyAccess returns the session and the role of the access token
+func (i *AccessTokenIssuer) VerifyAccess(raw string) (Session, profile.Role, error) {
+	claims, err := i.verify(raw, tokenTypeAccess)
+	if err != nil {
+		return Session{}, profile.UnknownRole, err
+	}
+
+	session, err := claims.session()
+	if err != nil {
+		return Session{}, profile.UnknownRole, err
+	}
+
+	return session, claims.Role, nil
+}
+
+// VerifyRefresh returns the session and the id of the refresh token
+func (i *AccessTokenIssuer) VerifyRefresh(raw string) (Session, string, error) {
+	claims, err := i.verify(raw, tokenTypeRefresh)
+	if err != nil {
+		return Session{}, "", err
+	}
+
+	if claims.ID == "" {
+		return Session{}, "", fmt.Errorf("%w: missing token id", ErrInvalidAccessToken)
+	}
+
+	session, err := claims.session()
+	if err != nil {
+		return Session{}, "", err
+	}
+
+	return session, claims.ID, nil
+}
+
+func (i *AccessTokenIssuer) sign(session Session, role profile.Role, tokenType string, now time.Time, ttl time.Duration) (string, error) {
+	key := i.keys[0]
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    accessTokenIssuer,
+			Subject:   session.UserID.String(),
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Type:       tokenType,
+		SessionID:  session.ID.String(),
+		DeviceUUID: session.DeviceUUID,
+		Role:       role,
+	})
+	token.Header["kid"] = key.id
+
+	return token.SignedString(key.secret)
+}
+
+func (i *AccessTokenIssuer) verify(raw, tokenType string) (*tokenClaims, error) {
+	if !i.Enabled() {
+		return nil, ErrAccessTokensDisabled
+	}
+
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, i.keyFunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(accessTokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAccessToken, err)
+	}
+
+	if claims.Type != tokenType {
+		return nil, fmt.Errorf("%w: unexpected token type: %s", ErrInvalidAccessToken, claims.Type)
+	}
+
+	return claims, nil
+}
+
+func (i *AccessTokenIssuer) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range i.keys {
+		if key.id == kid {
+			return key.secret, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown key id: %s", kid)
+}
+
+func (c *tokenClaims) session() (Session, error) {
+	userID, err := uuid.Parse(c.Subject)
+	if err != nil {
+		return Session{}, fmt.Errorf("%w: subject: %w", ErrInvalidAccessToken, err)
+	}
+
+	sessionID, err := uuid.Parse(c.SessionID)
+	if err != nil {
+		return Session{}, fmt.Errorf("%w: session id: %w", ErrInvalidAccessToken, err)
+	}
+
+	return Session{
+		ID:         SessionID(sessionID),
+		UserID:     UserID(userID),
+		DeviceUUID: c.DeviceUUID,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
+)
+
+func newTestIssuer(t *testing.T, keys ...string) *AccessTokenIssuer {
+	issuer, err := NewAccessTokenIssuer(config.Auth{
+		AccessTokenKeys: keys,
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	})
+	require.NoError(t, err)
+
+	return issuer
+}
+
+func TestAccessTokenIssuer(t *testing.T) {
+	session := Session{
+		ID:         SessionID(uuid.New()),
+		UserID:     UserID(uuid.New()),
+		DeviceUUID: "device",
+	}
+
+	t.Run("issue and verify", func(t *testing.T) {
+		issuer := newTestIssuer(t, "k1:secret")
+
+		tokens, err := issuer.Issue(session, profile.RegularRole)
+		require.NoError(t, err)
+
+		verified, role, err := issuer.VerifyAccess(tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, session, verified)
+		assert.Equal(t, profile.RegularRole, role)
+
+		refreshed, tokenID, err := issuer.VerifyRefresh(tokens.RefreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, session, refreshed)
+		assert.NotEmpty(t, tokenID)
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		tokens, err := newTestIssuer(t, "k1:secret").Issue(session, profile.GuestRole)
+		require.NoError(t, err)
+
+		_, _, err = newTestIssuer(t, "k2:another", "k1:secret").VerifyAccess(tokens.AccessToken)
+		assert.NoError(t, err)
+
+		_, _, err = newTestIssuer(t, "k2:another").VerifyAccess(tokens.AccessToken)
+		assert.ErrorIs(t, err, ErrInvalidAccessToken)
+
+		_, _, err = newTestIssuer(t, "k1:changed").VerifyAccess(tokens.AccessToken)
+		assert.ErrorIs(t, err, ErrInvalidAccessToken)
+	})
+
+	t.Run("token type mismatch", func(t *testing.T) {
+		issuer := newTestIssuer(t, "k1:secret")
+
+		tokens, err := issuer.Issue(session, profile.RegularRole)
+		require.NoError(t, err)
+
+		_, _, err = issuer.VerifyAccess(tokens.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidAccessToken)
+
+		_, _, err = issuer.VerifyRefresh(tokens.AccessToken)
+		assert.ErrorIs(t, err, ErrInvalidAccessToken)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		issuer := newTestIssuer(t, "k1:secret")
+		issuer.accessTTL = -time.Minute
+
+		tokens, err := issuer.Issue(session, profile.RegularRole)
+		require.NoError(t, err)
+
+		_, _, err = issuer.VerifyAccess(tokens.AccessToken)
+		assert.ErrorIs(t, err, ErrInvalidAccessToken)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		assert.False(t, issuer.Enabled())
+
+		_, err := issuer.Issue(session, profile.RegularRole)
+		assert.ErrorIs(t, err, ErrAccessTokensDisabled)
+	})
+
+	t.Run("wrong key format", func(t *testing.T) {
+		_, err := NewAccessTokenIssuer(config.Auth{AccessTokenKeys: []string{"secret"}})
+		assert.Error(t, err)
+	})
+}
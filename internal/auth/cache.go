@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+const cleanCacheInterval = 1 * time.Minute
+
+type cachedSession struct {
+	expiresAt time.Time
+	value     Session
+}
+
+func (i cachedSession) expired() bool {
+	return time.Now().After(i.expiresAt)
+}
+
+// SessionCache keeps recently resolved sessions in memory to avoid calling the storage on each request.
+// The cache is bounded by size: when the limit is reached the entry closest to expiration is evicted.
+type SessionCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	size    int
+	cache   map[SessionID]cachedSession
+	byUsers map[UserID]map[SessionID]struct{}
+}
+
+func NewSessionCache(ttl time.Duration, size int) *SessionCache {
+	repo := &SessionCache{
+		ttl:     ttl,
+		size:    size,
+		cache:   make(map[SessionID]cachedSession),
+		byUsers: make(map[UserID]map[SessionID]struct{}),
+	}
+
+	go func() {
+		for {
+			<-time.After(cleanCacheInterval)
+
+			repo.clean()
+		}
+	}()
+
+	return repo
+}
+
+func (r *SessionCache) clean() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, item := range r.cache {
+		if !item.expired() {
+			continue
+		}
+
+		r.remove(id)
+	}
+
+	sessionCacheSize.Set(float64(len(r.cache)))
+}
+
+func (r *SessionCache) Get(id SessionID) (Session, bool) {
+	r.mu.RLock()
+	item, ok := r.cache[id]
+	r.mu.RUnlock()
+
+	if ok && !item.expired() {
+		sessionCacheRequests.WithLabelValues(cacheHit).Inc()
+
+		return item.value, true
+	}
+
+	sessionCacheRequests.WithLabelValues(cacheMiss).Inc()
+
+	return EmptySession, false
+}
+
+func (r *SessionCache) Add(session Session) {
+	if r.size <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.cache[session.ID]; !ok && len(r.cache) >= r.size {
+		r.evictOne()
+	}
+
+	r.cache[session.ID] = cachedSession{
+		expiresAt: time.Now().Add(r.ttl),
+		value:     session,
+	}
+
+	sessions, ok := r.byUsers[session.UserID]
+	if !ok {
+		sessions = make(map[SessionID]struct{})
+		r.byUsers[session.UserID] = sessions
+	}
+	sessions[session.ID] = struct{}{}
+
+	sessionCacheSize.Set(float64(len(r.cache)))
+}
+
+// DeleteSession removes the session from the cache, the next lookup goes to the storage
+func (r *SessionCache) DeleteSession(id SessionID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.remove(id) {
+		sessionCacheEvictions.WithLabelValues(evictionReasonInvalidated).Inc()
+	}
+
+	sessionCacheSize.Set(float64(len(r.cache)))
+}
+
+// DeleteUser removes all cached sessions related to the user
+func (r *SessionCache) DeleteUser(id UserID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for sessionID := range r.byUsers[id] {
+		if r.remove(sessionID) {
+			sessionCacheEvictions.WithLabelValues(evictionReasonInvalidated).Inc()
+		}
+	}
+
+	sessionCacheSize.Set(float64(len(r.cache)))
+}
+
+// evictOne drops expired entry or the entry which expires first. Must be called under the write lock.
+func (r *SessionCache) evictOne() {
+	var (
+		candidate SessionID
+		expiresAt time.Time
+		found     bool
+	)
+
+	for id, item := range r.cache {
+		if item.expired() {
+			candidate, found = id, true
+			break
+		}
+
+		if !found || item.expiresAt.Before(expiresAt) {
+			candidate, expiresAt, found = id, item.expiresAt, true
+		}
+	}
+
+	if found && r.remove(candidate) {
+		sessionCacheEvictions.WithLabelValues(evictionReasonCapacity).Inc()
+	}
+}
+
+// remove deletes the session from all indexes. Must be called under the write lock.
+func (r *SessionCache) remove(id SessionID) bool {
+	item, ok := r.cache[id]
+	if !ok {
+		return false
+	}
+
+	delete(r.cache, id)
+
+	sessions := r.byUsers[item.value.UserID]
+	delete(sessions, id)
+	if len(sessions) == 0 {
+		delete(r.byUsers, item.value.UserID)
+	}
+
+	return true
+}
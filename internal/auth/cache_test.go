@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSession(userID UserID) Session {
+	return Session{
+		ID:     SessionID(uuid.New()),
+		UserID: userID,
+	}
+}
+
+func TestSessionCache(t *testing.T) {
+	t.Run("returns stored session", func(t *testing.T) {
+		cache := NewSessionCache(time.Minute, 10)
+		session := newTestSession(UserID(uuid.New()))
+		cache.Add(session)
+
+		actual, ok := cache.Get(session.ID)
+		assert.True(t, ok)
+		assert.Equal(t, session, actual)
+	})
+
+	t.Run("expired session is missed", func(t *testing.T) {
+		cache := NewSessionCache(time.Millisecond, 10)
+		session := newTestSession(UserID(uuid.New()))
+		cache.Add(session)
+
+		time.Sleep(5 * time.Millisecond)
+
+		_, ok := cache.Get(session.ID)
+		assert.False(t, ok)
+	})
+
+	t.Run("size is bounded", func(t *testing.T) {
+		cache := NewSessionCache(time.Minute, 2)
+		first := newTestSession(UserID(uuid.New()))
+		cache.Add(first)
+		cache.Add(newTestSession(UserID(uuid.New())))
+		cache.Add(newTestSession(UserID(uuid.New())))
+
+		assert.Len(t, cache.cache, 2)
+		_, ok := cache.Get(first.ID)
+		assert.False(t, ok)
+	})
+
+	t.Run("delete session", func(t *testing.T) {
+		cache := NewSessionCache(time.Minute, 10)
+		session := newTestSession(UserID(uuid.New()))
+		cache.Add(session)
+		cache.DeleteSession(session.ID)
+
+		_, ok := cache.Get(session.ID)
+		assert.False(t, ok)
+		assert.Empty(t, cache.byUsers)
+	})
+
+	t.Run("delete user removes all user sessions", func(t *testing.T) {
+		cache := NewSessionCache(time.Minute, 10)
+		userID := UserID(uuid.New())
+		first, second := newTestSession(userID), newTestSession(userID)
+		other := newTestSession(UserID(uuid.New()))
+		cache.Add(first)
+		cache.Add(second)
+		cache.Add(other)
+
+		cache.DeleteUser(userID)
+
+		_, ok := cache.Get(first.ID)
+		assert.False(t, ok)
+		_, ok = cache.Get(second.ID)
+		assert.False(t, ok)
+		_, ok = cache.Get(other.ID)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+const (
+	// DeviceInfoTTL defines how long the app details are kept for the session without activity
+	DeviceInfoTTL     = 30 * 24 * time.Hour
+	deviceCleanPeriod = time.Hour
+	// deviceActivityPrecision limits writes of the last activity, it's updated at most once per the interval
+	deviceActivityPrecision = time.Minute
+)
+
+// DeviceInfo holds the app details of the session. The inbox api doesn't return them with sessions,
+// so they are collected from requests headers of each session. The name is set by the user.
+type DeviceInfo struct {
+	Name           string    `json:"name,omitempty"`
+	AppPlatform    string    `json:"app_platform,omitempty"`
+	AppVersion     string    `json:"app_version,omitempty"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+}
+
+// DeviceStorage keeps app details of sessions in the bucket shared by all instances. The bucket TTL removes details
+// of sessions without activity. Details written by the instance are remembered, so requests of the same app
+// don't write them again until the activity precision is passed.
+type DeviceStorage struct {
+	devices *kvstore.Store[DeviceInfo]
+
+	mu          sync.Mutex
+	written     map[SessionID]DeviceInfo
+	lastCleanAt time.Time
+}
+
+func NewDeviceStorage(bucket kvstore.Bucket) *DeviceStorage {
+	return &DeviceStorage{
+		devices:     kvstore.New[DeviceInfo](bucket),
+		written:     make(map[SessionID]DeviceInfo),
+		lastCleanAt: time.Now(),
+	}
+}
+
+// track stores app details from the request, the request is already authenticated, so errors are logged only
+func (s *DeviceStorage) track(sessionID SessionID, platform, version string) {
+	now := time.Now()
+	if !s.changed(sessionID, platform, version, now) {
+		return
+	}
+
+	info, err := s.devices.Update(deviceKey(sessionID), func(info *DeviceInfo) error {
+		if platform != "" {
+			info.AppPlatform = platform
+		}
+		if version != "" {
+			info.AppVersion = version
+		}
+		info.LastActivityAt = now
+
+		return nil
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("session_id", sessionID.String()).Msg("track session device")
+
+		return
+	}
+
+	s.remember(sessionID, info, now)
+}
+
+func (s *DeviceStorage) get(sessionID SessionID) (DeviceInfo, bool, error) {
+	info, err := s.devices.Get(deviceKey(sessionID))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return DeviceInfo{}, false, nil
+	}
+	if err != nil {
+		return DeviceInfo{}, false, fmt.Errorf("get session device: %s: %w", sessionID, err)
+	}
+
+	return info, true, nil
+}
+
+func (s *DeviceStorage) rename(sessionID SessionID, name string) error {
+	_, err := s.devices.Update(deviceKey(sessionID), func(info *DeviceInfo) error {
+		info.Name = name
+		if info.LastActivityAt.IsZero() {
+			info.LastActivityAt = time.Now()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("rename session device: %s: %w", sessionID, err)
+	}
+
+	return nil
+}
+
+func (s *DeviceStorage) delete(sessionID SessionID) error {
+	s.mu.Lock()
+	delete(s.written, sessionID)
+	s.mu.Unlock()
+
+	if err := s.devices.Delete(deviceKey(sessionID)); err != nil {
+		return fmt.Errorf("delete session device: %s: %w", sessionID, err)
+	}
+
+	return nil
+}
+
+// changed reports whether app details differ from the ones written by the instance or the activity is outdated
+func (s *DeviceStorage) changed(sessionID SessionID, platform, version string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, ok := s.written[sessionID]
+
+	return !ok ||
+		(platform != "" && platform != info.AppPlatform) ||
+		(version != "" && version != info.AppVersion) ||
+		now.Sub(info.LastActivityAt) >= deviceActivityPrecision
+}
+
+func (s *DeviceStorage) remember(sessionID SessionID, info DeviceInfo, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.written[sessionID] = info
+
+	if now.Sub(s.lastCleanAt) > deviceCleanPeriod {
+		s.clean(now)
+	}
+}
+
+// clean forgets sessions without recent activity on the instance. Must be called under the lock.
+func (s *DeviceStorage) clean(now time.Time) {
+	for id, info := range s.written {
+		if now.Sub(info.LastActivityAt) > deviceCleanPeriod {
+			delete(s.written, id)
+		}
+	}
+
+	s.lastCleanAt = now
+}
+
+func deviceKey(sessionID SessionID) string {
+	return kvstore.Key("session", sessionID.String())
+}
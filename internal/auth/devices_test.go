@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func TestDeviceStorage(t *testing.T) {
+	bucket := kvstoretest.NewBucket()
+	storage := NewDeviceStorage(bucket)
+	sessionID := SessionID(uuid.New())
+
+	storage.track(sessionID, "ios", "1.0.0")
+	storage.track(sessionID, "", "1.1.0")
+
+	// another instance sharing the bucket sees the same details
+	shared := NewDeviceStorage(bucket)
+	info, ok, err := shared.get(sessionID)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "ios", info.AppPlatform)
+	assert.Equal(t, "1.1.0", info.AppVersion)
+
+	// the same app details aren't written again within the precision
+	assert.False(t, storage.changed(sessionID, "ios", "1.1.0", time.Now()))
+	assert.True(t, storage.changed(sessionID, "ios", "1.1.0", time.Now().Add(deviceActivityPrecision)))
+
+	require.NoError(t, shared.rename(sessionID, "Work phone"))
+	storage.track(sessionID, "android", "")
+
+	info, ok, err = storage.get(sessionID)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Work phone", info.Name)
+	assert.Equal(t, "android", info.AppPlatform)
+
+	require.NoError(t, storage.delete(sessionID))
+	_, ok, err = shared.get(sessionID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
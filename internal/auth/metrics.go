@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	metricsNamespace = "inbox_web_api"
+	metricsSubsystem = "auth"
+
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+
+	evictionReasonCapacity    = "capacity"
+	evictionReasonInvalidated = "invalidated"
+)
+
+var (
+	sessionCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "session_cache_requests_total",
+		Help:      "Number of session cache lookups partitioned by result (hit, miss)",
+	}, []string{"result"})
+
+	sessionCacheEvictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "session_cache_evictions_total",
+		Help:      "Number of sessions removed from the cache partitioned by reason",
+	}, []string{"reason"})
+
+	sessionCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "session_cache_size",
+		Help:      "Number of sessions stored in the cache",
+	})
+)
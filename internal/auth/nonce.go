@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	nonceTimeSize   = 8
+	nonceRandomSize = 8
+	nonceMACSize    = 16
+	nonceSize       = nonceTimeSize + nonceRandomSize + nonceMACSize
+)
+
+var (
+	errNonceFormat  = errors.New("nonce was not issued by the server")
+	errNonceMAC     = errors.New("nonce signature mismatch")
+	errNonceExpired = errors.New("nonce is expired")
+)
+
+type Nonce struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+// NonceIssuer generates short-lived nonces for SIWE messages. The nonce is self-contained: it holds the expiration
+// time and HMAC signature, so it can be validated by any instance without additional storage. Replay protection
+// is done by the storage on using the nonce.
+type NonceIssuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewNonceIssuer(secret string, ttl time.Duration) *NonceIssuer {
+	key := []byte(secret)
+	if len(key) == 0 {
+		log.Warn().Msg("siwe nonce secret is empty, generating random one: nonces are valid only for this instance")
+
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(fmt.Sprintf("generate nonce secret: %v", err))
+		}
+	}
+
+	return &NonceIssuer{
+		secret: key,
+		ttl:    ttl,
+	}
+}
+
+func (n *NonceIssuer) Issue() (Nonce, error) {
+	expiresAt := time.Now().Add(n.ttl).Truncate(time.Second)
+
+	raw := make([]byte, nonceSize)
+	binary.BigEndian.PutUint64(raw[:nonceTimeSize], uint64(expiresAt.Unix()))
+	if _, err := rand.Read(raw[nonceTimeSize : nonceTimeSize+nonceRandomSize]); err != nil {
+		return Nonce{}, fmt.Errorf("generate random part: %w", err)
+	}
+	copy(raw[nonceTimeSize+nonceRandomSize:], n.sign(raw[:nonceTimeSize+nonceRandomSize]))
+
+	return Nonce{
+		Value:     hex.EncodeToString(raw),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (n *NonceIssuer) Validate(nonce string) error {
+	raw, err := hex.DecodeString(nonce)
+	if err != nil || len(raw) != nonceSize {
+		return errNonceFormat
+	}
+
+	payload, mac := raw[:nonceTimeSize+nonceRandomSize], raw[nonceTimeSize+nonceRandomSize:]
+	if !hmac.Equal(mac, n.sign(payload)) {
+		return errNonceMAC
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[:nonceTimeSize])), 0)
+	if time.Now().After(expiresAt) {
+		return errNonceExpired
+	}
+
+	return nil
+}
+
+func (n *NonceIssuer) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, n.secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)[:nonceMACSize]
+}
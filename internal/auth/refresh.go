@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+var ErrRefreshTokenReused = errors.New("refresh token is already used")
+
+type usedRefreshToken struct {
+	SessionID string    `json:"session_id"`
+	UsedAt    time.Time `json:"used_at"`
+}
+
+// RefreshTokenStorage remembers ids of used refresh tokens, so each refresh token is exchanged only once.
+// The bucket has to keep ids not shorter than the TTL of refresh tokens, expired tokens are rejected anyway.
+type RefreshTokenStorage struct {
+	used *kvstore.Store[usedRefreshToken]
+}
+
+func NewRefreshTokenStorage(bucket kvstore.Bucket) *RefreshTokenStorage {
+	return &RefreshTokenStorage{
+		used: kvstore.New[usedRefreshToken](bucket),
+	}
+}
+
+// Use marks the refresh token as used, it returns ErrRefreshTokenReused if the token has been used before
+func (s *RefreshTokenStorage) Use(id string, sessionID SessionID) error {
+	_, err := s.used.Update(kvstore.Key("jti", id), func(value *usedRefreshToken) error {
+		if !value.UsedAt.IsZero() {
+			return fmt.Errorf("%w: %s", ErrRefreshTokenReused, id)
+		}
+
+		*value = usedRefreshToken{SessionID: sessionID.String(), UsedAt: time.Now()}
+
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func TestRefreshTokenStorage(t *testing.T) {
+	bucket := kvstoretest.NewBucket()
+	sessionID := SessionID(uuid.New())
+
+	require.NoError(t, NewRefreshTokenStorage(bucket).Use("first", sessionID))
+	require.NoError(t, NewRefreshTokenStorage(bucket).Use("second", sessionID))
+
+	// the used token is rejected by other instances too
+	err := NewRefreshTokenStorage(bucket).Use("first", sessionID)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+}
@@ -2,9 +2,17 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
@@ -25,14 +33,57 @@ type RegularSessionRequest struct {
 	SessionRequest
 
 	Address string
+	// GuestSessionID links the regular account with the guest one on upgrade
+	GuestSessionID *SessionID
+}
+
+// revokeOthersMaxRounds limits requests for revoking other sessions, the profile returns only last sessions
+const revokeOthersMaxRounds = 10
+
+// Targets of cache purges with invalidated sessions, they are broadcast to all instances
+const (
+	PurgeTargetSessions = "sessions"
+	PurgeTargetUsers    = "users"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// CachePurges notifies other instances about invalidated entries of local caches
+type CachePurges interface {
+	Publish(target string, ids []string) error
 }
 
 type Service struct {
-	userClient inboxapi.UserClient
+	userClient    inboxapi.UserClient
+	cache         *SessionCache
+	devices       *DeviceStorage
+	tokens        *TokenStorage
+	feedTokens    *TokenStorage
+	refreshTokens *RefreshTokenStorage
+	issuer        *AccessTokenIssuer
+	purges        CachePurges
+	admins        []string
 }
 
-func NewService(userClient inboxapi.UserClient) *Service {
-	return &Service{userClient: userClient}
+func NewService(userClient inboxapi.UserClient, cache *SessionCache, devices *DeviceStorage, tokens, feedTokens *TokenStorage, refreshTokens *RefreshTokenStorage, issuer *AccessTokenIssuer, purges CachePurges, admins []string) *Service {
+	normalized := make([]string, 0, len(admins))
+	for _, address := range admins {
+		if address = strings.TrimSpace(address); address != "" {
+			normalized = append(normalized, strings.ToLower(address))
+		}
+	}
+
+	return &Service{
+		userClient:    userClient,
+		cache:         cache,
+		devices:       devices,
+		tokens:        tokens,
+		feedTokens:    feedTokens,
+		refreshTokens: refreshTokens,
+		issuer:        issuer,
+		purges:        purges,
+		admins:        normalized,
+	}
 }
 
 func (s *Service) Guest(ctx context.Context, request GuestSessionRequest) (Info, error) {
@@ -57,13 +108,18 @@ func (s *Service) Guest(ctx context.Context, request GuestSessionRequest) (Info,
 	if err != nil {
 		return Info{}, fmt.Errorf("convert session: %w", err)
 	}
-	return Info{
-		Session:  session,
-		AuthInfo: convertToAuthInfo(resp),
-	}, nil
+	s.devices.track(session.ID, request.AppPlatform, request.AppVersion)
+
+	return s.newInfo(session, convertToAuthInfo(resp))
 }
 
 func (s *Service) Regular(ctx context.Context, request RegularSessionRequest) (Info, error) {
+	var guestSessionID *string
+	if request.GuestSessionID != nil {
+		id := request.GuestSessionID.String()
+		guestSessionID = &id
+	}
+
 	resp, err := s.userClient.CreateSession(ctx, &inboxapi.CreateSessionRequest{
 		DeviceUuid:  request.DeviceID,
 		DeviceName:  request.DeviceName,
@@ -71,7 +127,8 @@ func (s *Service) Regular(ctx context.Context, request RegularSessionRequest) (I
 		AppPlatform: request.AppPlatform,
 		Account: &inboxapi.CreateSessionRequest_Regular{
 			Regular: &inboxapi.Regular{
-				Address: request.Address,
+				Address:        request.Address,
+				GuestSessionId: guestSessionID,
 			},
 		},
 	})
@@ -87,13 +144,88 @@ func (s *Service) Regular(ctx context.Context, request RegularSessionRequest) (I
 	if err != nil {
 		return Info{}, fmt.Errorf("convert session: %w", err)
 	}
+	s.devices.track(session.ID, request.AppPlatform, request.AppVersion)
+
+	return s.newInfo(session, convertToAuthInfo(resp))
+}
+
+func (s *Service) newInfo(session Session, authInfo profile.AuthInfo) (Info, error) {
+	if s.issuer.Enabled() {
+		tokens, err := s.issuer.Issue(session, s.roleOf(authInfo.Profile))
+		if err != nil {
+			return Info{}, fmt.Errorf("issue access token: %w", err)
+		}
+
+		authInfo.Tokens = &tokens
+	}
+
 	return Info{
 		Session:  session,
-		AuthInfo: convertToAuthInfo(resp),
+		AuthInfo: authInfo,
 	}, nil
 }
 
+// VerifyAccessToken authenticates the request by the signed access token without requests to the storage
+func (s *Service) VerifyAccessToken(raw string, callback func(id UserID)) (Session, profile.Role, error) {
+	session, role, err := s.issuer.VerifyAccess(raw)
+	if err != nil {
+		return Session{}, profile.UnknownRole, err
+	}
+
+	callback(session.UserID)
+
+	return session, role, nil
+}
+
+// Refresh issues new access and refresh tokens by the refresh token if the session still exists.
+// Each refresh token is exchanged once: the reuse means the token is leaked, so the whole session is revoked.
+func (s *Service) Refresh(ctx context.Context, raw string) (profile.AccessTokens, error) {
+	claimed, tokenID, err := s.issuer.VerifyRefresh(raw)
+	if err != nil {
+		return profile.AccessTokens{}, err
+	}
+
+	err = s.refreshTokens.Use(tokenID, claimed.ID)
+	if errors.Is(err, ErrRefreshTokenReused) {
+		if lerr := s.Logout(claimed.ID); lerr != nil {
+			return profile.AccessTokens{}, fmt.Errorf("revoke session of reused refresh token: %w", lerr)
+		}
+
+		return profile.AccessTokens{}, fmt.Errorf("%w: %w", ErrInvalidAccessToken, err)
+	}
+	if err != nil {
+		return profile.AccessTokens{}, fmt.Errorf("use refresh token: %w", err)
+	}
+
+	resp, err := s.userClient.GetSession(ctx, &inboxapi.GetSessionRequest{
+		SessionId: claimed.ID.String(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return profile.AccessTokens{}, fmt.Errorf("%w: session is revoked: %s", ErrInvalidAccessToken, claimed.ID)
+	}
+	if err != nil {
+		return profile.AccessTokens{}, fmt.Errorf("get session by id: %s: %w", claimed.ID, err)
+	}
+
+	if resp.GetUser().GetId() != claimed.UserID.String() {
+		return profile.AccessTokens{}, fmt.Errorf("%w: session user mismatch: %s", ErrInvalidAccessToken, claimed.ID)
+	}
+
+	role, err := s.GetRole(claimed.UserID)
+	if err != nil {
+		return profile.AccessTokens{}, err
+	}
+
+	return s.issuer.Issue(claimed, role)
+}
+
 func (s *Service) GetSession(sessionID SessionID, callback func(id UserID)) (Session, error) {
+	if session, ok := s.cache.Get(sessionID); ok {
+		callback(session.UserID)
+
+		return session, nil
+	}
+
 	sessionResp, err := s.userClient.GetSession(context.Background(), &inboxapi.GetSessionRequest{
 		SessionId: sessionID.String(),
 	})
@@ -110,11 +242,72 @@ func (s *Service) GetSession(sessionID SessionID, callback func(id UserID)) (Ses
 		return Session{}, fmt.Errorf("convert session: %w", err)
 	}
 
+	s.cache.Add(session)
 	callback(session.UserID)
 
 	return session, nil
 }
 
+// GetTokenSession authenticates the request by the personal token. The session of the token has the same identifier
+// as the token itself, because it isn't bound to any device.
+func (s *Service) GetTokenSession(raw string, callback func(id UserID)) (Session, []Scope, error) {
+	token, err := s.tokens.Authenticate(raw)
+	if err != nil {
+		return Session{}, nil, err
+	}
+
+	callback(token.UserID)
+
+	return Session{
+		ID:     SessionID(token.ID),
+		UserID: token.UserID,
+	}, token.Scopes, nil
+}
+
+func (s *Service) CreateToken(userID UserID, name string, scopes []Scope, expiresAt *time.Time) (string, Token, error) {
+	return s.tokens.Create(userID, name, scopes, expiresAt)
+}
+
+func (s *Service) ListTokens(userID UserID) ([]Token, error) {
+	return s.tokens.List(userID)
+}
+
+func (s *Service) RevokeToken(userID UserID, id uuid.UUID) error {
+	return s.tokens.Revoke(userID, id)
+}
+
+// GetFeedTokenSession authenticates the request by the secret of the feed URL
+func (s *Service) GetFeedTokenSession(raw string) (Session, error) {
+	token, err := s.feedTokens.Authenticate(raw)
+	if err != nil {
+		return Session{}, err
+	}
+
+	return Session{
+		ID:     SessionID(token.ID),
+		UserID: token.UserID,
+	}, nil
+}
+
+func (s *Service) CreateFeedToken(userID UserID, name string) (string, Token, error) {
+	return s.feedTokens.Create(userID, name, nil, nil)
+}
+
+func (s *Service) ListFeedTokens(userID UserID) ([]Token, error) {
+	return s.feedTokens.List(userID)
+}
+
+func (s *Service) RevokeFeedToken(userID UserID, id uuid.UUID) error {
+	return s.feedTokens.Revoke(userID, id)
+}
+
+// TrackDevice stores the app details of the session from the current request
+func (s *Service) TrackDevice(sessionID SessionID, appPlatform, appVersion string) {
+	s.devices.track(sessionID, appPlatform, appVersion)
+}
+
+// Logout deletes the session. The cached session is dropped on all instances after the deletion,
+// so concurrent requests can't cache it again.
 func (s *Service) Logout(sessionID SessionID) error {
 	_, err := s.userClient.DeleteSession(context.Background(), &inboxapi.DeleteSessionRequest{
 		SessionId: sessionID.String(),
@@ -123,10 +316,23 @@ func (s *Service) Logout(sessionID SessionID) error {
 		return fmt.Errorf("delete session by id: %s: %w", sessionID, err)
 	}
 
+	if err := s.devices.delete(sessionID); err != nil {
+		log.Warn().Err(err).Msg("delete session device")
+	}
+	s.cache.DeleteSession(sessionID)
+	s.publishPurge(PurgeTargetSessions, sessionID.String())
+
 	return nil
 }
 
 func (s *Service) DeleteUser(userID UserID) error {
+	if err := s.tokens.DeleteUser(userID); err != nil {
+		return fmt.Errorf("delete user tokens: %s: %w", userID, err)
+	}
+	if err := s.feedTokens.DeleteUser(userID); err != nil {
+		return fmt.Errorf("delete user feed tokens: %s: %w", userID, err)
+	}
+
 	_, err := s.userClient.DeleteUser(context.Background(), &inboxapi.DeleteUserRequest{
 		UserId: userID.String(),
 	})
@@ -134,9 +340,38 @@ func (s *Service) DeleteUser(userID UserID) error {
 		return fmt.Errorf("delete user by id: %s: %w", userID, err)
 	}
 
+	s.cache.DeleteUser(userID)
+	s.publishPurge(PurgeTargetUsers, userID.String())
+
 	return nil
 }
 
+// PurgeCache drops sessions invalidated by other instances from the local cache
+func (s *Service) PurgeCache(target string, ids []string) {
+	for _, id := range ids {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			log.Warn().Err(err).Str("target", target).Str("id", id).Msg("purge session cache")
+
+			continue
+		}
+
+		switch target {
+		case PurgeTargetSessions:
+			s.cache.DeleteSession(SessionID(parsed))
+		case PurgeTargetUsers:
+			s.cache.DeleteUser(UserID(parsed))
+		}
+	}
+}
+
+// publishPurge notifies other instances, their cached sessions expire by the cache TTL if it fails
+func (s *Service) publishPurge(target, id string) {
+	if err := s.purges.Publish(target, []string{id}); err != nil {
+		log.Error().Err(err).Str("target", target).Str("id", id).Msg("publish session cache purge")
+	}
+}
+
 func (s *Service) GetProfileInfo(userID UserID) (profile.Profile, error) {
 	resp, err := s.userClient.GetUserProfile(context.Background(), &inboxapi.GetUserProfileRequest{
 		UserId: userID.String(),
@@ -145,7 +380,139 @@ func (s *Service) GetProfileInfo(userID UserID) (profile.Profile, error) {
 		return profile.Profile{}, fmt.Errorf("get profile info by user id: %s: %w", userID, err)
 	}
 
-	return convertToProfileInfo(resp), nil
+	profileInfo := convertToProfileInfo(resp)
+	s.enrichSessions(profileInfo.LastSessions)
+
+	return profileInfo, nil
+}
+
+// GetRole returns the role of the user including the admin one
+func (s *Service) GetRole(userID UserID) (profile.Role, error) {
+	profileInfo, err := s.GetProfileInfo(userID)
+	if err != nil {
+		return profile.UnknownRole, err
+	}
+
+	return s.roleOf(profileInfo), nil
+}
+
+func (s *Service) roleOf(profileInfo profile.Profile) profile.Role {
+	address := profileInfo.GetAddress()
+	if profileInfo.Role == profile.RegularRole && address != nil && slices.Contains(s.admins, strings.ToLower(*address)) {
+		return profile.AdminRole
+	}
+
+	return profileInfo.Role
+}
+
+func (s *Service) ListSessions(userID UserID) ([]profile.Session, error) {
+	profileInfo, err := s.GetProfileInfo(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return profileInfo.LastSessions, nil
+}
+
+// RevokeSession deletes the session of the user. Returns ErrSessionNotFound if the session belongs to another user.
+func (s *Service) RevokeSession(ctx context.Context, userID UserID, sessionID SessionID) error {
+	if err := s.checkOwner(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	return s.Logout(sessionID)
+}
+
+// RenameSession sets the name shown instead of the device name. Returns ErrSessionNotFound if the session belongs
+// to another user.
+func (s *Service) RenameSession(ctx context.Context, userID UserID, sessionID SessionID, name string) error {
+	if err := s.checkOwner(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	return s.devices.rename(sessionID, name)
+}
+
+func (s *Service) checkOwner(ctx context.Context, userID UserID, sessionID SessionID) error {
+	resp, err := s.userClient.GetSession(ctx, &inboxapi.GetSessionRequest{
+		SessionId: sessionID.String(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+	}
+	if err != nil {
+		return fmt.Errorf("get session by id: %s: %w", sessionID, err)
+	}
+
+	if resp.GetUser().GetId() != userID.String() {
+		return fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions deletes all sessions of the user except the current one and returns the number of revoked sessions
+func (s *Service) RevokeOtherSessions(userID UserID, current SessionID) (int, error) {
+	revoked := 0
+	for range revokeOthersMaxRounds {
+		sessions, err := s.ListSessions(userID)
+		if err != nil {
+			return revoked, err
+		}
+
+		others := 0
+		for _, session := range sessions {
+			if session.ID == current.String() {
+				continue
+			}
+
+			sessionUUID, err := uuid.Parse(session.ID)
+			if err != nil {
+				return revoked, fmt.Errorf("parse session id: %s: %w", session.ID, err)
+			}
+
+			if err := s.Logout(SessionID(sessionUUID)); err != nil {
+				return revoked, err
+			}
+
+			others++
+		}
+
+		revoked += others
+		if others == 0 {
+			break
+		}
+	}
+
+	return revoked, nil
+}
+
+func (s *Service) enrichSessions(sessions []profile.Session) {
+	for i := range sessions {
+		sessionUUID, err := uuid.Parse(sessions[i].ID)
+		if err != nil {
+			continue
+		}
+
+		info, ok, err := s.devices.get(SessionID(sessionUUID))
+		if err != nil {
+			log.Warn().Err(err).Msg("enrich session")
+
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		if info.Name != "" {
+			sessions[i].DeviceName = info.Name
+		}
+		sessions[i].AppPlatform = info.AppPlatform
+		sessions[i].AppVersion = info.AppVersion
+		if sessions[i].LastActivityAt == nil || sessions[i].LastActivityAt.AsTime().Before(info.LastActivityAt) {
+			sessions[i].LastActivityAt = common.NewTime(info.LastActivityAt)
+		}
+	}
 }
 
 func (s *Service) GetUserInfo(address string) (profile.PublicProfile, error) {
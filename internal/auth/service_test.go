@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+type userClientMock struct {
+	inboxapi.UserClient
+
+	deleteErr error
+	owner     UserID
+	// cached is called during the deletion to check that the session isn't dropped before it
+	cached func()
+}
+
+func (m userClientMock) DeleteSession(context.Context, *inboxapi.DeleteSessionRequest, ...grpc.CallOption) (*emptypb.Empty, error) {
+	if m.cached != nil {
+		m.cached()
+	}
+
+	return &emptypb.Empty{}, m.deleteErr
+}
+
+func (m userClientMock) GetSession(context.Context, *inboxapi.GetSessionRequest, ...grpc.CallOption) (*inboxapi.GetSessionResponse, error) {
+	return &inboxapi.GetSessionResponse{User: &inboxapi.UserInfo{Id: m.owner.String()}}, nil
+}
+
+type purgesMock struct {
+	published map[string][]string
+}
+
+func (m *purgesMock) Publish(target string, ids []string) error {
+	m.published[target] = append(m.published[target], ids...)
+
+	return nil
+}
+
+func TestServiceLogout(t *testing.T) {
+	session := newTestSession(UserID(uuid.New()))
+
+	t.Run("failed deletion keeps the session", func(t *testing.T) {
+		purges := &purgesMock{published: map[string][]string{}}
+		service := NewService(userClientMock{deleteErr: errors.New("unavailable")}, NewSessionCache(time.Minute, 10), NewDeviceStorage(kvstoretest.NewBucket()), nil, nil, nil, nil, purges, nil)
+		service.cache.Add(session)
+
+		require.Error(t, service.Logout(session.ID))
+
+		_, ok := service.cache.Get(session.ID)
+		assert.True(t, ok)
+		assert.Empty(t, purges.published)
+	})
+
+	t.Run("session is dropped on all instances after the deletion", func(t *testing.T) {
+		purges := &purgesMock{published: map[string][]string{}}
+		cache := NewSessionCache(time.Minute, 10)
+		cache.Add(session)
+		service := NewService(userClientMock{cached: func() {
+			_, ok := cache.Get(session.ID)
+			assert.True(t, ok)
+		}}, cache, NewDeviceStorage(kvstoretest.NewBucket()), nil, nil, nil, nil, purges, nil)
+
+		require.NoError(t, service.Logout(session.ID))
+
+		_, ok := cache.Get(session.ID)
+		assert.False(t, ok)
+		assert.Equal(t, map[string][]string{PurgeTargetSessions: {session.ID.String()}}, purges.published)
+	})
+}
+
+func TestServicePurgeCache(t *testing.T) {
+	userID := UserID(uuid.New())
+	first, second := newTestSession(userID), newTestSession(userID)
+	other := newTestSession(UserID(uuid.New()))
+
+	service := NewService(nil, NewSessionCache(time.Minute, 10), nil, nil, nil, nil, nil, nil, nil)
+	for _, session := range []Session{first, second, other} {
+		service.cache.Add(session)
+	}
+
+	service.PurgeCache(PurgeTargetSessions, []string{first.ID.String(), "wrong"})
+	_, ok := service.cache.Get(first.ID)
+	assert.False(t, ok)
+	_, ok = service.cache.Get(second.ID)
+	assert.True(t, ok)
+
+	service.PurgeCache(PurgeTargetUsers, []string{userID.String()})
+	_, ok = service.cache.Get(second.ID)
+	assert.False(t, ok)
+
+	// targets of other caches are ignored
+	service.PurgeCache("proposal", []string{other.UserID.String()})
+	_, ok = service.cache.Get(other.ID)
+	assert.True(t, ok)
+}
+
+func TestServiceRenameSession(t *testing.T) {
+	owner := UserID(uuid.New())
+	sessionID := SessionID(uuid.New())
+	devices := NewDeviceStorage(kvstoretest.NewBucket())
+	service := NewService(userClientMock{owner: owner}, NewSessionCache(time.Minute, 10), devices, nil, nil, nil, nil, nil, nil)
+
+	err := service.RenameSession(context.Background(), UserID(uuid.New()), sessionID, "Lost phone")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+
+	require.NoError(t, service.RenameSession(context.Background(), owner, sessionID, "Lost phone"))
+
+	sessions := []profile.Session{{ID: sessionID.String(), DeviceName: "iPhone"}}
+	service.enrichSessions(sessions)
+	assert.Equal(t, "Lost phone", sessions[0].DeviceName)
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rs/zerolog/log"
+	"github.com/spruceid/siwe-go"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/chain"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+)
+
+const (
+	// allowedClockSkew covers the difference between client and server clocks for issued at field
+	allowedClockSkew = 5 * time.Minute
+
+	// ecdsaSignatureLength is the length of r, s, v signature produced by externally owned accounts
+	ecdsaSignatureLength = 65
+)
+
+var (
+	ErrSiweDomain      = errors.New("siwe domain is not allowed")
+	ErrSiweURI         = errors.New("siwe uri is not allowed")
+	ErrSiweChainID     = errors.New("siwe chain id is not allowed")
+	ErrSiweNonce       = errors.New("siwe nonce is not valid")
+	ErrSiweIssuedAt    = errors.New("siwe issued at is not valid")
+	ErrSiweExpired     = errors.New("siwe message is expired")
+	ErrSiweNotYetValid = errors.New("siwe message is not yet valid")
+	ErrSiweSignature   = errors.New("siwe signature is not valid")
+	ErrSiweAddress     = errors.New("address is not related to sign")
+)
+
+// ContractSignatureValidator checks signatures of smart contract accounts by ERC-1271
+type ContractSignatureValidator interface {
+	IsValidSignature(ctx context.Context, preferred chain.ChainID, address common.Address, hash common.Hash, signature []byte) (bool, error)
+}
+
+type SiweVerifier struct {
+	cfg       config.Siwe
+	nonces    *NonceIssuer
+	uris      []*url.URL
+	contracts ContractSignatureValidator
+}
+
+func NewSiweVerifier(cfg config.Siwe, contracts ContractSignatureValidator) (*SiweVerifier, error) {
+	uris := make([]*url.URL, 0, len(cfg.AllowedURIs))
+	for _, raw := range cfg.AllowedURIs {
+		parsed, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("parse allowed siwe uri: %s: %w", raw, err)
+		}
+
+		uris = append(uris, parsed)
+	}
+
+	if !cfg.StrictNonce {
+		log.Warn().Msg("strict siwe nonce is disabled, nonces which were not issued by the server are accepted")
+	}
+
+	return &SiweVerifier{
+		cfg:       cfg,
+		nonces:    NewNonceIssuer(cfg.NonceSecret, cfg.NonceTTL),
+		uris:      uris,
+		contracts: contracts,
+	}, nil
+}
+
+func (v *SiweVerifier) IssueNonce() (Nonce, error) {
+	return v.nonces.Issue()
+}
+
+// Verify checks the message fields against the configuration and the signature against the address.
+// Returns the issued at time of the message, it's used as the base for the nonce expiration.
+func (v *SiweVerifier) Verify(ctx context.Context, msg *siwe.Message, signature string, address common.Address) (time.Time, error) {
+	issuedAt, err := time.Parse(time.RFC3339, msg.GetIssuedAt())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrSiweIssuedAt, msg.GetIssuedAt())
+	}
+
+	now := time.Now()
+	if now.Sub(issuedAt) > v.cfg.TTL {
+		return time.Time{}, fmt.Errorf("%w: issued at %s", ErrSiweExpired, issuedAt)
+	}
+
+	if issuedAt.Sub(now) > allowedClockSkew {
+		return time.Time{}, fmt.Errorf("%w: issued in the future: %s", ErrSiweIssuedAt, issuedAt)
+	}
+
+	if _, err := msg.ValidAt(now); err != nil {
+		var expired *siwe.ExpiredMessage
+		if errors.As(err, &expired) {
+			return time.Time{}, fmt.Errorf("%w: %s", ErrSiweExpired, err.Error())
+		}
+
+		return time.Time{}, fmt.Errorf("%w: %s", ErrSiweNotYetValid, err.Error())
+	}
+
+	if err := v.verifyDomain(msg); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := v.verifyURI(msg); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := v.verifyChainID(msg); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := v.verifyNonce(msg); err != nil {
+		return time.Time{}, err
+	}
+
+	if msg.GetAddress() != address {
+		return time.Time{}, fmt.Errorf("%w: message address %s", ErrSiweAddress, msg.GetAddress().Hex())
+	}
+
+	if err := v.verifySignature(ctx, msg, signature); err != nil {
+		return time.Time{}, err
+	}
+
+	return issuedAt, nil
+}
+
+// verifySignature recovers the signer by ECDSA and falls back to ERC-1271 check for smart contract accounts
+// like Safe, because they can't produce ECDSA signature by themselves.
+func (v *SiweVerifier) verifySignature(ctx context.Context, msg *siwe.Message, signature string) error {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return fmt.Errorf("%w: decode signature: %s", ErrSiweSignature, err.Error())
+	}
+
+	var ecdsaErr error
+	if len(sig) == ecdsaSignatureLength {
+		if _, ecdsaErr = msg.VerifyEIP191(signature); ecdsaErr == nil {
+			return nil
+		}
+	}
+
+	hash := accounts.TextHash([]byte(msg.String()))
+	valid, err := v.contracts.IsValidSignature(ctx, chain.ChainID(msg.GetChainID()), msg.GetAddress(), common.BytesToHash(hash), sig)
+	if err != nil {
+		// chains are unreachable, the signature isn't rejected
+		return fmt.Errorf("erc1271: %w", err)
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: ecdsa: %v: erc1271: invalid", ErrSiweSignature, ecdsaErr)
+	}
+
+	return nil
+}
+
+func (v *SiweVerifier) verifyDomain(msg *siwe.Message) error {
+	if len(v.cfg.AllowedDomains) == 0 {
+		return nil
+	}
+
+	if !slices.Contains(v.cfg.AllowedDomains, msg.GetDomain()) {
+		return fmt.Errorf("%w: %s", ErrSiweDomain, msg.GetDomain())
+	}
+
+	return nil
+}
+
+func (v *SiweVerifier) verifyURI(msg *siwe.Message) error {
+	if len(v.uris) == 0 {
+		return nil
+	}
+
+	uri := msg.GetURI()
+	for _, allowed := range v.uris {
+		if uri.Scheme == allowed.Scheme && uri.Host == allowed.Host && strings.HasPrefix(uri.Path, allowed.Path) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrSiweURI, uri.String())
+}
+
+func (v *SiweVerifier) verifyChainID(msg *siwe.Message) error {
+	if len(v.cfg.AllowedChainIDs) == 0 {
+		return nil
+	}
+
+	if !slices.Contains(v.cfg.AllowedChainIDs, msg.GetChainID()) {
+		return fmt.Errorf("%w: %d", ErrSiweChainID, msg.GetChainID())
+	}
+
+	return nil
+}
+
+func (v *SiweVerifier) verifyNonce(msg *siwe.Message) error {
+	err := v.nonces.Validate(msg.GetNonce())
+	if err == nil {
+		return nil
+	}
+
+	// todo: remove it when all clients request the nonce from the server
+	if errors.Is(err, errNonceFormat) && !v.cfg.StrictNonce {
+		log.Warn().Str("nonce", msg.GetNonce()).Msg("siwe nonce is not issued by the server")
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrSiweNonce, err.Error())
+}
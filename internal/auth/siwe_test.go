@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spruceid/siwe-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/chain"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+)
+
+type contractValidatorMock struct {
+	address   common.Address
+	signature []byte
+	err       error
+}
+
+func (m contractValidatorMock) IsValidSignature(_ context.Context, _ chain.ChainID, address common.Address, _ common.Hash, signature []byte) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+
+	return address == m.address && string(signature) == string(m.signature), nil
+}
+
+type siweTestCase struct {
+	domain   string
+	uri      string
+	chainID  int
+	nonce    string
+	issuedAt time.Time
+}
+
+func signSiwe(t *testing.T, key *ecdsa.PrivateKey, tc siweTestCase) (*siwe.Message, string) {
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	msg, err := siwe.InitMessage(tc.domain, address.Hex(), tc.uri, tc.nonce, map[string]interface{}{
+		"chainId":  tc.chainID,
+		"issuedAt": tc.issuedAt.UTC().Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(msg.String())), key)
+	require.NoError(t, err)
+	sig[64] += 27
+
+	return msg, hexutil.Encode(sig)
+}
+
+func TestSiweVerifier(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	verifier, err := NewSiweVerifier(config.Siwe{
+		TTL:             time.Hour,
+		NonceTTL:        time.Minute,
+		NonceSecret:     "secret",
+		StrictNonce:     true,
+		AllowedDomains:  []string{"app.goverland.xyz"},
+		AllowedURIs:     []string{"https://app.goverland.xyz"},
+		AllowedChainIDs: []int{1},
+	}, contractValidatorMock{})
+	require.NoError(t, err)
+
+	nonce, err := verifier.IssueNonce()
+	require.NoError(t, err)
+
+	valid := siweTestCase{
+		domain:   "app.goverland.xyz",
+		uri:      "https://app.goverland.xyz/login",
+		chainID:  1,
+		nonce:    nonce.Value,
+		issuedAt: time.Now(),
+	}
+
+	for name, tc := range map[string]struct {
+		modify  func(tc siweTestCase) siweTestCase
+		address common.Address
+		want    error
+	}{
+		"valid message": {
+			modify:  func(tc siweTestCase) siweTestCase { return tc },
+			address: address,
+		},
+		"wrong domain": {
+			modify:  func(tc siweTestCase) siweTestCase { tc.domain = "evil.xyz"; return tc },
+			address: address,
+			want:    ErrSiweDomain,
+		},
+		"wrong uri": {
+			modify:  func(tc siweTestCase) siweTestCase { tc.uri = "https://evil.xyz/login"; return tc },
+			address: address,
+			want:    ErrSiweURI,
+		},
+		"wrong chain id": {
+			modify:  func(tc siweTestCase) siweTestCase { tc.chainID = 100; return tc },
+			address: address,
+			want:    ErrSiweChainID,
+		},
+		"client generated nonce": {
+			modify:  func(tc siweTestCase) siweTestCase { tc.nonce = siwe.GenerateNonce(); return tc },
+			address: address,
+			want:    ErrSiweNonce,
+		},
+		"expired message": {
+			modify:  func(tc siweTestCase) siweTestCase { tc.issuedAt = time.Now().Add(-2 * time.Hour); return tc },
+			address: address,
+			want:    ErrSiweExpired,
+		},
+		"issued in the future": {
+			modify:  func(tc siweTestCase) siweTestCase { tc.issuedAt = time.Now().Add(time.Hour); return tc },
+			address: address,
+			want:    ErrSiweIssuedAt,
+		},
+		"another address": {
+			modify:  func(tc siweTestCase) siweTestCase { return tc },
+			address: common.HexToAddress("0x91e2E2D26076C8A1EaDb69273605c16ef01928ce"),
+			want:    ErrSiweAddress,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			msg, sig := signSiwe(t, key, tc.modify(valid))
+
+			_, err := verifier.Verify(context.Background(), msg, sig, tc.address)
+			if tc.want == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestSiweVerifierContractSignature(t *testing.T) {
+	wallet := common.HexToAddress("0x91e2E2D26076C8A1EaDb69273605c16ef01928ce")
+	signature := []byte{0x01, 0x02, 0x03}
+
+	verifier, err := NewSiweVerifier(config.Siwe{TTL: time.Hour}, contractValidatorMock{
+		address:   wallet,
+		signature: signature,
+	})
+	require.NoError(t, err)
+
+	msg, err := siwe.InitMessage("app.goverland.xyz", wallet.Hex(), "https://app.goverland.xyz", siwe.GenerateNonce(), map[string]interface{}{
+		"chainId":  1,
+		"issuedAt": time.Now().UTC().Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), msg, hexutil.Encode(signature), wallet)
+	assert.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), msg, hexutil.Encode([]byte{0x04}), wallet)
+	assert.ErrorIs(t, err, ErrSiweSignature)
+
+	// unreachable chains don't reject the signature
+	unreachable, err := NewSiweVerifier(config.Siwe{TTL: time.Hour}, contractValidatorMock{err: chain.ErrChainRequestUnreachable})
+	require.NoError(t, err)
+
+	_, err = unreachable.Verify(context.Background(), msg, hexutil.Encode(signature), wallet)
+	assert.ErrorIs(t, err, chain.ErrChainRequestUnreachable)
+	assert.NotErrorIs(t, err, ErrSiweSignature)
+}
+
+func TestNonceIssuer(t *testing.T) {
+	issuer := NewNonceIssuer("secret", time.Minute)
+
+	nonce, err := issuer.Issue()
+	require.NoError(t, err)
+	assert.NoError(t, issuer.Validate(nonce.Value))
+
+	assert.ErrorIs(t, NewNonceIssuer("another", time.Minute).Validate(nonce.Value), errNonceMAC)
+	assert.ErrorIs(t, issuer.Validate("abcdefgh12345678"), errNonceFormat)
+
+	expired, err := NewNonceIssuer("secret", -time.Minute).Issue()
+	require.NoError(t, err)
+	assert.ErrorIs(t, issuer.Validate(expired.Value), errNonceExpired)
+}
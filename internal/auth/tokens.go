@@ -0,0 +1,267 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+type Scope string
+
+const (
+	ScopeFeedRead           Scope = "feed:read"
+	ScopeSubscriptionsRead  Scope = "subscriptions:read"
+	ScopeSubscriptionsWrite Scope = "subscriptions:write"
+	ScopeVotePrepare        Scope = "vote:prepare"
+)
+
+var Scopes = []Scope{
+	ScopeFeedRead,
+	ScopeSubscriptionsRead,
+	ScopeSubscriptionsWrite,
+	ScopeVotePrepare,
+}
+
+const (
+	personalTokenPrefix = "gvt_"
+	feedTokenPrefix     = "gvf_"
+	personalTokenSize   = 32
+
+	// MaxPersonalTokens limits the number of personal tokens per user
+	MaxPersonalTokens = 20
+)
+
+var (
+	ErrTokenNotFound = errors.New("token not found")
+	ErrTokenExpired  = errors.New("token is expired")
+	ErrTokensLimit   = errors.New("tokens limit is reached")
+)
+
+// IsPersonalToken checks if the raw authorization value is the personal token instead of the session id
+func IsPersonalToken(raw string) bool {
+	return strings.HasPrefix(raw, personalTokenPrefix)
+}
+
+type Token struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     UserID     `json:"user_id"`
+	Name       string     `json:"name"`
+	Scopes     []Scope    `json:"scopes"`
+	Hash       string     `json:"hash"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+func (t Token) HasScope(scope Scope) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+func (t Token) expired(now time.Time) bool {
+	return t.ExpiresAt != nil && now.After(*t.ExpiresAt)
+}
+
+// lastUsedPrecision limits writes of the last usage time, it's updated at most once per the interval
+const lastUsedPrecision = time.Minute
+
+// tokenRef finds the owner of the token by its hash
+type tokenRef struct {
+	UserID UserID    `json:"user_id"`
+	ID     uuid.UUID `json:"id"`
+}
+
+// TokenStorage keeps personal tokens of users in the bucket shared by all instances. Only SHA-256 hashes of tokens
+// are stored, the raw value is returned once on creation.
+type TokenStorage struct {
+	prefix string
+	tokens *kvstore.Store[[]Token]
+	refs   *kvstore.Store[tokenRef]
+}
+
+func NewTokenStorage(bucket kvstore.Bucket) *TokenStorage {
+	return newTokenStorage(bucket, personalTokenPrefix)
+}
+
+// NewFeedTokenStorage creates the storage of secrets for feed URLs. They are kept apart from personal tokens,
+// so they can't be used for the Authorization header.
+func NewFeedTokenStorage(bucket kvstore.Bucket) *TokenStorage {
+	return newTokenStorage(bucket, feedTokenPrefix)
+}
+
+func newTokenStorage(bucket kvstore.Bucket, prefix string) *TokenStorage {
+	return &TokenStorage{
+		prefix: prefix,
+		tokens: kvstore.New[[]Token](bucket),
+		refs:   kvstore.New[tokenRef](bucket),
+	}
+}
+
+// Create generates the new token and returns its raw value
+func (s *TokenStorage) Create(userID UserID, name string, scopes []Scope, expiresAt *time.Time) (string, Token, error) {
+	secret := make([]byte, personalTokenSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", Token{}, fmt.Errorf("generate token: %w", err)
+	}
+	raw := s.prefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	token := Token{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Scopes:    scopes,
+		Hash:      hashToken(raw),
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	_, err := s.tokens.Update(userTokensKey(userID), func(list *[]Token) error {
+		if len(*list) >= MaxPersonalTokens {
+			return ErrTokensLimit
+		}
+
+		*list = append(*list, token)
+
+		return nil
+	})
+	if err != nil {
+		return "", Token{}, err
+	}
+
+	// the token isn't accepted until the reference is stored, so it's safe to fail here
+	if err := s.refs.Put(tokenRefKey(token.Hash), tokenRef{UserID: userID, ID: token.ID}); err != nil {
+		return "", Token{}, err
+	}
+
+	return raw, token, nil
+}
+
+func (s *TokenStorage) List(userID UserID) ([]Token, error) {
+	list, err := s.tokens.Get(userTokensKey(userID))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return []Token{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(list, func(a, b Token) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return list, nil
+}
+
+func (s *TokenStorage) Revoke(userID UserID, id uuid.UUID) error {
+	list, err := s.List(userID)
+	if err != nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(list, func(t Token) bool { return t.ID == id })
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", ErrTokenNotFound, id)
+	}
+
+	// the reference is removed first, so the token isn't accepted even if removing it from the list fails
+	if err := s.refs.Delete(tokenRefKey(list[idx].Hash)); err != nil {
+		return err
+	}
+
+	_, err = s.tokens.Update(userTokensKey(userID), func(list *[]Token) error {
+		*list = slices.DeleteFunc(*list, func(t Token) bool { return t.ID == id })
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *TokenStorage) DeleteUser(userID UserID) error {
+	list, err := s.List(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range list {
+		if err := s.refs.Delete(tokenRefKey(token.Hash)); err != nil {
+			return err
+		}
+	}
+
+	return s.tokens.Delete(userTokensKey(userID))
+}
+
+// Authenticate finds the token by the raw value and updates the last usage time
+func (s *TokenStorage) Authenticate(raw string) (Token, error) {
+	ref, err := s.refs.Get(tokenRefKey(hashToken(raw)))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return Token{}, ErrTokenNotFound
+	}
+	if err != nil {
+		return Token{}, err
+	}
+
+	list, err := s.List(ref.UserID)
+	if err != nil {
+		return Token{}, err
+	}
+
+	idx := slices.IndexFunc(list, func(t Token) bool { return t.ID == ref.ID })
+	if idx < 0 {
+		return Token{}, ErrTokenNotFound
+	}
+
+	now := time.Now()
+	token := list[idx]
+	if token.expired(now) {
+		return Token{}, fmt.Errorf("%w: %s", ErrTokenExpired, token.ID)
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedPrecision {
+		token.LastUsedAt = &now
+		s.touch(token)
+	}
+
+	return token, nil
+}
+
+// touch stores the last usage time, the token is already authenticated, so errors are logged only
+func (s *TokenStorage) touch(token Token) {
+	_, err := s.tokens.Update(userTokensKey(token.UserID), func(list *[]Token) error {
+		for i := range *list {
+			if (*list)[i].ID == token.ID {
+				(*list)[i].LastUsedAt = token.LastUsedAt
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("token_id", token.ID.String()).Msg("update token usage")
+	}
+}
+
+func userTokensKey(userID UserID) string {
+	return kvstore.Key("user", userID.String())
+}
+
+func tokenRefKey(hash string) string {
+	return kvstore.Key("hash", hash)
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func TestTokenStorage(t *testing.T) {
+	userID := UserID(uuid.New())
+
+	t.Run("authenticate by raw token", func(t *testing.T) {
+		storage := NewTokenStorage(kvstoretest.NewBucket())
+
+		raw, created, err := storage.Create(userID, "bot", []Scope{ScopeFeedRead}, nil)
+		require.NoError(t, err)
+		assert.True(t, IsPersonalToken(raw))
+		assert.NotContains(t, created.Hash, raw)
+
+		token, err := storage.Authenticate(raw)
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, token.ID)
+		assert.True(t, token.HasScope(ScopeFeedRead))
+		assert.False(t, token.HasScope(ScopeSubscriptionsWrite))
+		assert.NotNil(t, token.LastUsedAt)
+
+		_, err = storage.Authenticate(raw + "x")
+		assert.ErrorIs(t, err, ErrTokenNotFound)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		storage := NewTokenStorage(kvstoretest.NewBucket())
+
+		expiresAt := time.Now().Add(-time.Minute)
+		raw, _, err := storage.Create(userID, "bot", []Scope{ScopeFeedRead}, &expiresAt)
+		require.NoError(t, err)
+
+		_, err = storage.Authenticate(raw)
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("revoke only own token", func(t *testing.T) {
+		storage := NewTokenStorage(kvstoretest.NewBucket())
+
+		raw, token, err := storage.Create(userID, "bot", []Scope{ScopeFeedRead}, nil)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, storage.Revoke(UserID(uuid.New()), token.ID), ErrTokenNotFound)
+		require.NoError(t, storage.Revoke(userID, token.ID))
+
+		_, err = storage.Authenticate(raw)
+		assert.ErrorIs(t, err, ErrTokenNotFound)
+
+		list, err := storage.List(userID)
+		require.NoError(t, err)
+		assert.Empty(t, list)
+	})
+
+	t.Run("tokens are shared between instances", func(t *testing.T) {
+		bucket := kvstoretest.NewBucket()
+		storage := NewTokenStorage(bucket)
+
+		raw, _, err := storage.Create(userID, "bot", []Scope{ScopeVotePrepare}, nil)
+		require.NoError(t, err)
+
+		token, err := NewTokenStorage(bucket).Authenticate(raw)
+		require.NoError(t, err)
+		assert.Equal(t, userID, token.UserID)
+		assert.Equal(t, []Scope{ScopeVotePrepare}, token.Scopes)
+
+		list, err := storage.List(userID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.NotNil(t, list[0].LastUsedAt)
+	})
+
+	t.Run("tokens limit", func(t *testing.T) {
+		storage := NewTokenStorage(kvstoretest.NewBucket())
+		for i := 0; i < MaxPersonalTokens; i++ {
+			_, _, err := storage.Create(userID, "bot", []Scope{ScopeFeedRead}, nil)
+			require.NoError(t, err)
+		}
+
+		_, _, err := storage.Create(userID, "bot", []Scope{ScopeFeedRead}, nil)
+		assert.ErrorIs(t, err, ErrTokensLimit)
+	})
+}
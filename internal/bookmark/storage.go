@@ -0,0 +1,159 @@
+package bookmark
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+// MaxBookmarks limits the number of bookmarked proposals per user
+const MaxBookmarks = 1000
+
+var (
+	ErrNotFound = errors.New("bookmark not found")
+	ErrLimit    = errors.New("bookmarks limit is reached")
+)
+
+type Bookmark struct {
+	UserID     auth.UserID `json:"user_id"`
+	ProposalID string      `json:"proposal_id"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// Storage keeps bookmarked proposals in the bucket shared by all instances, bookmarks of the user are stored together
+type Storage struct {
+	items *kvstore.Store[[]Bookmark]
+}
+
+func NewStorage(bucket kvstore.Bucket) *Storage {
+	return &Storage{
+		items: kvstore.New[[]Bookmark](bucket),
+	}
+}
+
+// Add bookmarks the proposal, bookmarking the same proposal again keeps the original time
+func (s *Storage) Add(userID auth.UserID, proposalID string) (Bookmark, error) {
+	var bookmark Bookmark
+	_, err := s.items.Update(userKey(userID), func(list *[]Bookmark) error {
+		if idx := index(*list, proposalID); idx >= 0 {
+			bookmark = (*list)[idx]
+
+			return kvstore.ErrUnchanged
+		}
+
+		if len(*list) >= MaxBookmarks {
+			return ErrLimit
+		}
+
+		bookmark = Bookmark{
+			UserID:     userID,
+			ProposalID: proposalID,
+			CreatedAt:  time.Now(),
+		}
+		*list = append([]Bookmark{bookmark}, *list...)
+
+		return nil
+	})
+	if err != nil {
+		return Bookmark{}, err
+	}
+
+	return bookmark, nil
+}
+
+func (s *Storage) Remove(userID auth.UserID, proposalID string) error {
+	_, err := s.items.Update(userKey(userID), func(list *[]Bookmark) error {
+		idx := index(*list, proposalID)
+		if idx < 0 {
+			return fmt.Errorf("%w: %s", ErrNotFound, proposalID)
+		}
+
+		*list = slices.Delete(*list, idx, idx+1)
+
+		return nil
+	})
+
+	return err
+}
+
+// List returns bookmarks of the user, the last bookmarked proposals go first
+func (s *Storage) List(userID auth.UserID) ([]Bookmark, error) {
+	list, err := s.items.Get(userKey(userID))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return []Bookmark{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Bookmarked returns the set of bookmarked proposals among the ids
+func (s *Storage) Bookmarked(userID auth.UserID, ids ...string) (map[string]bool, error) {
+	list, err := s.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool)
+	for _, bookmark := range list {
+		if slices.Contains(ids, bookmark.ProposalID) {
+			result[bookmark.ProposalID] = true
+		}
+	}
+
+	return result, nil
+}
+
+// Move merges bookmarks of the user into bookmarks of another one, the earlier bookmarks are kept within the limit
+func (s *Storage) Move(from, to auth.UserID) error {
+	moved, err := s.List(from)
+	if err != nil {
+		return err
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+
+	_, err = s.items.Update(userKey(to), func(list *[]Bookmark) error {
+		for _, bookmark := range moved {
+			if index(*list, bookmark.ProposalID) < 0 {
+				bookmark.UserID = to
+				*list = append(*list, bookmark)
+			}
+		}
+
+		slices.SortStableFunc(*list, func(a, b Bookmark) int {
+			return b.CreatedAt.Compare(a.CreatedAt)
+		})
+		if len(*list) > MaxBookmarks {
+			*list = (*list)[len(*list)-MaxBookmarks:]
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.DeleteUser(from)
+}
+
+func (s *Storage) DeleteUser(userID auth.UserID) error {
+	return s.items.Delete(userKey(userID))
+}
+
+func index(list []Bookmark, proposalID string) int {
+	return slices.IndexFunc(list, func(b Bookmark) bool {
+		return b.ProposalID == proposalID
+	})
+}
+
+func userKey(userID auth.UserID) string {
+	return kvstore.Key("user", userID.String())
+}
@@ -0,0 +1,76 @@
+package bookmark
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func TestStorage(t *testing.T) {
+	bucket := kvstoretest.NewBucket()
+	storage := NewStorage(bucket)
+
+	userID, other := auth.UserID(uuid.New()), auth.UserID(uuid.New())
+
+	first, err := storage.Add(userID, "0x1")
+	require.NoError(t, err)
+	_, err = storage.Add(userID, "0x2")
+	require.NoError(t, err)
+	_, err = storage.Add(other, "0x3")
+	require.NoError(t, err)
+
+	again, err := storage.Add(userID, "0x1")
+	require.NoError(t, err)
+	assert.True(t, first.CreatedAt.Equal(again.CreatedAt))
+
+	bookmarked, err := storage.Bookmarked(userID, "0x2", "0x3")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"0x2": true}, bookmarked)
+
+	require.NoError(t, storage.Remove(userID, "0x2"))
+	assert.ErrorIs(t, storage.Remove(userID, "0x2"), ErrNotFound)
+
+	// bookmarks are shared between instances
+	shared := NewStorage(bucket)
+
+	list, err := shared.List(userID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "0x1", list[0].ProposalID)
+
+	require.NoError(t, shared.DeleteUser(other))
+	list, err = storage.List(other)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestStorageMove(t *testing.T) {
+	storage := NewStorage(kvstoretest.NewBucket())
+
+	guest, regular := auth.UserID(uuid.New()), auth.UserID(uuid.New())
+
+	_, err := storage.Add(regular, "0x1")
+	require.NoError(t, err)
+	_, err = storage.Add(guest, "0x1")
+	require.NoError(t, err)
+	_, err = storage.Add(guest, "0x2")
+	require.NoError(t, err)
+
+	require.NoError(t, storage.Move(guest, regular))
+
+	list, err := storage.List(regular)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "0x2", list[0].ProposalID)
+	assert.Equal(t, regular, list[0].UserID)
+	assert.Equal(t, "0x1", list[1].ProposalID)
+
+	list, err = storage.List(guest)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,119 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// finishMargin is the time for storing the result after the timeout of the job,
+// pending jobs older than the timeout with the margin are lost with their instance
+const finishMargin = time.Minute
+
+// Sender resolves recipients and publishes the push to each of them, numbers of recipients and sent pushes
+// are returned with the error as well
+type Sender func(ctx context.Context) (recipients, sent int, err error)
+
+type Job struct {
+	ID         uuid.UUID  `json:"id"`
+	Status     Status     `json:"status"`
+	Recipients int        `json:"recipients"`
+	Sent       int        `json:"sent"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Jobs sends admin pushes in background, so fan-out to large segments isn't limited by the request timeout.
+// Jobs are shared by all instances, so the admin polls any of them until the job is finished.
+type Jobs struct {
+	jobs    *kvstore.Store[Job]
+	timeout time.Duration
+}
+
+func NewJobs(cfg config.Broadcast, bucket kvstore.Bucket) *Jobs {
+	return &Jobs{
+		jobs:    kvstore.New[Job](bucket),
+		timeout: cfg.Timeout,
+	}
+}
+
+// Start stores the pending job and runs the sender in background
+func (j *Jobs) Start(send Sender) (Job, error) {
+	job := Job{
+		ID:        uuid.New(),
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+	}
+	if err := j.jobs.Put(jobKey(job.ID), job); err != nil {
+		return Job{}, fmt.Errorf("start push: %w", err)
+	}
+
+	go j.run(job, send)
+
+	return job, nil
+}
+
+// Get returns false if the job is unknown or expired
+func (j *Jobs) Get(id uuid.UUID) (Job, bool, error) {
+	job, err := j.jobs.Get(jobKey(id))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return Job{}, false, nil
+	}
+	if err != nil {
+		return Job{}, false, fmt.Errorf("get push: %w", err)
+	}
+
+	job.Status = j.status(job, time.Now())
+
+	return job, true, nil
+}
+
+// status reports pending jobs lost with the stopped instance as failed
+func (j *Jobs) status(job Job, now time.Time) Status {
+	if job.Status == StatusPending && now.Sub(job.CreatedAt) > j.timeout+finishMargin {
+		return StatusFailed
+	}
+
+	return job.Status
+}
+
+func (j *Jobs) run(job Job, send Sender) {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+
+	var err error
+	job.Recipients, job.Sent, err = send(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("job_id", job.ID.String()).Msg("send push")
+	}
+
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = StatusDone
+	if err != nil {
+		job.Status = StatusFailed
+	}
+
+	if err := j.jobs.Put(jobKey(job.ID), job); err != nil {
+		log.Error().Err(err).Str("job_id", job.ID.String()).Msg("finish push")
+	}
+}
+
+func jobKey(id uuid.UUID) string {
+	return kvstore.Key("job", id.String())
+}
@@ -0,0 +1,70 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func awaitJob(t *testing.T, jobs *Jobs, id uuid.UUID) Job {
+	var job Job
+	require.Eventually(t, func() bool {
+		var (
+			ok  bool
+			err error
+		)
+		job, ok, err = jobs.Get(id)
+		require.NoError(t, err)
+
+		return ok && job.Status != StatusPending
+	}, time.Second, 10*time.Millisecond)
+
+	return job
+}
+
+func TestJobs(t *testing.T) {
+	t.Run("done", func(t *testing.T) {
+		jobs := NewJobs(config.Broadcast{Timeout: time.Minute}, kvstoretest.NewBucket())
+
+		started, err := jobs.Start(func(context.Context) (int, int, error) {
+			return 3, 2, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, StatusPending, started.Status)
+
+		job := awaitJob(t, jobs, started.ID)
+		assert.Equal(t, StatusDone, job.Status)
+		assert.Equal(t, 3, job.Recipients)
+		assert.Equal(t, 2, job.Sent)
+		assert.NotNil(t, job.FinishedAt)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		jobs := NewJobs(config.Broadcast{Timeout: time.Minute}, kvstoretest.NewBucket())
+
+		started, err := jobs.Start(func(context.Context) (int, int, error) {
+			return 5, 1, errors.New("timeout")
+		})
+		require.NoError(t, err)
+
+		job := awaitJob(t, jobs, started.ID)
+		assert.Equal(t, StatusFailed, job.Status)
+		assert.Equal(t, 1, job.Sent)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		jobs := NewJobs(config.Broadcast{Timeout: time.Minute}, kvstoretest.NewBucket())
+
+		_, ok, err := jobs.Get(uuid.New())
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
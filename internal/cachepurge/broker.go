@@ -0,0 +1,124 @@
+package cachepurge
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+)
+
+// SubjectCachePurge is published by the instance which handled the purge request, so other instances
+// purge their local caches too
+const SubjectCachePurge = "inbox.web.cache.purge"
+
+type Payload struct {
+	Origin string   `json:"origin"`
+	Target string   `json:"target"`
+	IDs    []string `json:"ids"`
+}
+
+// Purger removes items of the target from the local cache, all items are removed if ids are empty
+type Purger func(target string, ids []string)
+
+// Broker broadcasts purges of local caches to all instances of the service. The instance skips own messages,
+// the purge is applied there by the request.
+type Broker struct {
+	nc     *nats.Conn
+	origin string
+
+	mu      sync.Mutex
+	purgers []Purger
+	sub     *nats.Subscription
+	stop    chan struct{}
+}
+
+func NewBroker(nc *nats.Conn) *Broker {
+	return &Broker{
+		nc:     nc,
+		origin: uuid.NewString(),
+		stop:   make(chan struct{}),
+	}
+}
+
+// OnPurge adds the purger of local caches, each purger gets all targets. Purgers have to be added before the start.
+func (b *Broker) OnPurge(purger Purger) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.purgers = append(b.purgers, purger)
+}
+
+// Publish notifies other instances about the purge
+func (b *Broker) Publish(target string, ids []string) error {
+	data, err := json.Marshal(Payload{Origin: b.origin, Target: target, IDs: ids})
+	if err != nil {
+		return fmt.Errorf("marshal cache purge: %w", err)
+	}
+
+	if err = b.nc.Publish(SubjectCachePurge, data); err != nil {
+		return fmt.Errorf("publish cache purge: %w", err)
+	}
+
+	return nil
+}
+
+// Start subscribes to purges and blocks until the broker is stopped
+func (b *Broker) Start() error {
+	b.mu.Lock()
+	sub, err := b.nc.Subscribe(SubjectCachePurge, b.handlePurge)
+	if err != nil {
+		b.mu.Unlock()
+
+		return fmt.Errorf("subscribe %s: %w", SubjectCachePurge, err)
+	}
+	b.sub = sub
+	b.mu.Unlock()
+
+	<-b.stop
+
+	return nil
+}
+
+func (b *Broker) Stop() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.sub != nil {
+		if err := b.sub.Unsubscribe(); err != nil {
+			log.Error().Err(err).Str("subject", b.sub.Subject).Msg("unsubscribe cache purge")
+		}
+		b.sub = nil
+	}
+
+	select {
+	case <-b.stop:
+	default:
+		close(b.stop)
+	}
+
+	return nil
+}
+
+func (b *Broker) handlePurge(msg *nats.Msg) {
+	var payload Payload
+	if err := json.Unmarshal(msg.Data, &payload); err != nil {
+		log.Error().Err(err).Msg("unmarshal cache purge")
+
+		return
+	}
+
+	if payload.Origin == b.origin {
+		return
+	}
+
+	b.mu.Lock()
+	purgers := b.purgers
+	b.mu.Unlock()
+
+	for _, purger := range purgers {
+		purger(payload.Target, payload.IDs)
+	}
+}
@@ -0,0 +1,154 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	productID = "-//Goverland//Inbox//EN"
+
+	// maxLineLength is the limit of the content line in octets, longer lines are folded
+	maxLineLength = 75
+
+	timeLayout = "20060102T150405Z"
+)
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// Calendar is the list of events rendered in the iCalendar format (RFC 5545)
+type Calendar struct {
+	Name string
+	// RefreshInterval is the suggested interval of polling the calendar by clients
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+type Event struct {
+	// UID has to be stable between renders, clients replace events with the same UID
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	// Stamp is the creation time of the event, the start is used if it is empty
+	Stamp time.Time
+	// Alarms are reminders before the end of the event
+	Alarms []time.Duration
+}
+
+// Render returns the calendar with CRLF line endings and folded long lines
+func Render(cal Calendar) []byte {
+	var w writer
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + productID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if cal.Name != "" {
+		w.line("X-WR-CALNAME:" + escapeText(cal.Name))
+	}
+	if cal.RefreshInterval > 0 {
+		w.line("REFRESH-INTERVAL;VALUE=DURATION:" + formatDuration(cal.RefreshInterval))
+		w.line("X-PUBLISHED-TTL:" + formatDuration(cal.RefreshInterval))
+	}
+
+	for _, event := range cal.Events {
+		stamp := event.Stamp
+		if stamp.IsZero() {
+			stamp = event.Start
+		}
+
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + escapeText(event.UID))
+		w.line("DTSTAMP:" + formatTime(stamp))
+		w.line("DTSTART:" + formatTime(event.Start))
+		w.line("DTEND:" + formatTime(event.End))
+		w.line("SUMMARY:" + escapeText(event.Summary))
+		if event.Description != "" {
+			w.line("DESCRIPTION:" + escapeText(event.Description))
+		}
+		if event.URL != "" {
+			w.line("URL:" + event.URL)
+		}
+		w.line("TRANSP:TRANSPARENT")
+
+		for _, before := range event.Alarms {
+			w.line("BEGIN:VALARM")
+			w.line("ACTION:DISPLAY")
+			w.line("DESCRIPTION:" + escapeText(event.Summary))
+			w.line("TRIGGER;RELATED=END:-" + formatDuration(before))
+			w.line("END:VALARM")
+		}
+
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+
+	return []byte(w.sb.String())
+}
+
+type writer struct {
+	sb strings.Builder
+}
+
+// line writes the content line folding it by the length limit without splitting multibyte characters
+func (w *writer) line(text string) {
+	limit := maxLineLength
+	for len(text) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+
+		w.sb.WriteString(text[:cut])
+		w.sb.WriteString("\r\n ")
+		text = text[cut:]
+
+		// the leading space of the continuation line is counted too
+		limit = maxLineLength - 1
+	}
+
+	w.sb.WriteString(text)
+	w.sb.WriteString("\r\n")
+}
+
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// formatDuration formats the positive duration as the iCalendar duration value, e.g. P1D or PT1H30M
+func formatDuration(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("P%dD", d/(24*time.Hour))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("PT")
+	if h := d / time.Hour; h > 0 {
+		fmt.Fprintf(&sb, "%dH", h)
+	}
+	if m := d % time.Hour / time.Minute; m > 0 {
+		fmt.Fprintf(&sb, "%dM", m)
+	}
+	if s := d % time.Minute / time.Second; s > 0 || d < time.Minute {
+		fmt.Fprintf(&sb, "%dS", s)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/dao"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+)
+
+func newProposal(id string, state proposal.State, start, end time.Time) proposal.Proposal {
+	return proposal.Proposal{
+		ID:          id,
+		Title:       "Proposal " + id,
+		DAO:         dao.ShortDAO{Name: "Aave"},
+		State:       helpers.Ptr(state),
+		Created:     *common.NewTime(start.Add(-time.Hour)),
+		VotingStart: *common.NewTime(start),
+		VotingEnd:   *common.NewTime(end),
+		Choices:     []string{"For", "Against"},
+	}
+}
+
+func TestProposalEvents(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	events := ProposalEvents([]proposal.Proposal{
+		newProposal("later", proposal.ActiveState, now.Add(-time.Hour), now.Add(72*time.Hour)),
+		newProposal("closed", proposal.ClosedState, now.Add(-72*time.Hour), now.Add(-time.Hour)),
+		newProposal("pending", proposal.PendingState, now.Add(time.Hour), now.Add(24*time.Hour)),
+		newProposal("expired", proposal.ActiveState, now.Add(-72*time.Hour), now.Add(-time.Minute)),
+	}, now, "https://app.goverland.xyz/proposals/%s", nil)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "proposal-pending@goverland.xyz", events[0].UID)
+	assert.Equal(t, "proposal-later@goverland.xyz", events[1].UID)
+	assert.Equal(t, "https://app.goverland.xyz/proposals/later", events[1].URL)
+}
+
+func TestRender(t *testing.T) {
+	start := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	pr := newProposal("0x1", proposal.ActiveState, start, start.Add(48*time.Hour))
+	pr.Title = "Fund grants; round 2, part 1"
+
+	out := Render(Calendar{
+		Name:            "Goverland votes",
+		RefreshInterval: time.Hour,
+		Events: []Event{
+			NewProposalEvent(pr, "https://app.goverland.xyz/proposals/0x1", []time.Duration{24 * time.Hour, 90 * time.Minute}),
+		},
+	})
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Goverland//Inbox//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Goverland votes",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT1H",
+		"X-PUBLISHED-TTL:PT1H",
+		"BEGIN:VEVENT",
+		"UID:proposal-0x1@goverland.xyz",
+		"DTSTAMP:20240310T110000Z",
+		"DTSTART:20240310T120000Z",
+		"DTEND:20240312T120000Z",
+		`SUMMARY:Aave: Fund grants\; round 2\, part 1`,
+		`DESCRIPTION:Voting on "Fund grants\; round 2\, part 1" in Aave\nChoices: Fo`,
+		` r\, Against\nhttps://app.goverland.xyz/proposals/0x1`,
+		"URL:https://app.goverland.xyz/proposals/0x1",
+		"TRANSP:TRANSPARENT",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		`DESCRIPTION:Aave: Fund grants\; round 2\, part 1`,
+		"TRIGGER;RELATED=END:-P1D",
+		"END:VALARM",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		`DESCRIPTION:Aave: Fund grants\; round 2\, part 1`,
+		"TRIGGER;RELATED=END:-PT1H30M",
+		"END:VALARM",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, expected, string(out))
+}
+
+func TestFoldKeepsMultibyteCharacters(t *testing.T) {
+	var w writer
+	w.line("SUMMARY:" + strings.Repeat("ü", 60))
+
+	for _, line := range strings.Split(strings.TrimSuffix(w.sb.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineLength)
+		assert.True(t, strings.ToValidUTF8(line, "?") == line)
+	}
+}
@@ -0,0 +1,80 @@
+package calendar
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+)
+
+const uidDomain = "goverland.xyz"
+
+// ProposalEvents converts voting windows of active and upcoming proposals to events sorted by the voting end.
+// The link template receives the proposal id.
+func ProposalEvents(proposals []proposal.Proposal, now time.Time, linkTemplate string, alarms []time.Duration) []Event {
+	events := make([]Event, 0, len(proposals))
+	for _, pr := range proposals {
+		if pr.State == nil || (*pr.State != proposal.ActiveState && *pr.State != proposal.PendingState) {
+			continue
+		}
+
+		start, end := timeOf(pr.VotingStart), timeOf(pr.VotingEnd)
+		if start.IsZero() || end.IsZero() || !end.After(now) {
+			continue
+		}
+
+		events = append(events, NewProposalEvent(pr, fmt.Sprintf(linkTemplate, pr.ID), alarms))
+	}
+
+	slices.SortFunc(events, func(a, b Event) int {
+		if c := a.End.Compare(b.End); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.UID, b.UID)
+	})
+
+	return events
+}
+
+// NewProposalEvent returns the event of the voting window, the UID depends on the proposal id only
+func NewProposalEvent(pr proposal.Proposal, link string, alarms []time.Duration) Event {
+	summary := pr.Title
+	if pr.DAO.Name != "" {
+		summary = fmt.Sprintf("%s: %s", pr.DAO.Name, pr.Title)
+	}
+
+	var description strings.Builder
+	fmt.Fprintf(&description, "Voting on %q", pr.Title)
+	if pr.DAO.Name != "" {
+		fmt.Fprintf(&description, " in %s", pr.DAO.Name)
+	}
+	if len(pr.Choices) > 0 {
+		fmt.Fprintf(&description, "\nChoices: %s", strings.Join(pr.Choices, ", "))
+	}
+	if link != "" {
+		fmt.Fprintf(&description, "\n%s", link)
+	}
+
+	return Event{
+		UID:         fmt.Sprintf("proposal-%s@%s", pr.ID, uidDomain),
+		Summary:     summary,
+		Description: description.String(),
+		URL:         link,
+		Start:       timeOf(pr.VotingStart),
+		End:         timeOf(pr.VotingEnd),
+		Stamp:       timeOf(pr.Created),
+		Alarms:      alarms,
+	}
+}
+
+func timeOf(t common.Time) time.Time {
+	if t.Time == nil {
+		return time.Time{}
+	}
+
+	return *t.Time
+}
@@ -0,0 +1,93 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog/log"
+)
+
+// erc1271MagicValue is returned by isValidSignature(bytes32,bytes) when the signature is valid
+var erc1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+// IsValidSignature checks the signature of smart contract account by ERC-1271. The preferred chain is checked first,
+// then all other configured chains. The account is considered as valid if any of chains returns the magic value.
+func (s *Service) IsValidSignature(ctx context.Context, preferred ChainID, address common.Address, hash common.Hash, signature []byte) (bool, error) {
+	input, err := s.erc1271ABI.Pack("isValidSignature", hash, signature)
+	if err != nil {
+		return false, fmt.Errorf("pack isValidSignature: %w", err)
+	}
+
+	var errs []error
+	for _, chain := range s.orderedChains(preferred) {
+		valid, err := chain.isValidSignature(ctx, address, input)
+		if err != nil {
+			log.Warn().Err(err).Str("chain", chain.chain).Str("address", address.Hex()).Msg("erc1271 signature check")
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if valid {
+			return true, nil
+		}
+	}
+
+	if len(errs) == len(s.chains) && len(errs) > 0 {
+		return false, fmt.Errorf("%w: %w", ErrChainRequestUnreachable, errors.Join(errs...))
+	}
+
+	return false, nil
+}
+
+func (s *Service) orderedChains(preferred ChainID) []chainInstance {
+	list := make([]chainInstance, 0, len(s.chains))
+	for _, chain := range s.chains {
+		list = append(list, chain)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].chainID == preferred || list[j].chainID == preferred {
+			return list[i].chainID == preferred
+		}
+
+		return list[i].chainID < list[j].chainID
+	})
+
+	return list
+}
+
+func (c chainInstance) isValidSignature(ctx context.Context, address common.Address, input []byte) (bool, error) {
+	code, err := c.client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return false, fmt.Errorf("get code for %s: %w", address.Hex(), err)
+	}
+
+	// externally owned account can't validate signatures
+	if len(code) == 0 {
+		return false, nil
+	}
+
+	resp, err := c.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &address,
+		Data: input,
+	}, nil)
+	var rpcErr rpc.Error
+	if err != nil && !errors.As(err, &rpcErr) {
+		return false, fmt.Errorf("call isValidSignature for %s: %w", address.Hex(), err)
+	}
+	if err != nil {
+		// the call reverted by the node means the signature is not accepted by the contract
+		log.Debug().Err(err).Str("chain", c.chain).Str("address", address.Hex()).Msg("call isValidSignature")
+
+		return false, nil
+	}
+
+	return len(resp) >= len(erc1271MagicValue) && bytes.Equal(resp[:len(erc1271MagicValue)], erc1271MagicValue[:]), nil
+}
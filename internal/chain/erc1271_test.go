@@ -0,0 +1,142 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// erc1271Wallet returns the runtime code of the contract which returns the magic value
+// only for the expected hash passed as the first argument of isValidSignature
+func erc1271Wallet(expected common.Hash) []byte {
+	code := []byte{0x60, 0x04, 0x35, 0x7f} // PUSH1 4 CALLDATALOAD PUSH32
+	code = append(code, expected.Bytes()...)
+	code = append(code, 0x14, 0x60, 0x00, 0x57) // EQ PUSH1 <label> JUMPI
+	label := len(code) - 2
+
+	code = append(code, 0x60, 0x00, 0x60, 0x00, 0xf3) // PUSH1 0 PUSH1 0 RETURN
+	code[label] = byte(len(code))
+
+	code = append(code, 0x5b, 0x63) // JUMPDEST PUSH4
+	code = append(code, erc1271MagicValue[:]...)
+	code = append(code, 0x60, 0xe0, 0x1b, 0x60, 0x00, 0x52) // PUSH1 224 SHL PUSH1 0 MSTORE
+	code = append(code, 0x60, 0x20, 0x60, 0x00, 0xf3)       // PUSH1 32 PUSH1 0 RETURN
+
+	return code
+}
+
+var errUnreachable = errors.New("dial tcp: connection refused")
+
+// unreachableClient fails requests like the node which can't be reached
+type unreachableClient struct {
+	Client
+	code []byte
+}
+
+func (c unreachableClient) CodeAt(_ context.Context, _ common.Address, _ *big.Int) ([]byte, error) {
+	if c.code == nil {
+		return nil, errUnreachable
+	}
+
+	return c.code, nil
+}
+
+func (c unreachableClient) CallContract(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	return nil, errUnreachable
+}
+
+func TestIsValidSignature(t *testing.T) {
+	wallet := common.HexToAddress("0x91e2E2D26076C8A1EaDb69273605c16ef01928ce")
+	eoa := common.HexToAddress("0x2B8fDb4a5Fe3c5Ea5bd2B9bD4eF4F4dF0A0c1d34")
+	reverting := common.HexToAddress("0x7A250d5630B4cF539739dF2C5dAcb4c659F2488D")
+	expected := common.HexToHash("0x5e1c9a0c1d0fbb0a1fc0a1d6b0e0f6e9b3a5e8d2a49b4f1c2d3e4f5a6b7c8d9e")
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		wallet:    {Code: erc1271Wallet(expected)},
+		reverting: {Code: []byte{0x60, 0x00, 0x60, 0x00, 0xfd}}, // PUSH1 0 PUSH1 0 REVERT
+	})
+	t.Cleanup(func() { _ = backend.Close() })
+
+	erc1271ABI, err := getERC1271Abi()
+	require.NoError(t, err)
+
+	service := &Service{
+		chains: map[ChainID]chainInstance{
+			1: {chain: "eth", chainID: 1, client: backend.Client()},
+		},
+		erc1271ABI: erc1271ABI,
+	}
+
+	for name, tc := range map[string]struct {
+		address common.Address
+		hash    common.Hash
+		want    bool
+	}{
+		"valid signature":  {address: wallet, hash: expected, want: true},
+		"another hash":     {address: wallet, hash: common.HexToHash("0x01"), want: false},
+		"externally owned": {address: eoa, hash: expected, want: false},
+		"reverted":         {address: reverting, hash: expected, want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			valid, err := service.IsValidSignature(context.Background(), 1, tc.address, tc.hash, []byte{0x01})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, valid)
+		})
+	}
+}
+
+func TestIsValidSignatureUnreachable(t *testing.T) {
+	wallet := common.HexToAddress("0x91e2E2D26076C8A1EaDb69273605c16ef01928ce")
+	expected := common.HexToHash("0x5e1c9a0c1d0fbb0a1fc0a1d6b0e0f6e9b3a5e8d2a49b4f1c2d3e4f5a6b7c8d9e")
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		wallet: {Code: erc1271Wallet(expected)},
+	})
+	t.Cleanup(func() { _ = backend.Close() })
+
+	erc1271ABI, err := getERC1271Abi()
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		chains  map[ChainID]chainInstance
+		wantErr bool
+	}{
+		"all chains unreachable": {
+			chains: map[ChainID]chainInstance{
+				1:   {chain: "eth", chainID: 1, client: unreachableClient{}},
+				100: {chain: "gnosis", chainID: 100, client: unreachableClient{code: []byte{0x01}}},
+			},
+			wantErr: true,
+		},
+		"another chain is reachable": {
+			chains: map[ChainID]chainInstance{
+				1:   {chain: "eth", chainID: 1, client: unreachableClient{}},
+				100: {chain: "gnosis", chainID: 100, client: backend.Client()},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			service := &Service{chains: tc.chains, erc1271ABI: erc1271ABI}
+
+			valid, err := service.IsValidSignature(context.Background(), 1, wallet, expected, []byte{0x01})
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrChainRequestUnreachable)
+				assert.ErrorIs(t, err, errUnreachable)
+				assert.False(t, valid)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, valid)
+		})
+	}
+}
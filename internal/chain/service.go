@@ -26,9 +26,20 @@ type Info struct {
 	TxScanTemplate   string  `json:"tx_scan_template"`
 }
 
+// Client describes the subset of node API used by the service. It's implemented by ethclient.Client and
+// by the simulated backend client.
+type Client interface {
+	ethereum.ChainStateReader
+	ethereum.TransactionReader
+	ethereum.ContractCaller
+	ethereum.GasEstimator
+	ethereum.GasPricer
+	ethereum.GasPricer1559
+}
+
 type chainInstance struct {
 	chain          string
-	client         *ethclient.Client
+	client         Client
 	chainID        ChainID
 	publicName     string
 	symbol         string
@@ -50,6 +61,7 @@ type TxStatusWrapper struct {
 
 type Service struct {
 	splitDelegationABI *abi.ABI
+	erc1271ABI         *abi.ABI
 	chains             map[ChainID]chainInstance
 }
 
@@ -89,9 +101,15 @@ func NewService(cfg config.Chain) (*Service, error) {
 		return nil, err
 	}
 
+	erc1271ABI, err := getERC1271Abi()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Service{
 		chains:             chains,
 		splitDelegationABI: sdABI,
+		erc1271ABI:         erc1271ABI,
 	}, nil
 }
 
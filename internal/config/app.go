@@ -1,17 +1,24 @@
 package config
 
-import "time"
-
 type App struct {
-	LogLevel   string `env:"LOG_LEVEL" envDefault:"info"`
-	Prometheus Prometheus
-	Health     Health
-	REST       REST
-	Core       Core
-	Inbox      Inbox
-	Analytics  Analytics
-	Nats       Nats
-	Chain      Chain
-
-	SiweTTL time.Duration `env:"SIWE_TTL" envDefault:"1h"`
+	LogLevel    string `env:"LOG_LEVEL" envDefault:"info"`
+	Prometheus  Prometheus
+	Health      Health
+	REST        REST
+	Core        Core
+	Inbox       Inbox
+	Analytics   Analytics
+	Nats        Nats
+	Chain       Chain
+	Auth        Auth
+	Siwe        Siwe
+	Export      Export
+	Broadcast   Broadcast
+	FeedStream  FeedStream
+	Syndication Syndication
+	Calendar    Calendar
+	Snooze      Snooze
+	Upgrade     Upgrade
+	Search      Search
+	Revisions   Revisions
 }
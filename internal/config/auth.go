@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+type Auth struct {
+	SessionCacheTTL  time.Duration `env:"AUTH_SESSION_CACHE_TTL" envDefault:"1m"`
+	SessionCacheSize int           `env:"AUTH_SESSION_CACHE_SIZE" envDefault:"10000"`
+	// AdminAddresses are wallets of users with the admin role
+	AdminAddresses []string `env:"AUTH_ADMIN_ADDRESSES" envSeparator:","`
+
+	// AccessTokenKeys is the list of signing keys in format "key_id:secret". The first key signs new tokens,
+	// others are used only for verification, so the key can be rotated without invalidating issued tokens.
+	// Access tokens are disabled if the list is empty.
+	AccessTokenKeys []string      `env:"AUTH_ACCESS_TOKEN_KEYS" envSeparator:","`
+	AccessTokenTTL  time.Duration `env:"AUTH_ACCESS_TOKEN_TTL" envDefault:"15m"`
+	RefreshTokenTTL time.Duration `env:"AUTH_REFRESH_TOKEN_TTL" envDefault:"720h"`
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type Broadcast struct {
+	// Timeout limits the time of sending one admin push to all recipients
+	Timeout time.Duration `env:"BROADCAST_TIMEOUT" envDefault:"10m"`
+	// TTL defines how long the result of the finished push is available
+	TTL time.Duration `env:"BROADCAST_TTL" envDefault:"24h"`
+}
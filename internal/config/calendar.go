@@ -0,0 +1,12 @@
+package config
+
+import "time"
+
+type Calendar struct {
+	// Alarms are reminders before the end of voting added to each event
+	Alarms []time.Duration `env:"CALENDAR_ALARMS" envSeparator:"," envDefault:"24h,1h"`
+	// DaoProposalsLimit is the number of the last proposals of each dao checked for voting windows
+	DaoProposalsLimit int `env:"CALENDAR_DAO_PROPOSALS_LIMIT" envDefault:"30"`
+	// RefreshInterval is suggested to calendar clients as the polling interval
+	RefreshInterval time.Duration `env:"CALENDAR_REFRESH_INTERVAL" envDefault:"1h"`
+}
@@ -0,0 +1,12 @@
+package config
+
+import "time"
+
+type Export struct {
+	// Timeout limits the time of collecting the data of one export
+	Timeout time.Duration `env:"EXPORT_TIMEOUT" envDefault:"10m"`
+	// TTL defines how long the ready archive is available for downloading
+	TTL time.Duration `env:"EXPORT_TTL" envDefault:"1h"`
+	// Concurrency limits the number of exports collected at the same time
+	Concurrency int `env:"EXPORT_CONCURRENCY" envDefault:"2"`
+}
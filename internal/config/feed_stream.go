@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+type FeedStream struct {
+	// Heartbeat is the interval of keep-alive messages for idle connections
+	Heartbeat time.Duration `env:"FEED_STREAM_HEARTBEAT" envDefault:"15s"`
+	// HistorySize is the number of the last events per user kept for resuming by Last-Event-ID
+	HistorySize int `env:"FEED_STREAM_HISTORY_SIZE" envDefault:"100"`
+	// HistoryTTL defines how long the history is kept after the user is disconnected
+	HistoryTTL time.Duration `env:"FEED_STREAM_HISTORY_TTL" envDefault:"5m"`
+	// UnreadDelay is the delay before refreshing the unread counter after new feed items,
+	// the inbox feed service needs time to create items for subscribers
+	UnreadDelay time.Duration `env:"FEED_STREAM_UNREAD_DELAY" envDefault:"2s"`
+}
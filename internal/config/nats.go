@@ -9,6 +9,11 @@ type Nats struct {
 	URL              string        `env:"NATS_URL" envDefault:"nats://127.0.0.1:4222"`
 	MaxReconnects    int           `env:"NATS_MAX_RECONNECTS" envDefault:"10"`
 	ReconnectTimeout time.Duration `env:"NATS_RECONNECT_TIMEOUT" envDefault:"1s"`
+	// KVReplicas is the number of replicas of key-value buckets created on the first start
+	KVReplicas int `env:"NATS_KV_REPLICAS" envDefault:"1"`
+	// LeaseTTL is the time after which workers running on one instance at a time are taken over by another instance
+	// if the holder stops renewing the lease
+	LeaseTTL time.Duration `env:"NATS_LEASE_TTL" envDefault:"3m"`
 }
 
 func GenerateGroupName(subgroup string) string {
@@ -7,4 +7,8 @@ import (
 type REST struct {
 	Listen  string        `env:"REST_LISTEN" envDefault:":8080"`
 	Timeout time.Duration `env:"REST_TIMEOUT" envDefault:"30s"`
+	// CursorSecret signs pagination cursors, it has to be the same for all instances
+	CursorSecret string `env:"REST_CURSOR_SECRET"`
+	// PublicCacheMaxAge is the max age of GET responses of public read routes for requests without the session
+	PublicCacheMaxAge time.Duration `env:"REST_PUBLIC_CACHE_MAX_AGE" envDefault:"30s"`
 }
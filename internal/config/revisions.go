@@ -0,0 +1,8 @@
+package config
+
+type Revisions struct {
+	// CacheSize is the number of proposals which last versions are remembered by the instance,
+	// so loading them again doesn't read the storage
+	CacheSize    int `env:"REVISIONS_CACHE_SIZE" envDefault:"5000"`
+	MaxRevisions int `env:"REVISIONS_MAX_PER_PROPOSAL" envDefault:"50"`
+}
@@ -0,0 +1,6 @@
+package config
+
+type Search struct {
+	// IndexSize is the number of proposals kept in the local search index, the least recently used are evicted
+	IndexSize int `env:"SEARCH_INDEX_SIZE" envDefault:"20000"`
+}
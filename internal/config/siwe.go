@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+type Siwe struct {
+	TTL time.Duration `env:"SIWE_TTL" envDefault:"1h"`
+
+	// NonceTTL defines how long the nonce issued by the server is valid
+	NonceTTL time.Duration `env:"SIWE_NONCE_TTL" envDefault:"10m"`
+	// NonceSecret is used for signing issued nonces, must be the same for all instances
+	NonceSecret string `env:"SIWE_NONCE_SECRET"`
+	// StrictNonce rejects the nonces which were not issued by the server. Disabling it is the explicit opt-in
+	// for clients generating nonces themselves, such nonces can be replayed.
+	StrictNonce bool `env:"SIWE_STRICT_NONCE" envDefault:"true"`
+
+	// Empty lists allow any value
+	AllowedDomains  []string `env:"SIWE_ALLOWED_DOMAINS" envSeparator:","`
+	AllowedURIs     []string `env:"SIWE_ALLOWED_URIS" envSeparator:","`
+	AllowedChainIDs []int    `env:"SIWE_ALLOWED_CHAIN_IDS" envSeparator:","`
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type Snooze struct {
+	// CheckInterval is the interval of resurfacing snoozed items, it has to be shorter than NATS_LEASE_TTL
+	CheckInterval time.Duration `env:"SNOOZE_CHECK_INTERVAL" envDefault:"1m"`
+	// Reminders enables pushes for items snoozed with the reminder
+	Reminders bool `env:"SNOOZE_REMINDERS" envDefault:"true"`
+}
@@ -0,0 +1,10 @@
+package config
+
+type Syndication struct {
+	// PublicURL is the external address of the api used in links to feeds, the request host is used if it's empty
+	PublicURL string `env:"SYNDICATION_PUBLIC_URL"`
+	// AppURL is the address of the web app used in links to proposals
+	AppURL string `env:"SYNDICATION_APP_URL" envDefault:"https://app.goverland.xyz"`
+	// Limit is the number of the last feed items included in the feed
+	Limit int `env:"SYNDICATION_LIMIT" envDefault:"50"`
+}
@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type Upgrade struct {
+	// CheckInterval is the interval of applying the guest feed state to feed items of upgraded accounts,
+	// it has to be shorter than NATS_LEASE_TTL
+	CheckInterval time.Duration `env:"UPGRADE_CHECK_INTERVAL" envDefault:"1m"`
+	// StateTTL limits the time of waiting for feed items of the upgraded account, the rest of the guest state is lost
+	StateTTL time.Duration `env:"UPGRADE_STATE_TTL" envDefault:"24h"`
+}
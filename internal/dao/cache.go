@@ -1,6 +1,7 @@
 package dao
 
 import (
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -102,3 +103,27 @@ func (r *Cache) GetByID(id string) (*dao.DAO, bool) {
 
 	return nil, false
 }
+
+// Purge removes items by ids or aliases from the cache, all items are removed if ids are empty.
+// Returns the number of removed items.
+func (r *Cache) Purge(ids ...string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := make(map[string]struct{})
+	for key, item := range r.cache {
+		id := strings.ToLower(item.value.ID.String())
+		if len(ids) > 0 && !slices.ContainsFunc(ids, func(target string) bool {
+			target = strings.ToLower(target)
+
+			return target == id || target == strings.ToLower(item.value.Alias)
+		}) {
+			continue
+		}
+
+		delete(r.cache, key)
+		purged[id] = struct{}{}
+	}
+
+	return len(purged)
+}
@@ -76,6 +76,11 @@ func NewService(cache *Cache, dp DaoProvider, authService AuthService, chainServ
 	}
 }
 
+// PurgeCache removes daos from the local cache, all daos are removed if ids are empty
+func (s *Service) PurgeCache(ids ...string) int {
+	return s.cache.Purge(ids...)
+}
+
 func (s *Service) GetDao(ctx context.Context, id string) (*dao.DAO, error) {
 	item, ok := s.cache.GetByID(id)
 	if ok {
@@ -0,0 +1,166 @@
+package digest
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/dao"
+	entity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/digest"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	internalproposal "github.com/goverland-labs/goverland-inbox-web-api/internal/proposal"
+)
+
+type Period string
+
+const (
+	PeriodDay  Period = "day"
+	PeriodWeek Period = "week"
+
+	// EndingSoonWindow is the time before the end of voting when the proposal is shown as ending soon
+	EndingSoonWindow = 72 * time.Hour
+)
+
+func (p Period) Duration() time.Duration {
+	if p == PeriodDay {
+		return 24 * time.Hour
+	}
+
+	return 7 * 24 * time.Hour
+}
+
+// Delegation is the last delegation of the user in the dao
+type Delegation struct {
+	DAO  dao.ShortDAO
+	Info entity.DelegationInfo
+}
+
+// Build groups proposals and delegations of the period by dao. Proposals have to be enriched by the user vote,
+// groups without changes are skipped.
+func Build(period Period, now time.Time, proposals []proposal.Proposal, delegations []Delegation) entity.Digest {
+	from := now.Add(-period.Duration())
+	groups := make(map[uuid.UUID]*entity.Group)
+	group := func(d dao.ShortDAO) *entity.Group {
+		if g, ok := groups[d.ID]; ok {
+			return g
+		}
+
+		g := &entity.Group{
+			DAO:          d,
+			NewProposals: []entity.Proposal{},
+			EndingSoon:   []entity.Proposal{},
+			Ended:        []entity.EndedProposal{},
+			Delegations:  []entity.DelegationInfo{},
+		}
+		groups[d.ID] = g
+
+		return g
+	}
+
+	for _, pr := range proposals {
+		created, end := timeOf(pr.Created), timeOf(pr.VotingEnd)
+
+		switch {
+		case pr.VotingFinished() && !end.Before(from) && !end.After(now):
+			results := tallyScores(pr)
+			g := group(pr.DAO)
+			g.Ended = append(g.Ended, entity.EndedProposal{
+				Proposal:      convertProposal(pr),
+				WinningChoice: winningChoice(pr, results),
+				QuorumReached: results.QuorumReached,
+			})
+		case pr.IsActive() && pr.UserVote == nil && end.After(now) && !end.After(now.Add(EndingSoonWindow)):
+			g := group(pr.DAO)
+			g.EndingSoon = append(g.EndingSoon, convertProposal(pr))
+		case !created.Before(from) && !created.After(now):
+			g := group(pr.DAO)
+			g.NewProposals = append(g.NewProposals, convertProposal(pr))
+		}
+	}
+
+	for _, delegation := range delegations {
+		created := timeOf(delegation.Info.CreatedAt)
+		if created.Before(from) || created.After(now) {
+			continue
+		}
+
+		g := group(delegation.DAO)
+		g.Delegations = append(g.Delegations, delegation.Info)
+	}
+
+	list := make([]entity.Group, 0, len(groups))
+	for _, g := range groups {
+		slices.SortFunc(g.EndingSoon, func(a, b entity.Proposal) int {
+			return timeOf(a.VotingEnd).Compare(timeOf(b.VotingEnd))
+		})
+
+		list = append(list, *g)
+	}
+	slices.SortFunc(list, func(a, b entity.Group) int {
+		if c := strings.Compare(strings.ToLower(a.DAO.Name), strings.ToLower(b.DAO.Name)); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.DAO.ID.String(), b.DAO.ID.String())
+	})
+
+	digest := entity.Digest{
+		Period: string(period),
+		From:   *common.NewTime(from),
+		To:     *common.NewTime(now),
+		Groups: list,
+	}
+	digest.Markdown = RenderMarkdown(digest)
+
+	return digest
+}
+
+func convertProposal(pr proposal.Proposal) entity.Proposal {
+	state := ""
+	if pr.State != nil {
+		state = string(*pr.State)
+	}
+
+	return entity.Proposal{
+		ID:          pr.ID,
+		Title:       pr.Title,
+		State:       state,
+		VotingStart: pr.VotingStart,
+		VotingEnd:   pr.VotingEnd,
+	}
+}
+
+// tallyScores builds results from scores of the proposal, votes aren't loaded for digests
+func tallyScores(pr proposal.Proposal) proposal.Results {
+	var votingType string
+	if pr.Type != nil {
+		votingType = *pr.Type
+	}
+
+	var total float64
+	if pr.ScoresTotal != nil {
+		total = *pr.ScoresTotal
+	}
+
+	return internalproposal.TallyScores(votingType, pr.Choices, pr.Quorum, pr.Scores, total)
+}
+
+// winningChoice returns the title of the winner, ties are resolved by the order of choices
+func winningChoice(pr proposal.Proposal, results proposal.Results) string {
+	if len(results.Winners) == 0 {
+		return ""
+	}
+
+	return pr.Choices[results.Winners[0]-1]
+}
+
+func timeOf(t common.Time) time.Time {
+	if t.Time == nil {
+		return time.Time{}
+	}
+
+	return *t.Time
+}
@@ -0,0 +1,120 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/dao"
+	entity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/digest"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+)
+
+func newProposal(id string, d dao.ShortDAO, state proposal.State, created, end time.Time) proposal.Proposal {
+	return proposal.Proposal{
+		ID:        id,
+		Title:     "Proposal " + id,
+		DAO:       d,
+		State:     helpers.Ptr(state),
+		Created:   *common.NewTime(created),
+		VotingEnd: *common.NewTime(end),
+		Choices:   []string{"For", "Against"},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	aave := dao.ShortDAO{ID: uuid.New(), Name: "Aave"}
+	ens := dao.ShortDAO{ID: uuid.New(), Name: "ENS"}
+
+	created := newProposal("new", aave, proposal.ActiveState, now.Add(-48*time.Hour), now.Add(10*24*time.Hour))
+	endingSoon := newProposal("ending", aave, proposal.ActiveState, now.Add(-20*24*time.Hour), now.Add(24*time.Hour))
+	voted := newProposal("voted", aave, proposal.ActiveState, now.Add(-20*24*time.Hour), now.Add(24*time.Hour))
+	voted.UserVote = &proposal.Vote{}
+	ended := newProposal("ended", ens, proposal.ClosedState, now.Add(-20*24*time.Hour), now.Add(-24*time.Hour))
+	ended.Scores = []float64{10, 30}
+	ended.ScoresTotal = helpers.Ptr(40.0)
+	ended.Quorum = 50
+	old := newProposal("old", ens, proposal.ClosedState, now.Add(-40*24*time.Hour), now.Add(-30*24*time.Hour))
+
+	digest := Build(PeriodWeek, now, []proposal.Proposal{created, endingSoon, voted, ended, old}, []Delegation{
+		{DAO: ens, Info: entity.DelegationInfo{CreatedAt: *common.NewTime(now.Add(-time.Hour)), TxHash: "0x1", Delegates: []dao.PreparedDelegate{
+			{Address: "0xabc", PercentOfDelegated: 50},
+			{Address: "0xdef", ResolvedName: "alice.eth", PercentOfDelegated: 50},
+		}}},
+		{DAO: aave, Info: entity.DelegationInfo{CreatedAt: *common.NewTime(now.Add(-30 * 24 * time.Hour)), TxHash: "0x2"}},
+	})
+
+	require.Len(t, digest.Groups, 2)
+	assert.Equal(t, "Aave", digest.Groups[0].DAO.Name)
+	assert.Len(t, digest.Groups[0].NewProposals, 1)
+	assert.Equal(t, "new", digest.Groups[0].NewProposals[0].ID)
+	require.Len(t, digest.Groups[0].EndingSoon, 1)
+	assert.Equal(t, "ending", digest.Groups[0].EndingSoon[0].ID)
+	assert.Empty(t, digest.Groups[0].Delegations)
+
+	assert.Equal(t, "ENS", digest.Groups[1].DAO.Name)
+	require.Len(t, digest.Groups[1].Ended, 1)
+	assert.Equal(t, "Against", digest.Groups[1].Ended[0].WinningChoice)
+	assert.False(t, digest.Groups[1].Ended[0].QuorumReached)
+	assert.Len(t, digest.Groups[1].Delegations, 1)
+
+	expected := `# Weekly governance digest
+
+_Mar 3, 2024 – Mar 10, 2024_
+
+## Aave
+
+**New proposals**
+
+- Proposal new, voting ends Mar 20, 12:00 UTC
+
+**Ending soon, not voted yet**
+
+- Proposal ending, ends Mar 11, 12:00 UTC
+
+## ENS
+
+**Ended**
+
+- Proposal ended, winner: Against, quorum not reached
+
+**Delegation**
+
+- Delegation updated on Mar 10, 2024 to 0xabc (50%), alice.eth (50%)
+`
+	assert.Equal(t, expected, digest.Markdown)
+}
+
+func TestBuildFinalState(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	ens := dao.ShortDAO{ID: uuid.New(), Name: "ENS"}
+
+	final := newProposal("final", ens, proposal.FinalState, now.Add(-20*24*time.Hour), now.Add(-24*time.Hour))
+	final.Scores = []float64{30, 10}
+	final.ScoresTotal = helpers.Ptr(40.0)
+
+	digest := Build(PeriodWeek, now, []proposal.Proposal{final}, nil)
+
+	require.Len(t, digest.Groups, 1)
+	require.Len(t, digest.Groups[0].Ended, 1)
+	assert.Equal(t, "For", digest.Groups[0].Ended[0].WinningChoice)
+	assert.Empty(t, digest.Groups[0].NewProposals)
+}
+
+func TestRenderMarkdownEmpty(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	digest := Build(PeriodDay, now, nil, nil)
+
+	assert.Empty(t, digest.Groups)
+	assert.Equal(t, "# Daily governance digest\n\n_Mar 9, 2024 – Mar 10, 2024_\n\nNothing new in your DAOs for this period.\n", digest.Markdown)
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	assert.Equal(t, `\[Title\](http://x) \*bold\*`, escapeMarkdown("[Title](http://x)\n*bold*"))
+}
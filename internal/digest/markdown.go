@@ -0,0 +1,117 @@
+package digest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	entity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/digest"
+)
+
+const (
+	markdownDateLayout     = "Jan 2, 2006"
+	markdownDateTimeLayout = "Jan 2, 15:04 MST"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"#", `\#`,
+)
+
+// RenderMarkdown renders the digest as the markdown text suitable for emails and push bodies
+func RenderMarkdown(d entity.Digest) string {
+	var sb strings.Builder
+
+	title := "Weekly governance digest"
+	if d.Period == string(PeriodDay) {
+		title = "Daily governance digest"
+	}
+
+	fmt.Fprintf(&sb, "# %s\n\n", title)
+	fmt.Fprintf(&sb, "_%s – %s_\n", formatTime(d.From, markdownDateLayout), formatTime(d.To, markdownDateLayout))
+
+	if len(d.Groups) == 0 {
+		sb.WriteString("\nNothing new in your DAOs for this period.\n")
+
+		return sb.String()
+	}
+
+	for _, g := range d.Groups {
+		fmt.Fprintf(&sb, "\n## %s\n", escapeMarkdown(g.DAO.Name))
+
+		if len(g.NewProposals) > 0 {
+			sb.WriteString("\n**New proposals**\n\n")
+			for _, pr := range g.NewProposals {
+				fmt.Fprintf(&sb, "- %s, voting ends %s\n", escapeMarkdown(pr.Title), formatTime(pr.VotingEnd, markdownDateTimeLayout))
+			}
+		}
+
+		if len(g.EndingSoon) > 0 {
+			sb.WriteString("\n**Ending soon, not voted yet**\n\n")
+			for _, pr := range g.EndingSoon {
+				fmt.Fprintf(&sb, "- %s, ends %s\n", escapeMarkdown(pr.Title), formatTime(pr.VotingEnd, markdownDateTimeLayout))
+			}
+		}
+
+		if len(g.Ended) > 0 {
+			sb.WriteString("\n**Ended**\n\n")
+			for _, pr := range g.Ended {
+				result := "no votes"
+				if pr.WinningChoice != "" {
+					result = "winner: " + escapeMarkdown(pr.WinningChoice)
+				}
+
+				quorum := "quorum reached"
+				if !pr.QuorumReached {
+					quorum = "quorum not reached"
+				}
+
+				fmt.Fprintf(&sb, "- %s, %s, %s\n", escapeMarkdown(pr.Title), result, quorum)
+			}
+		}
+
+		if len(g.Delegations) > 0 {
+			sb.WriteString("\n**Delegation**\n\n")
+			for _, delegation := range g.Delegations {
+				fmt.Fprintf(&sb, "- Delegation updated on %s", formatTime(delegation.CreatedAt, markdownDateLayout))
+				if len(delegation.Delegates) > 0 {
+					delegates := make([]string, 0, len(delegation.Delegates))
+					for _, delegate := range delegation.Delegates {
+						name := delegate.ResolvedName
+						if name == "" {
+							name = delegate.Address
+						}
+						delegates = append(delegates, fmt.Sprintf("%s (%g%%)", escapeMarkdown(name), delegate.PercentOfDelegated))
+					}
+					fmt.Fprintf(&sb, " to %s", strings.Join(delegates, ", "))
+				}
+				if delegation.Expiration != nil && delegation.Expiration.Time != nil {
+					fmt.Fprintf(&sb, ", expires on %s", formatTime(*delegation.Expiration, markdownDateLayout))
+				}
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(strings.Join(strings.Fields(text), " "))
+}
+
+func formatTime(t common.Time, layout string) string {
+	tm := timeOf(t)
+	if tm.IsZero() {
+		return "unknown date"
+	}
+
+	return tm.UTC().Format(layout)
+}
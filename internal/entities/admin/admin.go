@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+)
+
+// PushJob is the admin push sent in background, numbers are filled when the job is finished
+type PushJob struct {
+	ID         uuid.UUID    `json:"id"`
+	Status     string       `json:"status"`
+	Recipients int          `json:"recipients"`
+	Sent       int          `json:"sent"`
+	CreatedAt  common.Time  `json:"created_at"`
+	FinishedAt *common.Time `json:"finished_at,omitempty"`
+}
+
+type FeaturedProposals struct {
+	Pinned []string `json:"pinned"`
+	Hidden []string `json:"hidden"`
+	// Resolved is the final list of featured proposals after applying pinned and hidden ones
+	Resolved []string `json:"resolved"`
+}
+
+type PurgedCache struct {
+	Dao      int `json:"dao"`
+	Proposal int `json:"proposal"`
+}
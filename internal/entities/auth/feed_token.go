@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+)
+
+type FeedToken struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	CreatedAt  common.Time  `json:"created_at"`
+	LastUsedAt *common.Time `json:"last_used_at,omitempty"`
+}
+
+// CreatedFeedToken contains secret feed URLs, they are shown only once on creation
+type CreatedFeedToken struct {
+	FeedToken
+
+	Secret      string `json:"token"`
+	AtomURL     string `json:"atom_url"`
+	RSSURL      string `json:"rss_url"`
+	CalendarURL string `json:"calendar_url"`
+}
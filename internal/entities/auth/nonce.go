@@ -0,0 +1,10 @@
+package auth
+
+import (
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+)
+
+type SiweNonce struct {
+	Nonce     string      `json:"nonce"`
+	ExpiresAt common.Time `json:"expires_at"`
+}
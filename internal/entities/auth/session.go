@@ -0,0 +1,5 @@
+package auth
+
+type RevokedSessions struct {
+	RevokedCount int `json:"revoked_count"`
+}
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+)
+
+type Token struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Scopes     []string     `json:"scopes"`
+	CreatedAt  common.Time  `json:"created_at"`
+	ExpiresAt  *common.Time `json:"expires_at,omitempty"`
+	LastUsedAt *common.Time `json:"last_used_at,omitempty"`
+}
+
+// CreatedToken contains the raw token value, it's shown only once on creation
+type CreatedToken struct {
+	Token
+
+	Secret string `json:"token"`
+}
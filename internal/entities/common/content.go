@@ -3,6 +3,7 @@ package common
 const (
 	Markdown ContentType = "markdown"
 	HTML     ContentType = "html"
+	Text     ContentType = "text"
 )
 
 type ContentType string
@@ -0,0 +1,44 @@
+package digest
+
+import (
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/dao"
+)
+
+type Digest struct {
+	Period   string      `json:"period"`
+	From     common.Time `json:"from"`
+	To       common.Time `json:"to"`
+	Groups   []Group     `json:"groups"`
+	Markdown string      `json:"markdown"`
+}
+
+type Group struct {
+	DAO          dao.ShortDAO     `json:"dao"`
+	NewProposals []Proposal       `json:"new_proposals"`
+	EndingSoon   []Proposal       `json:"ending_soon"`
+	Ended        []EndedProposal  `json:"ended"`
+	Delegations  []DelegationInfo `json:"delegations"`
+}
+
+type Proposal struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	State       string      `json:"state"`
+	VotingStart common.Time `json:"voting_start"`
+	VotingEnd   common.Time `json:"voting_end"`
+}
+
+type EndedProposal struct {
+	Proposal
+	// WinningChoice is empty if there are no votes
+	WinningChoice string `json:"winning_choice"`
+	QuorumReached bool   `json:"quorum_reached"`
+}
+
+type DelegationInfo struct {
+	CreatedAt  common.Time            `json:"created_at"`
+	TxHash     string                 `json:"tx_hash"`
+	Delegates  []dao.PreparedDelegate `json:"delegates"`
+	Expiration *common.Time           `json:"expiration,omitempty"`
+}
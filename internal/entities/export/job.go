@@ -0,0 +1,14 @@
+package export
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+)
+
+type Job struct {
+	ID         uuid.UUID    `json:"id"`
+	Status     string       `json:"status"`
+	CreatedAt  common.Time  `json:"created_at"`
+	FinishedAt *common.Time `json:"finished_at,omitempty"`
+}
@@ -50,6 +50,8 @@ type Item struct {
 	DAO          *dao.DAO           `json:"dao,omitempty"`
 	Proposal     *proposal.Proposal `json:"proposal,omitempty"`
 	Timeline     []Timeline         `json:"timeline,omitempty"`
+	// Changes describe the last edit of the proposal for proposal.updated items
+	Changes *proposal.RevisionChanges `json:"changes,omitempty"`
 }
 
 type Timeline struct {
@@ -0,0 +1,10 @@
+package feed
+
+// SearchResult is the feed item matched by the search, the title and snippets contain matches wrapped with <mark>,
+// the rest of the text is escaped
+type SearchResult struct {
+	Item     Item     `json:"item"`
+	Score    float64  `json:"score"`
+	Title    string   `json:"title"`
+	Snippets []string `json:"snippets"`
+}
@@ -0,0 +1,14 @@
+package feed
+
+import (
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+)
+
+// Snoozed is the feed item hidden from the feed until the time
+type Snoozed struct {
+	FeedItemID string      `json:"feed_item_id"`
+	ProposalID string      `json:"proposal_id,omitempty"`
+	Until      common.Time `json:"until"`
+	Remind     bool        `json:"remind"`
+	CreatedAt  common.Time `json:"created_at"`
+}
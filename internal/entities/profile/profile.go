@@ -8,6 +8,8 @@ const (
 	UnknownRole Role = ""
 	GuestRole   Role = "guest"
 	RegularRole Role = "regular"
+	// AdminRole isn't returned by the inbox api, it's assigned to regular users by configured wallets
+	AdminRole Role = "admin"
 )
 
 type Role string
@@ -15,6 +17,15 @@ type Role string
 type AuthInfo struct {
 	SessionID string  `json:"session_id"`
 	Profile   Profile `json:"profile"`
+	// Tokens are issued only if signed access tokens are enabled
+	Tokens *AccessTokens `json:"tokens,omitempty"`
+}
+
+type AccessTokens struct {
+	AccessToken           string      `json:"access_token"`
+	AccessTokenExpiresAt  common.Time `json:"access_token_expires_at"`
+	RefreshToken          string      `json:"refresh_token"`
+	RefreshTokenExpiresAt common.Time `json:"refresh_token_expires_at"`
 }
 
 type Session struct {
@@ -22,7 +33,10 @@ type Session struct {
 	CreatedAt      common.Time  `json:"created_at"`
 	DeviceID       string       `json:"device_id"`
 	DeviceName     string       `json:"device_name"`
+	AppPlatform    string       `json:"app_platform,omitempty"`
+	AppVersion     string       `json:"app_version,omitempty"`
 	LastActivityAt *common.Time `json:"last_activity_at,omitempty"`
+	Current        bool         `json:"current"`
 }
 
 type Profile struct {
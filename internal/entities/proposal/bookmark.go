@@ -0,0 +1,8 @@
+package proposal
+
+import "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+
+type Bookmark struct {
+	ProposalID string      `json:"proposal_id"`
+	CreatedAt  common.Time `json:"created_at"`
+}
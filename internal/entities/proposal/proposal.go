@@ -38,12 +38,18 @@ type Proposal struct {
 	Timeline         []Timeline         `json:"timeline,omitempty"`
 	UserVote         *Vote              `json:"user_vote"`
 	PublicUserVote   *Vote              `json:"public_user_vote"`
+	Bookmarked       bool               `json:"bookmarked"`
 }
 
 func (p *Proposal) IsActive() bool {
 	return p.State != nil && *p.State == ActiveState
 }
 
+// VotingFinished reports whether votes of the proposal can't change anymore
+func (p *Proposal) VotingFinished() bool {
+	return p.State != nil && (*p.State == ClosedState || *p.State == FinalState)
+}
+
 type AISummary struct {
 	SummaryMarkdown string `json:"summary_markdown"`
 }
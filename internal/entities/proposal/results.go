@@ -0,0 +1,46 @@
+package proposal
+
+// Results is the interpreted outcome of the voting, choices are numbered from 1 as in votes
+type Results struct {
+	Type          string         `json:"type"`
+	Choices       []ChoiceResult `json:"choices"`
+	Winners       []int          `json:"winners"`
+	ScoresTotal   float64        `json:"scores_total"`
+	VotesCount    int            `json:"votes_count"`
+	IgnoredVotes  int            `json:"ignored_votes"`
+	Quorum        float64        `json:"quorum"`
+	QuorumReached bool           `json:"quorum_reached"`
+	// Margin is the difference between percents of the winner and the runner-up
+	Margin float64 `json:"margin"`
+	// Final is set when the voting is closed, Partial is set when not all votes were counted
+	Final   bool `json:"final"`
+	Partial bool `json:"partial"`
+}
+
+type ChoiceResult struct {
+	Choice  int     `json:"choice"`
+	Title   string  `json:"title"`
+	Score   float64 `json:"score"`
+	Percent float64 `json:"percent"`
+	Winner  bool    `json:"winner"`
+}
+
+// RunoffResults are rounds of the instant runoff of ranked-choice proposals
+type RunoffResults struct {
+	Rounds       []RunoffRound `json:"rounds"`
+	Winner       *int          `json:"winner"`
+	ScoresTotal  float64       `json:"scores_total"`
+	VotesCount   int           `json:"votes_count"`
+	IgnoredVotes int           `json:"ignored_votes"`
+	Final        bool          `json:"final"`
+	Partial      bool          `json:"partial"`
+}
+
+// RunoffRound contains choices remaining in the round, percents are calculated from the voting power
+// counted in the round, so exhausted ballots aren't included
+type RunoffRound struct {
+	Round      int            `json:"round"`
+	Choices    []ChoiceResult `json:"choices"`
+	Eliminated *int           `json:"eliminated"`
+	Exhausted  float64        `json:"exhausted"`
+}
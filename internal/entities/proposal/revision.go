@@ -0,0 +1,62 @@
+package proposal
+
+import "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+type DiffOp string
+
+// Revision is the version of the proposal noticed by the service, revisions are numbered from 1
+// for display and referenced in diffs by ipfs hashes
+type Revision struct {
+	Revision  int         `json:"revision"`
+	Ipfs      string      `json:"ipfs"`
+	Title     string      `json:"title"`
+	Choices   []string    `json:"choices"`
+	CreatedAt common.Time `json:"created_at"`
+}
+
+type RevisionDiff struct {
+	From           Revision   `json:"from"`
+	To             Revision   `json:"to"`
+	TitleChanged   bool       `json:"title_changed"`
+	ChoicesChanged bool       `json:"choices_changed"`
+	Title          []DiffHunk `json:"title"`
+	Choices        []DiffHunk `json:"choices"`
+	Body           []DiffHunk `json:"body"`
+	Added          int        `json:"added"`
+	Removed        int        `json:"removed"`
+}
+
+// RevisionChanges is the short summary of the last edit attached to proposal.updated feed items,
+// From and To are ipfs hashes of the compared revisions
+type RevisionChanges struct {
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	TitleChanged   bool       `json:"title_changed"`
+	ChoicesChanged bool       `json:"choices_changed"`
+	Added          int        `json:"added"`
+	Removed        int        `json:"removed"`
+	Highlights     []DiffLine `json:"highlights"`
+}
+
+// DiffHunk is the group of changed lines with the context, line numbers start from 1
+type DiffHunk struct {
+	OldStart int        `json:"old_start"`
+	OldLines int        `json:"old_lines"`
+	NewStart int        `json:"new_start"`
+	NewLines int        `json:"new_lines"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// DiffLine has the line number in the old text, the new text or both for unchanged lines
+type DiffLine struct {
+	Op      DiffOp `json:"op"`
+	Text    string `json:"text"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+}
@@ -49,3 +49,18 @@ type Relayer struct {
 	Address string `json:"address"`
 	Receipt string `json:"receipt"`
 }
+
+// BatchVotePreparation is the prepared vote of the batch item, Error is set with the http status of the failure
+type BatchVotePreparation struct {
+	ProposalID  string                 `json:"proposal_id"`
+	Status      int                    `json:"status"`
+	Preparation *VotePreparation       `json:"preparation,omitempty"`
+	Error       map[string]interface{} `json:"error,omitempty"`
+}
+
+type BatchVoteResult struct {
+	ID     string                 `json:"id"`
+	Status int                    `json:"status"`
+	Vote   *SuccessfulVote        `json:"vote,omitempty"`
+	Error  map[string]interface{} `json:"error,omitempty"`
+}
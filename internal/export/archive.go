@@ -0,0 +1,49 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Archive is the zip archive with JSON files of the user data
+type Archive struct {
+	buf    bytes.Buffer
+	writer *zip.Writer
+}
+
+func NewArchive() *Archive {
+	a := &Archive{}
+	a.writer = zip.NewWriter(&a.buf)
+
+	return a
+}
+
+// AddJSON writes the value as the indented JSON file
+func (a *Archive) AddJSON(name string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+
+	f, err := a.writer.Create(name)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+
+	if _, err = f.Write(data); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Bytes closes the archive and returns its content
+func (a *Archive) Bytes() ([]byte, error) {
+	if err := a.writer.Close(); err != nil {
+		return nil, fmt.Errorf("close archive: %w", err)
+	}
+
+	return a.buf.Bytes(), nil
+}
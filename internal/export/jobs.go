@@ -0,0 +1,264 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusReady   Status = "ready"
+	StatusFailed  Status = "failed"
+)
+
+// finishMargin is the time for storing the result after the timeout of the export,
+// pending jobs older than the timeout with the margin are lost with their instance
+const finishMargin = time.Minute
+
+var ErrArchiveNotFound = errors.New("export archive not found")
+
+// Collector assembles the archive with the user data
+type Collector func(ctx context.Context) ([]byte, error)
+
+type Job struct {
+	ID         uuid.UUID   `json:"id"`
+	UserID     auth.UserID `json:"-"`
+	Status     Status      `json:"status"`
+	CreatedAt  time.Time   `json:"created_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+}
+
+func (j Job) expired(now time.Time, ttl time.Duration) bool {
+	return j.FinishedAt != nil && now.Sub(*j.FinishedAt) > ttl
+}
+
+// Jobs runs exports in background, so collecting the data of large accounts isn't limited by the request timeout.
+// Each user has only one export. Jobs and ready archives are shared by all instances, so the client polls any of them;
+// the archive is kept until the TTL is expired.
+type Jobs struct {
+	jobs     *kvstore.Store[Job]
+	archives kvstore.Objects
+	ttl      time.Duration
+	timeout  time.Duration
+	slots    chan struct{}
+}
+
+func NewJobs(cfg config.Export, jobs kvstore.Bucket, archives kvstore.Objects) *Jobs {
+	return &Jobs{
+		jobs:     kvstore.New[Job](jobs),
+		archives: archives,
+		ttl:      cfg.TTL,
+		timeout:  cfg.Timeout,
+		slots:    make(chan struct{}, max(cfg.Concurrency, 1)),
+	}
+}
+
+// Start returns the current export of the user if it's pending or ready, otherwise runs the new one
+func (j *Jobs) Start(userID auth.UserID, collect Collector) (Job, error) {
+	now := time.Now()
+	job := Job{
+		ID:        uuid.New(),
+		Status:    StatusPending,
+		CreatedAt: now,
+	}
+
+	current, err := j.jobs.Update(jobKey(userID), func(value *Job) error {
+		if value.ID != uuid.Nil && j.status(*value, now) != StatusFailed && !value.expired(now, j.ttl) {
+			return kvstore.ErrUnchanged
+		}
+
+		*value = job
+
+		return nil
+	})
+	if err != nil {
+		return Job{}, fmt.Errorf("start export: %w", err)
+	}
+
+	current.UserID = userID
+	current.Status = j.status(current, now)
+	if current.ID == job.ID {
+		go j.run(current, collect)
+	}
+
+	return current, nil
+}
+
+func (j *Jobs) Get(userID auth.UserID) (Job, bool, error) {
+	job, err := j.jobs.Get(jobKey(userID))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return Job{}, false, nil
+	}
+	if err != nil {
+		return Job{}, false, fmt.Errorf("get export: %w", err)
+	}
+
+	now := time.Now()
+	if job.expired(now, j.ttl) {
+		return Job{}, false, nil
+	}
+
+	job.UserID = userID
+	job.Status = j.status(job, now)
+
+	return job, true, nil
+}
+
+// Archive returns the archive of the ready job
+func (j *Jobs) Archive(job Job) ([]byte, error) {
+	archive, err := j.archives.GetBytes(job.ID.String())
+	if errors.Is(err, nats.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrArchiveNotFound, job.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get export archive: %s: %w", job.ID, err)
+	}
+
+	return archive, nil
+}
+
+// Move gives the ready export of the user to another one unless it has its own export. Pending exports are deleted:
+// they are finished by the key of the user.
+func (j *Jobs) Move(from, to auth.UserID) error {
+	job, found, err := j.Get(from)
+	if err != nil {
+		return err
+	}
+	if !found || job.Status != StatusReady {
+		return j.Delete(from)
+	}
+
+	now := time.Now()
+	current, err := j.jobs.Update(jobKey(to), func(value *Job) error {
+		if value.ID != uuid.Nil && j.status(*value, now) != StatusFailed && !value.expired(now, j.ttl) {
+			return kvstore.ErrUnchanged
+		}
+
+		*value = job
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("move export: %w", err)
+	}
+
+	if current.ID != job.ID {
+		return j.Delete(from)
+	}
+
+	if err := j.jobs.Delete(jobKey(from)); err != nil {
+		return fmt.Errorf("delete export: %w", err)
+	}
+
+	return nil
+}
+
+func (j *Jobs) Delete(userID auth.UserID) error {
+	job, err := j.jobs.Get(jobKey(userID))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get export: %w", err)
+	}
+
+	if err := j.jobs.Delete(jobKey(userID)); err != nil {
+		return fmt.Errorf("delete export: %w", err)
+	}
+
+	return j.deleteArchive(job.ID)
+}
+
+// status reports pending jobs lost with the stopped instance as failed, so they are restarted
+func (j *Jobs) status(job Job, now time.Time) Status {
+	if job.Status == StatusPending && now.Sub(job.CreatedAt) > j.timeout+finishMargin {
+		return StatusFailed
+	}
+
+	return job.Status
+}
+
+func (j *Jobs) run(job Job, collect Collector) {
+	// the timeout includes waiting for the slot, so the job is never pending longer than the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+
+	var (
+		archive []byte
+		err     error
+	)
+	select {
+	case j.slots <- struct{}{}:
+		archive, err = collect(ctx)
+		<-j.slots
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", job.UserID.String()).Msg("collect export")
+	}
+
+	if err := j.finish(job, archive, err); err != nil {
+		log.Error().Err(err).Str("user_id", job.UserID.String()).Msg("finish export")
+	}
+}
+
+func (j *Jobs) finish(job Job, archive []byte, collectErr error) error {
+	status := StatusReady
+	if collectErr != nil {
+		status = StatusFailed
+	} else if _, err := j.archives.PutBytes(job.ID.String(), archive); err != nil {
+		log.Error().Err(err).Str("user_id", job.UserID.String()).Msg("store export archive")
+
+		status = StatusFailed
+	}
+
+	deleted := false
+	_, err := j.jobs.Update(jobKey(job.UserID), func(current *Job) error {
+		if current.ID != job.ID {
+			deleted = true
+
+			return kvstore.ErrUnchanged
+		}
+
+		now := time.Now()
+		current.FinishedAt = &now
+		current.Status = status
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("update export: %w", err)
+	}
+
+	if deleted && status == StatusReady {
+		// the export was deleted while it was collected
+		return j.deleteArchive(job.ID)
+	}
+
+	return nil
+}
+
+func (j *Jobs) deleteArchive(id uuid.UUID) error {
+	if err := j.archives.Delete(id.String()); err != nil && !errors.Is(err, nats.ErrObjectNotFound) {
+		return fmt.Errorf("delete export archive: %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func jobKey(userID auth.UserID) string {
+	return kvstore.Key("user", userID.String())
+}
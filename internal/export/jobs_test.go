@@ -0,0 +1,233 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func awaitJob(t *testing.T, jobs *Jobs, userID auth.UserID) Job {
+	var job Job
+	require.Eventually(t, func() bool {
+		var (
+			ok  bool
+			err error
+		)
+		job, ok, err = jobs.Get(userID)
+		require.NoError(t, err)
+
+		return ok && job.Status != StatusPending
+	}, time.Second, 10*time.Millisecond)
+
+	return job
+}
+
+func newTestJobs(cfg config.Export) *Jobs {
+	return NewJobs(cfg, kvstoretest.NewBucket(), kvstoretest.NewObjects())
+}
+
+func start(t *testing.T, jobs *Jobs, userID auth.UserID, collect Collector) Job {
+	job, err := jobs.Start(userID, collect)
+	require.NoError(t, err)
+
+	return job
+}
+
+func TestJobs(t *testing.T) {
+	cfg := config.Export{Timeout: time.Second, TTL: time.Hour, Concurrency: 1}
+
+	t.Run("ready archive", func(t *testing.T) {
+		jobs := newTestJobs(cfg)
+		userID := auth.UserID(uuid.New())
+
+		release := make(chan struct{})
+		started := start(t, jobs, userID, func(ctx context.Context) ([]byte, error) {
+			<-release
+
+			archive := NewArchive()
+			require.NoError(t, archive.AddJSON("profile.json", map[string]string{"id": userID.String()}))
+
+			return archive.Bytes()
+		})
+		assert.Equal(t, StatusPending, started.Status)
+
+		// the pending job is returned instead of starting the new one
+		again := start(t, jobs, userID, func(ctx context.Context) ([]byte, error) {
+			return nil, errors.New("must not be called")
+		})
+		assert.Equal(t, started.ID, again.ID)
+
+		close(release)
+		job := awaitJob(t, jobs, userID)
+		require.Equal(t, StatusReady, job.Status)
+		require.NotNil(t, job.FinishedAt)
+
+		archive, err := jobs.Archive(job)
+		require.NoError(t, err)
+
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		require.NoError(t, err)
+		require.Len(t, reader.File, 1)
+		assert.Equal(t, "profile.json", reader.File[0].Name)
+
+		f, err := reader.File[0].Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), userID.String())
+	})
+
+	t.Run("failed job is restarted", func(t *testing.T) {
+		jobs := newTestJobs(cfg)
+		userID := auth.UserID(uuid.New())
+
+		start(t, jobs, userID, func(ctx context.Context) ([]byte, error) {
+			return nil, errors.New("unavailable")
+		})
+		failed := awaitJob(t, jobs, userID)
+		assert.Equal(t, StatusFailed, failed.Status)
+		_, err := jobs.Archive(failed)
+		assert.ErrorIs(t, err, ErrArchiveNotFound)
+
+		restarted := start(t, jobs, userID, func(ctx context.Context) ([]byte, error) {
+			return []byte("archive"), nil
+		})
+		assert.NotEqual(t, failed.ID, restarted.ID)
+		assert.Equal(t, StatusReady, awaitJob(t, jobs, userID).Status)
+	})
+
+	t.Run("expired archive", func(t *testing.T) {
+		jobs := newTestJobs(config.Export{Timeout: time.Second, TTL: time.Millisecond})
+		userID := auth.UserID(uuid.New())
+
+		start(t, jobs, userID, func(ctx context.Context) ([]byte, error) {
+			return []byte("archive"), nil
+		})
+		require.Eventually(t, func() bool {
+			_, ok, err := jobs.Get(userID)
+			require.NoError(t, err)
+
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("deleted while collecting", func(t *testing.T) {
+		jobs := newTestJobs(cfg)
+		userID := auth.UserID(uuid.New())
+
+		release := make(chan struct{})
+		start(t, jobs, userID, func(ctx context.Context) ([]byte, error) {
+			<-release
+
+			return []byte("archive"), nil
+		})
+		require.NoError(t, jobs.Delete(userID))
+		close(release)
+
+		time.Sleep(50 * time.Millisecond)
+		_, ok, err := jobs.Get(userID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("jobs are shared between instances", func(t *testing.T) {
+		bucket, archives := kvstoretest.NewBucket(), kvstoretest.NewObjects()
+		first, second := NewJobs(cfg, bucket, archives), NewJobs(cfg, bucket, archives)
+		userID := auth.UserID(uuid.New())
+
+		started := start(t, first, userID, func(ctx context.Context) ([]byte, error) {
+			return []byte("archive"), nil
+		})
+
+		job := awaitJob(t, second, userID)
+		assert.Equal(t, started.ID, job.ID)
+		require.Equal(t, StatusReady, job.Status)
+
+		archive, err := second.Archive(job)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("archive"), archive)
+	})
+
+	t.Run("pending job of the stopped instance is failed", func(t *testing.T) {
+		bucket := kvstoretest.NewBucket()
+		userID := auth.UserID(uuid.New())
+
+		stopped := Job{ID: uuid.New(), Status: StatusPending, CreatedAt: time.Now().Add(-cfg.Timeout - finishMargin - time.Second)}
+		data, err := json.Marshal(stopped)
+		require.NoError(t, err)
+		_, err = bucket.Put(jobKey(userID), data)
+		require.NoError(t, err)
+
+		jobs := NewJobs(cfg, bucket, kvstoretest.NewObjects())
+		job, ok, err := jobs.Get(userID)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, StatusFailed, job.Status)
+
+		restarted := start(t, jobs, userID, func(ctx context.Context) ([]byte, error) {
+			return []byte("archive"), nil
+		})
+		assert.NotEqual(t, stopped.ID, restarted.ID)
+	})
+	t.Run("ready export is moved", func(t *testing.T) {
+		jobs := newTestJobs(cfg)
+		guest, regular := auth.UserID(uuid.New()), auth.UserID(uuid.New())
+
+		started := start(t, jobs, guest, func(ctx context.Context) ([]byte, error) {
+			return []byte("archive"), nil
+		})
+		awaitJob(t, jobs, guest)
+
+		require.NoError(t, jobs.Move(guest, regular))
+
+		_, ok, err := jobs.Get(guest)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		job, ok, err := jobs.Get(regular)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, started.ID, job.ID)
+
+		archive, err := jobs.Archive(job)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("archive"), archive)
+	})
+
+	t.Run("own export is kept on move", func(t *testing.T) {
+		jobs := newTestJobs(cfg)
+		guest, regular := auth.UserID(uuid.New()), auth.UserID(uuid.New())
+
+		start(t, jobs, guest, func(ctx context.Context) ([]byte, error) {
+			return []byte("guest"), nil
+		})
+		guestJob := awaitJob(t, jobs, guest)
+		own := start(t, jobs, regular, func(ctx context.Context) ([]byte, error) {
+			return []byte("regular"), nil
+		})
+		awaitJob(t, jobs, regular)
+
+		require.NoError(t, jobs.Move(guest, regular))
+
+		job, ok, err := jobs.Get(regular)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, own.ID, job.ID)
+
+		_, err = jobs.Archive(guestJob)
+		assert.ErrorIs(t, err, ErrArchiveNotFound)
+	})
+}
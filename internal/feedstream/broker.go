@@ -0,0 +1,126 @@
+package feedstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/goverland-labs/goverland-platform-events/events/inbox"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+)
+
+// Broker connects the hub with NATS. Core subscriptions are used instead of consumers, so each instance
+// of the service receives all messages and delivers them to its own connections.
+type Broker struct {
+	nc  *nats.Conn
+	hub *Hub
+
+	mu   sync.Mutex
+	subs []*nats.Subscription
+	stop chan struct{}
+}
+
+func NewBroker(nc *nats.Conn, hub *Hub) *Broker {
+	return &Broker{
+		nc:   nc,
+		hub:  hub,
+		stop: make(chan struct{}),
+	}
+}
+
+// PublishState notifies all instances about changes of feed items of the user
+func (b *Broker) PublishState(payload StatePayload) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Msg("marshal feed state")
+
+		return
+	}
+
+	if err = b.nc.Publish(SubjectFeedState, data); err != nil {
+		log.Error().Err(err).Str("user_id", payload.UserID.String()).Msg("publish feed state")
+	}
+}
+
+// Start subscribes to subjects and blocks until the broker is stopped
+func (b *Broker) Start() error {
+	b.mu.Lock()
+	feedSub, err := b.nc.Subscribe(inbox.SubjectFeedUpdated, b.handleFeedUpdated)
+	if err != nil {
+		b.mu.Unlock()
+
+		return fmt.Errorf("subscribe %s: %w", inbox.SubjectFeedUpdated, err)
+	}
+
+	stateSub, err := b.nc.Subscribe(SubjectFeedState, b.handleFeedState)
+	if err != nil {
+		_ = feedSub.Unsubscribe()
+		b.mu.Unlock()
+
+		return fmt.Errorf("subscribe %s: %w", SubjectFeedState, err)
+	}
+
+	b.subs = []*nats.Subscription{feedSub, stateSub}
+	b.mu.Unlock()
+
+	<-b.stop
+
+	return nil
+}
+
+func (b *Broker) Stop() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		if err := sub.Unsubscribe(); err != nil {
+			log.Error().Err(err).Str("subject", sub.Subject).Msg("unsubscribe feed stream")
+		}
+	}
+	b.subs = nil
+
+	select {
+	case <-b.stop:
+	default:
+		close(b.stop)
+	}
+
+	return nil
+}
+
+func (b *Broker) handleFeedUpdated(msg *nats.Msg) {
+	var payload inbox.FeedPayload
+	if err := json.Unmarshal(msg.Data, &payload); err != nil {
+		log.Error().Err(err).Msg("unmarshal feed payload")
+
+		return
+	}
+
+	b.hub.PublishDao(payload.DaoID.String(), EventFeedItem, FeedItemPayload{
+		DaoID:        payload.DaoID,
+		ProposalID:   payload.ProposalID,
+		DiscussionID: payload.DiscussionID,
+		Type:         string(payload.Type),
+		Action:       string(payload.Action),
+	})
+}
+
+func (b *Broker) handleFeedState(msg *nats.Msg) {
+	var payload StatePayload
+	if err := json.Unmarshal(msg.Data, &payload); err != nil {
+		log.Error().Err(err).Msg("unmarshal feed state")
+
+		return
+	}
+
+	userID := auth.UserID(payload.UserID)
+	b.hub.Publish(userID, EventFeedState, payload)
+	b.hub.Publish(userID, EventUnread, UnreadPayload{UnreadCount: payload.UnreadCount})
+}
+
+func (b *Broker) Hub() *Hub {
+	return b.hub
+}
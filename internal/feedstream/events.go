@@ -0,0 +1,59 @@
+package feedstream
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// SubjectFeedState is published by instances of the service on changes of feed items state,
+// so the connected clients receive the changes regardless of the instance which handled the request
+const SubjectFeedState = "inbox.web.feed.state"
+
+type EventType string
+
+const (
+	// EventFeedItem is sent when the new item appears in the feed of the subscribed dao
+	EventFeedItem EventType = "feed.item"
+	// EventFeedState is sent when items are marked as read, unread, archived or unarchived
+	EventFeedState EventType = "feed.state"
+	// EventUnread is sent with the actual value of the unread counter
+	EventUnread EventType = "feed.unread"
+	// EventReset is sent when events since Last-Event-ID can't be restored, the client has to reload the feed
+	EventReset EventType = "reset"
+)
+
+type State string
+
+const (
+	StateRead       State = "read"
+	StateUnread     State = "unread"
+	StateArchived   State = "archived"
+	StateUnarchived State = "unarchived"
+)
+
+type Event struct {
+	ID   uint64
+	Type EventType
+	Data json.RawMessage
+}
+
+type FeedItemPayload struct {
+	DaoID        uuid.UUID `json:"dao_id"`
+	ProposalID   string    `json:"proposal_id,omitempty"`
+	DiscussionID string    `json:"discussion_id,omitempty"`
+	Type         string    `json:"type"`
+	Action       string    `json:"action"`
+}
+
+type StatePayload struct {
+	UserID uuid.UUID `json:"user_id"`
+	// IDs is empty if items are marked by the time
+	IDs         []string `json:"ids"`
+	State       State    `json:"state"`
+	UnreadCount int      `json:"unread_count"`
+}
+
+type UnreadPayload struct {
+	UnreadCount int `json:"unread_count"`
+}
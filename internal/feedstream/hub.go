@@ -0,0 +1,213 @@
+package feedstream
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+)
+
+const (
+	subscriberBuffer = 64
+	cleanPeriod      = time.Minute
+)
+
+// DaoFilter checks if the user is subscribed to the dao
+type DaoFilter func(daoID string) bool
+
+type Subscriber struct {
+	userID auth.UserID
+	events chan Event
+	done   chan struct{}
+}
+
+// Events returns events of the user in order of publishing
+func (s *Subscriber) Events() <-chan Event {
+	return s.events
+}
+
+// Done is closed if the subscriber is dropped because it doesn't read events in time
+func (s *Subscriber) Done() <-chan struct{} {
+	return s.done
+}
+
+type userState struct {
+	subscribers map[*Subscriber]struct{}
+	filter      DaoFilter
+	history     []Event
+	// evicted is the id of the last event removed from the history
+	evicted    uint64
+	lastSeenAt time.Time
+}
+
+// Hub delivers events to connected users and keeps the short history of events for resuming the stream.
+// Event ids are unique within the instance only, so resuming on another instance or after restart leads to reset.
+type Hub struct {
+	mu          sync.Mutex
+	users       map[auth.UserID]*userState
+	firstID     uint64
+	lastID      uint64
+	historySize int
+	historyTTL  time.Duration
+	lastCleanAt time.Time
+}
+
+func NewHub(cfg config.FeedStream) *Hub {
+	now := time.Now()
+	first := uint64(now.UnixNano())
+
+	return &Hub{
+		users:       make(map[auth.UserID]*userState),
+		firstID:     first,
+		lastID:      first,
+		historySize: max(cfg.HistorySize, 1),
+		historyTTL:  cfg.HistoryTTL,
+		lastCleanAt: now,
+	}
+}
+
+// Subscribe connects the user to the hub. If lastEventID is set, missed events are returned for replaying,
+// reset is true if they can't be restored.
+func (h *Hub) Subscribe(userID auth.UserID, filter DaoFilter, lastEventID *uint64) (sub *Subscriber, missed []Event, reset bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	state, known := h.users[userID]
+	if !known {
+		state = &userState{subscribers: make(map[*Subscriber]struct{})}
+		h.users[userID] = state
+	}
+	state.filter = filter
+
+	sub = &Subscriber{
+		userID: userID,
+		events: make(chan Event, subscriberBuffer),
+		done:   make(chan struct{}),
+	}
+	state.subscribers[sub] = struct{}{}
+
+	if lastEventID == nil {
+		return sub, nil, false
+	}
+
+	last := *lastEventID
+	if last < h.firstID || last > h.lastID || last < state.evicted || (!known && last < h.lastID) {
+		return sub, nil, true
+	}
+
+	for _, event := range state.history {
+		if event.ID > last {
+			missed = append(missed, event)
+		}
+	}
+
+	return sub, missed, false
+}
+
+func (h *Hub) Unsubscribe(sub *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(sub)
+}
+
+// Publish sends the event to the user
+func (h *Hub) Publish(userID auth.UserID, eventType EventType, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Str("event", string(eventType)).Msg("marshal feed stream event")
+
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.publish(userID, eventType, data)
+	h.clean(time.Now())
+}
+
+// PublishDao sends the event to users subscribed to the dao. Only users connected recently are checked,
+// others will load the feed on connection.
+func (h *Hub) PublishDao(daoID string, eventType EventType, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Str("event", string(eventType)).Msg("marshal feed stream event")
+
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for userID, state := range h.users {
+		if state.filter == nil || !state.filter(daoID) {
+			continue
+		}
+
+		h.publish(userID, eventType, data)
+	}
+
+	h.clean(time.Now())
+}
+
+func (h *Hub) publish(userID auth.UserID, eventType EventType, data json.RawMessage) {
+	state, ok := h.users[userID]
+	if !ok {
+		return
+	}
+
+	h.lastID++
+	event := Event{ID: h.lastID, Type: eventType, Data: data}
+
+	state.history = append(state.history, event)
+	if len(state.history) > h.historySize {
+		state.evicted = state.history[0].ID
+		state.history = state.history[1:]
+	}
+
+	for sub := range state.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			log.Warn().Str("user_id", userID.String()).Msg("drop slow feed stream subscriber")
+
+			h.remove(sub)
+			close(sub.done)
+		}
+	}
+}
+
+func (h *Hub) remove(sub *Subscriber) {
+	state, ok := h.users[sub.userID]
+	if !ok {
+		return
+	}
+
+	if _, ok := state.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(state.subscribers, sub)
+	if len(state.subscribers) == 0 {
+		state.lastSeenAt = time.Now()
+	}
+}
+
+func (h *Hub) clean(now time.Time) {
+	if now.Sub(h.lastCleanAt) < cleanPeriod {
+		return
+	}
+
+	for userID, state := range h.users {
+		if len(state.subscribers) == 0 && now.Sub(state.lastSeenAt) > h.historyTTL {
+			delete(h.users, userID)
+		}
+	}
+
+	h.lastCleanAt = now
+}
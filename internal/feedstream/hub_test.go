@@ -0,0 +1,119 @@
+package feedstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+)
+
+func newTestHub(historySize int) *Hub {
+	return NewHub(config.FeedStream{HistorySize: historySize, HistoryTTL: time.Minute})
+}
+
+func subscribedTo(daos ...string) DaoFilter {
+	return func(daoID string) bool {
+		for _, id := range daos {
+			if id == daoID {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func receive(t *testing.T, sub *Subscriber) Event {
+	select {
+	case event := <-sub.Events():
+		return event
+	case <-time.After(time.Second):
+		require.Fail(t, "event is not received")
+
+		return Event{}
+	}
+}
+
+func TestHub(t *testing.T) {
+	t.Run("dao events are sent to subscribers of the dao", func(t *testing.T) {
+		hub := newTestHub(10)
+		first, second := auth.UserID(uuid.New()), auth.UserID(uuid.New())
+
+		firstSub, _, _ := hub.Subscribe(first, subscribedTo("dao-1"), nil)
+		secondSub, _, _ := hub.Subscribe(second, subscribedTo("dao-2"), nil)
+
+		hub.PublishDao("dao-1", EventFeedItem, FeedItemPayload{ProposalID: "proposal"})
+
+		event := receive(t, firstSub)
+		assert.Equal(t, EventFeedItem, event.Type)
+		assert.JSONEq(t, `{"dao_id":"00000000-0000-0000-0000-000000000000","proposal_id":"proposal","type":"","action":""}`, string(event.Data))
+		assert.Empty(t, secondSub.Events())
+	})
+
+	t.Run("resume by last event id", func(t *testing.T) {
+		hub := newTestHub(10)
+		userID := auth.UserID(uuid.New())
+
+		sub, _, _ := hub.Subscribe(userID, nil, nil)
+		hub.Publish(userID, EventUnread, UnreadPayload{UnreadCount: 1})
+		last := receive(t, sub)
+		hub.Unsubscribe(sub)
+
+		hub.Publish(userID, EventUnread, UnreadPayload{UnreadCount: 2})
+		hub.Publish(userID, EventUnread, UnreadPayload{UnreadCount: 3})
+
+		_, missed, reset := hub.Subscribe(userID, nil, &last.ID)
+		assert.False(t, reset)
+		require.Len(t, missed, 2)
+		assert.JSONEq(t, `{"unread_count":2}`, string(missed[0].Data))
+		assert.JSONEq(t, `{"unread_count":3}`, string(missed[1].Data))
+		assert.Greater(t, missed[1].ID, missed[0].ID)
+	})
+
+	t.Run("reset if events are evicted", func(t *testing.T) {
+		hub := newTestHub(1)
+		userID := auth.UserID(uuid.New())
+
+		sub, _, _ := hub.Subscribe(userID, nil, nil)
+		hub.Publish(userID, EventUnread, UnreadPayload{UnreadCount: 1})
+		last := receive(t, sub)
+		hub.Unsubscribe(sub)
+
+		hub.Publish(userID, EventUnread, UnreadPayload{UnreadCount: 2})
+		hub.Publish(userID, EventUnread, UnreadPayload{UnreadCount: 3})
+
+		_, missed, reset := hub.Subscribe(userID, nil, &last.ID)
+		assert.True(t, reset)
+		assert.Empty(t, missed)
+	})
+
+	t.Run("reset for unknown event id", func(t *testing.T) {
+		hub := newTestHub(10)
+		userID := auth.UserID(uuid.New())
+
+		unknown := uint64(1)
+		_, _, reset := hub.Subscribe(userID, nil, &unknown)
+		assert.True(t, reset)
+	})
+
+	t.Run("slow subscriber is dropped", func(t *testing.T) {
+		hub := newTestHub(10)
+		userID := auth.UserID(uuid.New())
+
+		sub, _, _ := hub.Subscribe(userID, nil, nil)
+		for i := 0; i <= subscriberBuffer; i++ {
+			hub.Publish(userID, EventUnread, UnreadPayload{UnreadCount: i})
+		}
+
+		select {
+		case <-sub.Done():
+		default:
+			assert.Fail(t, "subscriber is not dropped")
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package helpers
+
+import (
+	"slices"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+)
+
+// ExcerptLength is the length of plain-text bodies in runes, it's enough for previews and push notifications
+const ExcerptLength = 280
+
+// RenderBody converts the markdown content to requested formats, the order of formats is kept
+func RenderBody(body []common.Content, formats []common.ContentType) []common.Content {
+	if slices.Equal(formats, []common.ContentType{common.Markdown}) {
+		return body
+	}
+
+	var md string
+	for _, content := range body {
+		if content.Type == common.Markdown {
+			md = content.Body
+			break
+		}
+	}
+
+	rendered := make([]common.Content, 0, len(formats))
+	for _, format := range formats {
+		switch format {
+		case common.Markdown:
+			rendered = append(rendered, common.Content{Type: common.Markdown, Body: md})
+		case common.HTML:
+			rendered = append(rendered, common.Content{Type: common.HTML, Body: CompileSafeMarkdown(md)})
+		case common.Text:
+			rendered = append(rendered, common.Content{Type: common.Text, Body: Excerpt(MarkdownText(md), ExcerptLength)})
+		}
+	}
+
+	return rendered
+}
+
+func RenderProposalBody(p proposal.Proposal, formats []common.ContentType) proposal.Proposal {
+	p.Body = RenderBody(p.Body, formats)
+
+	return p
+}
+
+func RenderProposalsBody(list []proposal.Proposal, formats []common.ContentType) []proposal.Proposal {
+	for i := range list {
+		list[i] = RenderProposalBody(list[i], formats)
+	}
+
+	return list
+}
+
+func RenderFeedItemBody(f feed.Item, formats []common.ContentType) feed.Item {
+	if f.Proposal != nil {
+		*f.Proposal = RenderProposalBody(*f.Proposal, formats)
+	}
+
+	return f
+}
+
+func RenderFeedItemsBody(list []feed.Item, formats []common.ContentType) []feed.Item {
+	for i := range list {
+		list[i] = RenderFeedItemBody(list[i], formats)
+	}
+
+	return list
+}
@@ -2,22 +2,88 @@ package helpers
 
 import (
 	"regexp"
+	"strings"
+	"unicode"
 
 	"github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/ast"
 	"github.com/gomarkdown/markdown/html"
 	"github.com/gomarkdown/markdown/parser"
 )
 
-var renderer = html.NewRenderer(html.RendererOptions{Flags: html.CommonFlags | html.HrefTargetBlank})
-
 var inlineImage = regexp.MustCompile(`\n(!\[.*\]\(.*\))\n`)
 
 func CompileMarkdown(md string) string {
 	tree := markdown.Parse([]byte(md), parser.New())
+	// the renderer keeps the state between calls, so it's not shared
+	renderer := html.NewRenderer(html.RendererOptions{Flags: html.CommonFlags | html.HrefTargetBlank})
 
 	return string(markdown.Render(tree, renderer))
 }
 
+// CompileSafeMarkdown compiles the markdown to html which is safe to embed into pages
+func CompileSafeMarkdown(md string) string {
+	return SanitizeHTML(CompileMarkdown(md))
+}
+
+// MarkdownText strips the markdown formatting, blocks are separated by new lines
+func MarkdownText(md string) string {
+	tree := markdown.Parse([]byte(md), parser.New())
+
+	var sb strings.Builder
+	ast.WalkFunc(tree, func(node ast.Node, entering bool) ast.WalkStatus {
+		switch n := node.(type) {
+		case *ast.Text:
+			if entering {
+				sb.Write(n.Literal)
+			}
+		case *ast.Code:
+			if entering {
+				sb.Write(n.Literal)
+			}
+		case *ast.CodeBlock:
+			if entering {
+				sb.Write(n.Literal)
+				sb.WriteByte('\n')
+			}
+		case *ast.Softbreak, *ast.Hardbreak:
+			if entering {
+				sb.WriteByte(' ')
+			}
+		case *ast.Paragraph, *ast.Heading, *ast.TableCell:
+			if !entering {
+				sb.WriteByte('\n')
+			}
+		}
+
+		return ast.GoToNext
+	})
+
+	return strings.TrimSpace(sb.String())
+}
+
+// Excerpt joins lines of the text and cuts it by the word boundary up to the limit in runes
+func Excerpt(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	cut := limit
+	for cut > 0 && !unicode.IsSpace(runes[cut]) {
+		cut--
+	}
+	if cut == 0 {
+		cut = limit
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
 func ReplaceInlineImages(text string) string {
 	return inlineImage.ReplaceAllString(text, "\n\n$1\n\n")
 }
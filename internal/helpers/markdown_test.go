@@ -58,3 +58,108 @@ Game Maker
 		})
 	}
 }
+
+func TestCompileSafeMarkdown(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in   string
+		want string
+	}{
+		"markdown": {
+			in:   "# Title\n\nSome **bold** and [link](https://goverland.xyz).",
+			want: "<h1>Title</h1>\n\n<p>Some <strong>bold</strong> and <a href=\"https://goverland.xyz\" target=\"_blank\" rel=\"noopener noreferrer nofollow\">link</a>.</p>\n",
+		},
+		"image": {
+			in:   `![img](https://example.com/a.png "title")`,
+			want: "<p><img src=\"https://example.com/a.png\" alt=\"img\" title=\"title\"></p>\n",
+		},
+		"code": {
+			in:   "`<script>`",
+			want: "<p><code>&lt;script&gt;</code></p>\n",
+		},
+		"script": {
+			in:   "<script>alert(1)</script>text",
+			want: "<p>text</p>\n",
+		},
+		"nested script": {
+			in:   "<<script>script>alert(1)<</script>/script>",
+			want: "<p>&lt;/script&gt;</p>\n",
+		},
+		"javascript link": {
+			in:   "[click](javascript:alert(1))",
+			want: "<p><a>click</a></p>\n",
+		},
+		"javascript link in mixed case": {
+			in:   "[click](JaVaScRiPt:alert(1))",
+			want: "<p><a>click</a></p>\n",
+		},
+		"javascript link with entities": {
+			in:   `<a href="jav&#x09;ascript:alert(1)">x</a>`,
+			want: "<p><a>x</a></p>\n",
+		},
+		"javascript link with spaces": {
+			in:   `<a href=" javascript:alert(1)">x</a>`,
+			want: "<p><a>x</a></p>\n",
+		},
+		"data link": {
+			in:   "[data](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)",
+			want: "<p><a>data</a></p>\n",
+		},
+		"link attributes": {
+			in:   `<a href="https://example.com" target="_self" rel="opener">ok</a>`,
+			want: "<p><a href=\"https://example.com\" target=\"_blank\" rel=\"noopener noreferrer nofollow\">ok</a></p>\n",
+		},
+		"event handler": {
+			in:   "<img src=x onerror=alert(1)>",
+			want: "<p><img></p>\n",
+		},
+		"style and event attributes": {
+			in:   `<div style="background:url(javascript:alert(1))" onclick="x()">block</div>`,
+			want: "<p><div>block</div></p>\n",
+		},
+		"iframe": {
+			in:   `<iframe src="https://evil.com"></iframe>after`,
+			want: "<p>after</p>\n",
+		},
+		"embed": {
+			in:   "<embed src=x>after embed",
+			want: "<p>after embed</p>\n",
+		},
+		"svg": {
+			in:   "<svg><script>alert(1)</script><text>hi</text></svg>tail",
+			want: "<p>tail</p>\n",
+		},
+		"math": {
+			in:   `<math><mi xlink:href="javascript:alert(1)">x</mi></math>ok`,
+			want: "<p>ok</p>\n",
+		},
+		"noscript": {
+			in:   "<noscript><img src=x onerror=alert(1)></noscript>ok",
+			want: "<p>ok</p>\n",
+		},
+		"comment and style": {
+			in:   "<!-- comment --><style>body{}</style>visible",
+			want: "<p>visible</p>\n",
+		},
+		"unclosed tags": {
+			in:   "<b>unclosed <i>tags",
+			want: "<p><b>unclosed <i>tags</i></b></p>\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, CompileSafeMarkdown(tc.in))
+		})
+	}
+}
+
+func TestMarkdownText(t *testing.T) {
+	md := "# Treasury\n\nMove **100 ETH** to the [multisig](https://example.com).\n\n- first\n- `second`"
+
+	assert.Equal(t, "Treasury\nMove 100 ETH to the multisig.\nfirst\nsecond", MarkdownText(md))
+}
+
+func TestExcerpt(t *testing.T) {
+	assert.Equal(t, "Move 100 ETH", Excerpt("Move  100\nETH", 20))
+	assert.Equal(t, "Move 100 ETH to the…", Excerpt("Move 100 ETH to the multisig.", 22))
+	assert.Equal(t, "Treasury…", Excerpt("Treasury, multisig", 12))
+	assert.Equal(t, "Treas…", Excerpt("Treasury", 5))
+}
@@ -0,0 +1,161 @@
+package helpers
+
+import (
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// allowedTags maps tags produced by the markdown renderer to their allowed attributes
+var allowedTags = map[string][]string{
+	"a": {"href", "title"}, "img": {"src", "alt", "title"},
+	"p": nil, "br": nil, "hr": nil, "blockquote": nil, "pre": nil, "code": {"class"},
+	"h1": {"id"}, "h2": {"id"}, "h3": {"id"}, "h4": {"id"}, "h5": {"id"}, "h6": {"id"},
+	"strong": nil, "b": nil, "em": nil, "i": nil, "del": nil, "s": nil, "sup": {"id"}, "sub": nil,
+	"ul": nil, "ol": {"start"}, "li": {"id"}, "dl": nil, "dt": nil, "dd": nil,
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": {"align"}, "td": {"align"},
+	"div": {"class"}, "span": nil,
+}
+
+// droppedTags are removed together with the content
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true, "object": true,
+	"embed": true, "applet": true, "noscript": true, "noembed": true, "noframes": true, "template": true,
+	"textarea": true, "select": true, "title": true, "xmp": true, "svg": true, "math": true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true, "embed": true, "frame": true}
+
+var (
+	linkSchemes  = []string{"http", "https", "mailto"}
+	imageSchemes = []string{"http", "https"}
+)
+
+// SanitizeHTML keeps allowed tags and attributes only, the rest of tags are stripped with the text kept.
+// Links are opened in the new tab, unclosed tags are closed at the end.
+func SanitizeHTML(src string) string {
+	var (
+		sb        strings.Builder
+		open      []string
+		skip      string
+		skipDepth int
+	)
+
+	tokenizer := html.NewTokenizer(strings.NewReader(src))
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				return ""
+			}
+
+			break
+		}
+
+		token := tokenizer.Token()
+		if skip != "" {
+			switch {
+			case tt == html.StartTagToken && token.Data == skip:
+				skipDepth++
+			case tt == html.EndTagToken && token.Data == skip:
+				skipDepth--
+				if skipDepth == 0 {
+					skip = ""
+				}
+			}
+
+			continue
+		}
+
+		switch tt {
+		case html.TextToken:
+			sb.WriteString(html.EscapeString(token.Data))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedTags[token.Data] {
+				if tt == html.StartTagToken && !voidTags[token.Data] {
+					skip, skipDepth = token.Data, 1
+				}
+
+				continue
+			}
+
+			attrs, ok := allowedTags[token.Data]
+			if !ok {
+				continue
+			}
+
+			writeStartTag(&sb, token, attrs)
+			if !voidTags[token.Data] {
+				open = append(open, token.Data)
+			}
+		case html.EndTagToken:
+			idx := slices.Index(open, token.Data)
+			if idx < 0 {
+				continue
+			}
+
+			for i := len(open) - 1; i >= idx; i-- {
+				sb.WriteString("</" + open[i] + ">")
+			}
+			open = open[:idx]
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		sb.WriteString("</" + open[i] + ">")
+	}
+
+	return sb.String()
+}
+
+func writeStartTag(sb *strings.Builder, token html.Token, allowed []string) {
+	sb.WriteString("<" + token.Data)
+
+	var link bool
+	for _, attr := range token.Attr {
+		if attr.Namespace != "" || !slices.Contains(allowed, attr.Key) {
+			continue
+		}
+
+		value := attr.Val
+		switch attr.Key {
+		case "href":
+			var ok bool
+			if value, ok = safeURL(value, linkSchemes, true); !ok {
+				continue
+			}
+			link = !strings.HasPrefix(value, "#")
+		case "src":
+			var ok bool
+			if value, ok = safeURL(value, imageSchemes, false); !ok {
+				continue
+			}
+		}
+
+		sb.WriteString(" " + attr.Key + `="` + html.EscapeString(value) + `"`)
+	}
+
+	if link {
+		sb.WriteString(` target="_blank" rel="noopener noreferrer nofollow"`)
+	}
+
+	sb.WriteString(">")
+}
+
+// safeURL accepts absolute urls with allowed schemes, fragments are accepted for links to footnotes
+func safeURL(raw string, schemes []string, fragment bool) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if fragment && strings.HasPrefix(raw, "#") {
+		return raw, true
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || !slices.Contains(schemes, strings.ToLower(u.Scheme)) {
+		return "", false
+	}
+
+	return u.String(), true
+}
@@ -0,0 +1,109 @@
+package kvstoretest
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+type entry struct {
+	key      string
+	value    []byte
+	revision uint64
+	created  time.Time
+}
+
+func (e entry) Bucket() string             { return "test" }
+func (e entry) Key() string                { return e.key }
+func (e entry) Value() []byte              { return e.value }
+func (e entry) Revision() uint64           { return e.revision }
+func (e entry) Created() time.Time         { return e.created }
+func (e entry) Delta() uint64              { return 0 }
+func (e entry) Operation() nats.KeyValueOp { return nats.KeyValuePut }
+
+// Bucket follows revisions of the JetStream bucket: the revision is increased on each change of any key
+// and updates with the outdated revision are rejected with nats.ErrKeyExists
+type Bucket struct {
+	mu       sync.Mutex
+	revision uint64
+	entries  map[string]entry
+}
+
+func NewBucket() *Bucket {
+	return &Bucket{entries: make(map[string]entry)}
+}
+
+func (b *Bucket) Get(key string) (nats.KeyValueEntry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.entries[key]
+	if !ok {
+		return nil, nats.ErrKeyNotFound
+	}
+
+	return e, nil
+}
+
+func (b *Bucket) Put(key string, value []byte) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.put(key, value), nil
+}
+
+func (b *Bucket) Create(key string, value []byte) (uint64, error) {
+	return b.Update(key, value, 0)
+}
+
+func (b *Bucket) Update(key string, value []byte, last uint64) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.entries[key].revision != last {
+		return 0, nats.ErrKeyExists
+	}
+
+	return b.put(key, value), nil
+}
+
+func (b *Bucket) Delete(key string, _ ...nats.DeleteOpt) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.revision++
+	delete(b.entries, key)
+
+	return nil
+}
+
+func (b *Bucket) Keys(_ ...nats.WatchOpt) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) == 0 {
+		return nil, nats.ErrNoKeysFound
+	}
+
+	keys := make([]string, 0, len(b.entries))
+	for key := range b.entries {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys, nil
+}
+
+func (b *Bucket) put(key string, value []byte) uint64 {
+	b.revision++
+	b.entries[key] = entry{
+		key:      key,
+		value:    slices.Clone(value),
+		revision: b.revision,
+		created:  time.Now(),
+	}
+
+	return b.revision
+}
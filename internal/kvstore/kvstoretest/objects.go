@@ -0,0 +1,51 @@
+package kvstoretest
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Objects keeps objects of the JetStream object store in memory
+type Objects struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func NewObjects() *Objects {
+	return &Objects{objects: make(map[string][]byte)}
+}
+
+func (o *Objects) PutBytes(name string, data []byte, _ ...nats.ObjectOpt) (*nats.ObjectInfo, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.objects[name] = slices.Clone(data)
+
+	return &nats.ObjectInfo{ObjectMeta: nats.ObjectMeta{Name: name}, Size: uint64(len(data))}, nil
+}
+
+func (o *Objects) GetBytes(name string, _ ...nats.GetObjectOpt) ([]byte, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	data, ok := o.objects[name]
+	if !ok {
+		return nil, nats.ErrObjectNotFound
+	}
+
+	return slices.Clone(data), nil
+}
+
+func (o *Objects) Delete(name string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.objects[name]; !ok {
+		return nats.ErrObjectNotFound
+	}
+	delete(o.objects, name)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+// Lease lets only one instance of the service run the periodic work. The holder renews the lease on each run,
+// the lease of the stopped instance expires with the TTL of the bucket, so the TTL has to be longer than the interval
+// of runs. It isn't safe for concurrent use.
+type Lease struct {
+	bucket   Bucket
+	key      string
+	holder   string
+	revision uint64
+}
+
+func NewLease(bucket Bucket, key string) *Lease {
+	return &Lease{
+		bucket: bucket,
+		key:    key,
+		holder: uuid.NewString(),
+	}
+}
+
+// Acquire takes the free lease or renews the held one, it returns false if the lease is held by another instance
+func (l *Lease) Acquire() (bool, error) {
+	var (
+		revision uint64
+		err      error
+	)
+	if l.revision != 0 {
+		revision, err = l.bucket.Update(l.key, []byte(l.holder), l.revision)
+	} else {
+		revision, err = l.bucket.Create(l.key, []byte(l.holder))
+	}
+
+	if err != nil {
+		// the lease is lost or it's unknown if it's still held, so the work has to be stopped
+		l.revision = 0
+		if errors.Is(err, nats.ErrKeyExists) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("acquire lease %s: %w", l.key, err)
+	}
+
+	l.revision = revision
+
+	return true, nil
+}
+
+// Release frees the held lease, so another instance takes it without waiting for the TTL
+func (l *Lease) Release() error {
+	if l.revision == 0 {
+		return nil
+	}
+
+	revision := l.revision
+	l.revision = 0
+
+	if err := l.bucket.Delete(l.key, nats.LastRevision(revision)); err != nil && !errors.Is(err, nats.ErrKeyExists) {
+		return fmt.Errorf("release lease %s: %w", l.key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func TestLease(t *testing.T) {
+	bucket := kvstoretest.NewBucket()
+	first, second := NewLease(bucket, "worker"), NewLease(bucket, "worker")
+
+	held, err := first.Acquire()
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	held, err = second.Acquire()
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	// the holder renews the lease
+	held, err = first.Acquire()
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	require.NoError(t, first.Release())
+
+	held, err = second.Acquire()
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	held, err = first.Acquire()
+	require.NoError(t, err)
+	assert.False(t, held)
+}
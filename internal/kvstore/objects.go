@@ -0,0 +1,35 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Objects is the part of the JetStream object store used for values too large for key-value buckets
+type Objects interface {
+	PutBytes(name string, data []byte, opts ...nats.ObjectOpt) (*nats.ObjectInfo, error)
+	GetBytes(name string, opts ...nats.GetObjectOpt) ([]byte, error)
+	Delete(name string) error
+}
+
+// OpenObjects returns the object store shared by all instances of the service, the store is created on the first start.
+// Objects older than the TTL are removed, they are kept forever if it's zero.
+func OpenObjects(js nats.JetStreamContext, bucket string, ttl time.Duration, replicas int) (nats.ObjectStore, error) {
+	objects, err := js.ObjectStore(bucket)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		objects, err = js.CreateObjectStore(&nats.ObjectStoreConfig{
+			Bucket:   bucket,
+			TTL:      ttl,
+			Storage:  nats.FileStorage,
+			Replicas: max(replicas, 1),
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open object store %s: %w", bucket, err)
+	}
+
+	return objects, nil
+}
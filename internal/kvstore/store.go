@@ -0,0 +1,203 @@
+package kvstore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// maxConflicts limits attempts of the update when the key is changed by other instances at the same time
+const maxConflicts = 10
+
+var (
+	ErrNotFound = errors.New("key not found")
+	ErrConflict = errors.New("key is changed concurrently")
+	// ErrUnchanged is returned by the change of Update to skip writing
+	ErrUnchanged = errors.New("value is unchanged")
+	// ErrDelete is returned by the change of Update to delete the key instead of writing
+	ErrDelete = errors.New("value is deleted")
+)
+
+var keyToken = regexp.MustCompile(`^[-_A-Za-z0-9]+$`)
+
+// Bucket is the part of the JetStream key-value bucket used by stores
+type Bucket interface {
+	Get(key string) (nats.KeyValueEntry, error)
+	Put(key string, value []byte) (uint64, error)
+	Create(key string, value []byte) (uint64, error)
+	Update(key string, value []byte, last uint64) (uint64, error)
+	Delete(key string, opts ...nats.DeleteOpt) error
+	Keys(opts ...nats.WatchOpt) ([]string, error)
+}
+
+// Open returns the key-value bucket shared by all instances of the service, the bucket is created on the first start.
+// Values older than the TTL are removed, they are kept forever if it's zero.
+func Open(js nats.JetStreamContext, bucket string, ttl time.Duration, replicas int) (nats.KeyValue, error) {
+	kv, err := js.KeyValue(bucket)
+	if errors.Is(err, nats.ErrBucketNotFound) {
+		kv, err = js.CreateKeyValue(&nats.KeyValueConfig{
+			Bucket:   bucket,
+			TTL:      ttl,
+			Storage:  nats.FileStorage,
+			Replicas: max(replicas, 1),
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open bucket %s: %w", bucket, err)
+	}
+
+	return kv, nil
+}
+
+// Key joins parts by dots. Parts with characters which aren't allowed in keys are base64 encoded,
+// the "=" prefix separates them from the parts kept as is.
+func Key(parts ...string) string {
+	escaped := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if !keyToken.MatchString(part) {
+			part = "=" + base64.RawURLEncoding.EncodeToString([]byte(part))
+		}
+
+		escaped = append(escaped, part)
+	}
+
+	return strings.Join(escaped, ".")
+}
+
+// Store keeps values as JSON documents in the bucket
+type Store[T any] struct {
+	bucket Bucket
+}
+
+func New[T any](bucket Bucket) *Store[T] {
+	return &Store[T]{bucket: bucket}
+}
+
+// Get returns ErrNotFound if the key doesn't exist
+func (s *Store[T]) Get(key string) (T, error) {
+	value, _, err := s.get(key)
+
+	return value, err
+}
+
+func (s *Store[T]) Put(key string, value T) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", key, err)
+	}
+
+	if _, err := s.bucket.Put(key, data); err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Update applies the change to the current value and stores the result, the value is zero if the key doesn't exist.
+// If the key is changed by another instance in the meantime, the change is applied again to the fresh value,
+// so it mustn't have side effects. Errors of the change are returned as is and nothing is stored,
+// except ErrUnchanged which returns the value without writing and ErrDelete which deletes the key
+// unless it's changed in the meantime and returns the zero value.
+func (s *Store[T]) Update(key string, change func(value *T) error) (T, error) {
+	var zero T
+	for i := 0; i < maxConflicts; i++ {
+		value, revision, err := s.get(key)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return zero, err
+		}
+
+		if err := change(&value); errors.Is(err, ErrUnchanged) {
+			return value, nil
+		} else if errors.Is(err, ErrDelete) {
+			if revision == 0 {
+				return zero, nil
+			}
+
+			err = s.bucket.Delete(key, nats.LastRevision(revision))
+			if errors.Is(err, nats.ErrKeyExists) {
+				continue
+			}
+			if err != nil {
+				return zero, fmt.Errorf("delete %s: %w", key, err)
+			}
+
+			return zero, nil
+		} else if err != nil {
+			return zero, err
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return zero, fmt.Errorf("marshal %s: %w", key, err)
+		}
+
+		if revision == 0 {
+			_, err = s.bucket.Create(key, data)
+		} else {
+			_, err = s.bucket.Update(key, data, revision)
+		}
+		if errors.Is(err, nats.ErrKeyExists) {
+			continue
+		}
+		if err != nil {
+			return zero, fmt.Errorf("update %s: %w", key, err)
+		}
+
+		return value, nil
+	}
+
+	return zero, fmt.Errorf("%w: %s", ErrConflict, key)
+}
+
+// Delete removes the key, missing keys are ignored
+func (s *Store[T]) Delete(key string) error {
+	if err := s.bucket.Delete(key); err != nil && !errors.Is(err, nats.ErrKeyNotFound) {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Keys returns keys with the prefix
+func (s *Store[T]) Keys(prefix string) ([]string, error) {
+	keys, err := s.bucket.Keys()
+	if errors.Is(err, nats.ErrNoKeysFound) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+
+	list := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) {
+			list = append(list, key)
+		}
+	}
+
+	return list, nil
+}
+
+func (s *Store[T]) get(key string) (T, uint64, error) {
+	var value T
+
+	entry, err := s.bucket.Get(key)
+	if errors.Is(err, nats.ErrKeyNotFound) {
+		return value, 0, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return value, 0, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	if err := json.Unmarshal(entry.Value(), &value); err != nil {
+		return value, 0, fmt.Errorf("unmarshal %s: %w", key, err)
+	}
+
+	return value, entry.Revision(), nil
+}
@@ -0,0 +1,115 @@
+package kvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func TestKey(t *testing.T) {
+	for name, tc := range map[string]struct {
+		parts []string
+		want  string
+	}{
+		"plain":    {parts: []string{"user", "0b8a6d2e-1b1c-4c57-9d5c-3d2f6f4b6a1e"}, want: "user.0b8a6d2e-1b1c-4c57-9d5c-3d2f6f4b6a1e"},
+		"dots":     {parts: []string{"dao", "aave.eth"}, want: "dao.=YWF2ZS5ldGg"},
+		"wildcard": {parts: []string{"a", "*"}, want: "a.=Kg"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Key(tc.parts...))
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	t.Run("missing key", func(t *testing.T) {
+		store := New[[]string](kvstoretest.NewBucket())
+
+		_, err := store.Get("a")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NoError(t, store.Delete("a"))
+	})
+
+	t.Run("update is applied to the fresh value", func(t *testing.T) {
+		bucket := kvstoretest.NewBucket()
+		store := New[[]string](bucket)
+		other := New[[]string](bucket)
+
+		calls := 0
+		value, err := store.Update("a", func(value *[]string) error {
+			calls++
+			if calls == 1 {
+				// another instance changes the key between reading and writing
+				_, err := other.Update("a", func(value *[]string) error {
+					*value = append(*value, "other")
+					return nil
+				})
+				require.NoError(t, err)
+			}
+
+			*value = append(*value, "own")
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, []string{"other", "own"}, value)
+
+		stored, err := store.Get("a")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"other", "own"}, stored)
+	})
+
+	t.Run("failed change isn't stored", func(t *testing.T) {
+		store := New[[]string](kvstoretest.NewBucket())
+		errLimit := errors.New("limit")
+
+		_, err := store.Update("a", func(value *[]string) error {
+			*value = append(*value, "own")
+			return errLimit
+		})
+		assert.ErrorIs(t, err, errLimit)
+
+		_, err = store.Get("a")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("update deletes the key", func(t *testing.T) {
+		store := New[[]string](kvstoretest.NewBucket())
+		require.NoError(t, store.Put("a", []string{"own"}))
+
+		value, err := store.Update("a", func(value *[]string) error {
+			return ErrDelete
+		})
+		require.NoError(t, err)
+		assert.Nil(t, value)
+
+		_, err = store.Get("a")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = store.Update("b", func(value *[]string) error {
+			return ErrDelete
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("keys by prefix", func(t *testing.T) {
+		store := New[int](kvstoretest.NewBucket())
+
+		keys, err := store.Keys("user.")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+
+		require.NoError(t, store.Put("user.a", 1))
+		require.NoError(t, store.Put("user.b", 2))
+		require.NoError(t, store.Put("hash.c", 3))
+
+		keys, err = store.Keys("user.")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user.a", "user.b"}, keys)
+	})
+}
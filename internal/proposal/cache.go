@@ -97,3 +97,27 @@ func (r *Cache) GetByID(id string) (*proposal.Proposal, bool) {
 
 	return nil, false
 }
+
+// Purge removes items by ids from the cache, all items are removed if ids are empty.
+// Returns the number of removed items.
+func (r *Cache) Purge(ids ...string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(ids) == 0 {
+		purged := len(r.cache)
+		r.cache = make(map[string]cachedItem)
+
+		return purged
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if _, ok := r.cache[id]; ok {
+			delete(r.cache, id)
+			purged++
+		}
+	}
+
+	return purged
+}
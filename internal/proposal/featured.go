@@ -0,0 +1,95 @@
+package proposal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+// featuredCurationKey is the key of the curation shared by all instances
+const featuredCurationKey = "curation"
+
+type FeaturedProvider interface {
+	GetFeaturedProposals(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*inboxapi.GetFeaturedProposalsResponse, error)
+}
+
+// FeaturedCuration adjusts the list of featured proposals from the inbox api:
+// pinned proposals go first in the defined order, hidden ones are excluded.
+type FeaturedCuration struct {
+	Pinned []string `json:"pinned"`
+	Hidden []string `json:"hidden"`
+}
+
+func (c FeaturedCuration) apply(ids []string) []string {
+	list := make([]string, 0, len(c.Pinned)+len(ids))
+	for _, id := range slices.Concat(c.Pinned, ids) {
+		if slices.Contains(c.Hidden, id) || slices.Contains(list, id) {
+			continue
+		}
+
+		list = append(list, id)
+	}
+
+	return list
+}
+
+// FeaturedStorage keeps the curation in the bucket shared by all instances
+type FeaturedStorage struct {
+	curation *kvstore.Store[FeaturedCuration]
+}
+
+func NewFeaturedStorage(bucket kvstore.Bucket) *FeaturedStorage {
+	return &FeaturedStorage{
+		curation: kvstore.New[FeaturedCuration](bucket),
+	}
+}
+
+// Get returns the empty curation if it's never set
+func (s *FeaturedStorage) Get() (FeaturedCuration, error) {
+	curation, err := s.curation.Get(featuredCurationKey)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return FeaturedCuration{}, nil
+	}
+	if err != nil {
+		return FeaturedCuration{}, fmt.Errorf("get featured curation: %w", err)
+	}
+
+	return curation, nil
+}
+
+func (s *FeaturedStorage) Set(curation FeaturedCuration) error {
+	if err := s.curation.Put(featuredCurationKey, curation); err != nil {
+		return fmt.Errorf("set featured curation: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetFeaturedIDs(ctx context.Context) ([]string, error) {
+	curation, err := s.curation.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.featured.GetFeaturedProposals(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("get featured proposals: %w", err)
+	}
+
+	return curation.apply(resp.GetProposalIds()), nil
+}
+
+func (s *Service) GetFeaturedCuration() (FeaturedCuration, error) {
+	return s.curation.Get()
+}
+
+func (s *Service) SetFeaturedCuration(curation FeaturedCuration) error {
+	return s.curation.Set(curation)
+}
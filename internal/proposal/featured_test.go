@@ -0,0 +1,53 @@
+package proposal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func TestFeaturedCurationApply(t *testing.T) {
+	for name, tc := range map[string]struct {
+		curation FeaturedCuration
+		ids      []string
+		want     []string
+	}{
+		"empty curation keeps the order": {
+			ids:  []string{"a", "b"},
+			want: []string{"a", "b"},
+		},
+		"pinned go first without duplicates": {
+			curation: FeaturedCuration{Pinned: []string{"c", "b"}},
+			ids:      []string{"a", "b"},
+			want:     []string{"c", "b", "a"},
+		},
+		"hidden are excluded even if pinned": {
+			curation: FeaturedCuration{Pinned: []string{"c"}, Hidden: []string{"a", "c"}},
+			ids:      []string{"a", "b"},
+			want:     []string{"b"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.curation.apply(tc.ids))
+		})
+	}
+}
+
+func TestFeaturedStorage(t *testing.T) {
+	bucket := kvstoretest.NewBucket()
+
+	curation, err := NewFeaturedStorage(bucket).Get()
+	require.NoError(t, err)
+	assert.Empty(t, curation.Pinned)
+
+	set := FeaturedCuration{Pinned: []string{"a"}, Hidden: []string{"b"}}
+	require.NoError(t, NewFeaturedStorage(bucket).Set(set))
+
+	// the curation set on one instance is used by others
+	curation, err = NewFeaturedStorage(bucket).Get()
+	require.NoError(t, err)
+	assert.Equal(t, set, curation)
+}
@@ -0,0 +1,100 @@
+package proposal
+
+import (
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+)
+
+// Round is the state of the instant runoff after counting ballots, scores of eliminated choices are zero
+type Round struct {
+	Scores []float64
+	// Eliminated is the choice excluded after the round, it's zero for the last round
+	Eliminated int
+	// Exhausted is the voting power of ballots without remaining choices
+	Exhausted float64
+}
+
+// InstantRunoff counts ranked-choice votes. Each ballot counts for the highest ranked remaining choice,
+// the choice with the lowest score is eliminated until one choice has the majority of counted voting power.
+// Ties of the lowest score eliminate the last choice. It returns rounds, the total voting power of valid
+// votes and the number of ignored votes.
+func InstantRunoff(count int, votes []proposal.Vote) ([]Round, float64, int) {
+	type ballot struct {
+		ranking []int
+		vp      float64
+	}
+
+	var (
+		ballots []ballot
+		total   float64
+		ignored int
+	)
+	for _, vote := range votes {
+		ranking, err := parseChoiceList(vote.Choice, count, true)
+		if err != nil {
+			ignored++
+
+			continue
+		}
+
+		ballots = append(ballots, ballot{ranking: ranking, vp: vote.Vp})
+		total += vote.Vp
+	}
+
+	if count == 0 {
+		return nil, total, ignored
+	}
+
+	active := make([]bool, count)
+	for i := range active {
+		active[i] = true
+	}
+	remaining := count
+
+	var rounds []Round
+	for {
+		round := Round{Scores: make([]float64, count)}
+		var counted float64
+		for _, b := range ballots {
+			choice := 0
+			for _, c := range b.ranking {
+				if active[c-1] {
+					choice = c
+					break
+				}
+			}
+
+			if choice == 0 {
+				round.Exhausted += b.vp
+
+				continue
+			}
+
+			round.Scores[choice-1] += b.vp
+			counted += b.vp
+		}
+
+		top, lowest := -1, -1
+		for i := range round.Scores {
+			if !active[i] {
+				continue
+			}
+			if top < 0 || round.Scores[i] > round.Scores[top] {
+				top = i
+			}
+			if lowest < 0 || round.Scores[i] <= round.Scores[lowest] {
+				lowest = i
+			}
+		}
+
+		if counted == 0 || round.Scores[top] > counted/2 || remaining <= 2 {
+			rounds = append(rounds, round)
+
+			return rounds, total, ignored
+		}
+
+		active[lowest] = false
+		remaining--
+		round.Eliminated = lowest + 1
+		rounds = append(rounds, round)
+	}
+}
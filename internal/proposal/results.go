@@ -0,0 +1,260 @@
+package proposal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+)
+
+// Snapshot voting types
+const (
+	TypeSingleChoice = "single-choice"
+	TypeBasic        = "basic"
+	TypeApproval     = "approval"
+	TypeQuadratic    = "quadratic"
+	TypeRankedChoice = "ranked-choice"
+	TypeWeighted     = "weighted"
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported voting type")
+	errInvalidChoice   = errors.New("invalid choice")
+)
+
+// Tally counts votes by the rules of the voting type. Votes with choices which can't be parsed
+// or are out of range are ignored, e.g. encrypted choices of shielded proposals before the end.
+func Tally(votingType string, choices []string, quorum float64, votes []proposal.Vote) (proposal.Results, error) {
+	if votingType == "" {
+		votingType = TypeSingleChoice
+	}
+
+	var (
+		scores  []float64
+		total   float64
+		ignored int
+	)
+	switch votingType {
+	case TypeSingleChoice, TypeBasic, TypeApproval, TypeQuadratic, TypeWeighted:
+		scores, total, ignored = tallyScores(votingType, len(choices), votes)
+	case TypeRankedChoice:
+		var rounds []Round
+		rounds, total, ignored = InstantRunoff(len(choices), votes)
+		scores = make([]float64, len(choices))
+		if len(rounds) > 0 {
+			scores = rounds[len(rounds)-1].Scores
+		}
+	default:
+		return proposal.Results{}, fmt.Errorf("%w: %s", ErrUnsupportedType, votingType)
+	}
+
+	return newResults(votingType, choices, quorum, scores, total, len(votes), ignored), nil
+}
+
+// TallyScores builds results from scores already counted by Snapshot, e.g. for lists of proposals
+// where votes aren't loaded. Winners and the quorum follow the same rules as Tally.
+func TallyScores(votingType string, choices []string, quorum float64, scores []float64, total float64) proposal.Results {
+	if votingType == "" {
+		votingType = TypeSingleChoice
+	}
+
+	// scores of choices missing in the list are zero
+	padded := make([]float64, len(choices))
+	copy(padded, scores)
+
+	return newResults(votingType, choices, quorum, padded, total, 0, 0)
+}
+
+func tallyScores(votingType string, count int, votes []proposal.Vote) ([]float64, float64, int) {
+	scores := make([]float64, count)
+	var (
+		total   float64
+		ignored int
+	)
+
+	for _, vote := range votes {
+		var err error
+		switch votingType {
+		case TypeSingleChoice, TypeBasic:
+			var choice int
+			if choice, err = parseChoice(vote.Choice, count); err == nil {
+				scores[choice-1] += vote.Vp
+			}
+		case TypeApproval:
+			var list []int
+			if list, err = parseChoiceList(vote.Choice, count, false); err == nil {
+				for _, choice := range list {
+					scores[choice-1] += vote.Vp
+				}
+			}
+		case TypeWeighted, TypeQuadratic:
+			var weights map[int]float64
+			if weights, err = parseWeights(vote.Choice, count); err == nil {
+				for choice, weight := range weights {
+					if votingType == TypeQuadratic {
+						// the square root is normalized to the total voting power below
+						scores[choice-1] += math.Sqrt(vote.Vp * weight)
+					} else {
+						scores[choice-1] += vote.Vp * weight
+					}
+				}
+			}
+		}
+		if err != nil {
+			ignored++
+
+			continue
+		}
+
+		total += vote.Vp
+	}
+
+	if votingType == TypeQuadratic {
+		var sum float64
+		for _, score := range scores {
+			sum += score
+		}
+		for i := range scores {
+			if sum > 0 {
+				scores[i] = scores[i] / sum * total
+			}
+		}
+	}
+
+	return scores, total, ignored
+}
+
+func newResults(votingType string, choices []string, quorum float64, scores []float64, total float64, votes, ignored int) proposal.Results {
+	results := proposal.Results{
+		Type:          votingType,
+		Choices:       make([]proposal.ChoiceResult, 0, len(choices)),
+		Winners:       []int{},
+		ScoresTotal:   round(total, 6),
+		VotesCount:    votes - ignored,
+		IgnoredVotes:  ignored,
+		Quorum:        quorum,
+		QuorumReached: quorum <= 0 || total >= quorum,
+	}
+
+	// scores are rounded before comparing, so float errors of weighted votes don't break ties
+	rounded := make([]float64, len(choices))
+	var top float64
+	for i := range choices {
+		rounded[i] = round(scores[i], 6)
+		top = math.Max(top, rounded[i])
+	}
+
+	for i, title := range choices {
+		item := proposal.ChoiceResult{
+			Choice: i + 1,
+			Title:  title,
+			Score:  rounded[i],
+		}
+		if total > 0 {
+			item.Percent = round(rounded[i]/total*100, 4)
+		}
+		if top > 0 && rounded[i] == top {
+			item.Winner = true
+			results.Winners = append(results.Winners, item.Choice)
+		}
+
+		results.Choices = append(results.Choices, item)
+	}
+
+	if len(results.Winners) == 1 {
+		slices.Sort(rounded)
+		runnerUp := 0.0
+		if len(rounded) > 1 {
+			runnerUp = rounded[len(rounded)-2]
+		}
+
+		results.Margin = round((top-runnerUp)/total*100, 4)
+	}
+
+	return results
+}
+
+// parseChoice parses the index of the choice, some clients send it as the string
+func parseChoice(raw json.RawMessage, count int) (int, error) {
+	var value json.Number
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return 0, errInvalidChoice
+	}
+
+	choice, err := strconv.Atoi(value.String())
+	if err != nil || choice < 1 || choice > count {
+		return 0, errInvalidChoice
+	}
+
+	return choice, nil
+}
+
+// parseChoiceList parses the list of choices, duplicates are ignored for approval and forbidden for rankings
+func parseChoiceList(raw json.RawMessage, count int, unique bool) ([]int, error) {
+	var values []json.Number
+	if err := json.Unmarshal(raw, &values); err != nil || len(values) == 0 {
+		return nil, errInvalidChoice
+	}
+
+	list := make([]int, 0, len(values))
+	for _, value := range values {
+		choice, err := strconv.Atoi(value.String())
+		if err != nil || choice < 1 || choice > count {
+			return nil, errInvalidChoice
+		}
+
+		if slices.Contains(list, choice) {
+			if unique {
+				return nil, errInvalidChoice
+			}
+
+			continue
+		}
+
+		list = append(list, choice)
+	}
+
+	return list, nil
+}
+
+// parseWeights parses the distribution of the voting power, weights are normalized to the sum of 1
+func parseWeights(raw json.RawMessage, count int) (map[int]float64, error) {
+	var values map[string]float64
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, errInvalidChoice
+	}
+
+	var sum float64
+	weights := make(map[int]float64, len(values))
+	for key, weight := range values {
+		choice, err := strconv.Atoi(key)
+		if err != nil || choice < 1 || choice > count || weight < 0 {
+			return nil, errInvalidChoice
+		}
+
+		if weight > 0 {
+			weights[choice] = weight
+			sum += weight
+		}
+	}
+
+	if sum == 0 {
+		return nil, errInvalidChoice
+	}
+
+	for choice := range weights {
+		weights[choice] /= sum
+	}
+
+	return weights, nil
+}
+
+func round(value float64, precision int) float64 {
+	p := math.Pow10(precision)
+
+	return math.Round(value*p) / p
+}
@@ -0,0 +1,45 @@
+package proposal
+
+import (
+	"sync"
+)
+
+// resultsCacheSize limits the number of cached proposals, the oldest are evicted first
+const resultsCacheSize = 1000
+
+// ResultsCache keeps results of closed proposals, votes of closed proposals don't change
+type ResultsCache[T any] struct {
+	mu    sync.RWMutex
+	items map[string]T
+	order []string
+}
+
+func NewResultsCache[T any]() *ResultsCache[T] {
+	return &ResultsCache[T]{
+		items: make(map[string]T),
+	}
+}
+
+func (c *ResultsCache[T]) Get(id string) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	item, ok := c.items[id]
+
+	return item, ok
+}
+
+func (c *ResultsCache[T]) Add(id string, results T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.items[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.items[id] = results
+
+	for len(c.order) > resultsCacheSize {
+		delete(c.items, c.order[0])
+		c.order = c.order[1:]
+	}
+}
@@ -0,0 +1,107 @@
+package proposal
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type tallyCase struct {
+	Type    string          `json:"type"`
+	Choices []string        `json:"choices"`
+	Quorum  float64         `json:"quorum"`
+	Votes   []proposal.Vote `json:"votes"`
+}
+
+func TestTallyGolden(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "results", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		if strings.HasSuffix(file, ".golden.json") {
+			continue
+		}
+
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			var tc tallyCase
+			require.NoError(t, json.Unmarshal(data, &tc))
+
+			results, err := Tally(tc.Type, tc.Choices, tc.Quorum, tc.Votes)
+			require.NoError(t, err)
+
+			assertGolden(t, filepath.Join("testdata", "results", name+".golden.json"), results)
+		})
+	}
+}
+
+func TestRunoffResultsGolden(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "results", "ranked-choice.json"))
+	require.NoError(t, err)
+
+	var tc tallyCase
+	require.NoError(t, json.Unmarshal(data, &tc))
+
+	assertGolden(t, filepath.Join("testdata", "rounds", "ranked-choice.golden.json"), RunoffResults(tc.Choices, tc.Votes))
+}
+
+func assertGolden(t *testing.T, golden string, value any) {
+	t.Helper()
+
+	actual, err := json.MarshalIndent(value, "", "  ")
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+		require.NoError(t, os.WriteFile(golden, append(actual, '\n'), 0o644))
+	}
+
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestTallyUnsupportedType(t *testing.T) {
+	_, err := Tally("custom", []string{"a"}, 0, nil)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestTallyScores(t *testing.T) {
+	results := TallyScores("", []string{"For", "Against", "Abstain"}, 50, []float64{10, 30}, 40)
+	assert.Equal(t, TypeSingleChoice, results.Type)
+	assert.Equal(t, []int{2}, results.Winners)
+	assert.False(t, results.QuorumReached)
+	require.Len(t, results.Choices, 3)
+	assert.Zero(t, results.Choices[2].Score)
+}
+
+func TestInstantRunoffRounds(t *testing.T) {
+	votes := []proposal.Vote{
+		{Choice: json.RawMessage(`[1, 2, 3]`), Vp: 4},
+		{Choice: json.RawMessage(`[2, 1, 3]`), Vp: 3},
+		{Choice: json.RawMessage(`[3, 2, 1]`), Vp: 2},
+	}
+
+	rounds, total, ignored := InstantRunoff(3, votes)
+	require.Len(t, rounds, 2)
+	assert.Equal(t, 9.0, total)
+	assert.Zero(t, ignored)
+	assert.Equal(t, []float64{4, 3, 2}, rounds[0].Scores)
+	assert.Equal(t, 3, rounds[0].Eliminated)
+	assert.Equal(t, []float64{4, 5, 0}, rounds[1].Scores)
+	assert.Zero(t, rounds[1].Eliminated)
+}
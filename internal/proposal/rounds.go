@@ -0,0 +1,73 @@
+package proposal
+
+import (
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+)
+
+// RunoffResults replays the instant runoff of ranked-choice votes
+func RunoffResults(choices []string, votes []proposal.Vote) proposal.RunoffResults {
+	rounds, total, ignored := InstantRunoff(len(choices), votes)
+
+	results := proposal.RunoffResults{
+		Rounds:       make([]proposal.RunoffRound, 0, len(rounds)),
+		ScoresTotal:  round(total, 6),
+		VotesCount:   len(votes) - ignored,
+		IgnoredVotes: ignored,
+	}
+
+	eliminated := make([]bool, len(choices))
+	for i, r := range rounds {
+		var counted, top float64
+		for c, score := range r.Scores {
+			if !eliminated[c] {
+				counted += score
+				top = max(top, score)
+			}
+		}
+
+		item := proposal.RunoffRound{
+			Round:     i + 1,
+			Choices:   make([]proposal.ChoiceResult, 0, len(choices)),
+			Exhausted: round(r.Exhausted, 6),
+		}
+		leaders := 0
+		for c, title := range choices {
+			if eliminated[c] {
+				continue
+			}
+
+			choice := proposal.ChoiceResult{
+				Choice: c + 1,
+				Title:  title,
+				Score:  round(r.Scores[c], 6),
+			}
+			if counted > 0 {
+				choice.Percent = round(r.Scores[c]/counted*100, 4)
+			}
+			// the leader of the last round is the winner unless it's the tie
+			if i == len(rounds)-1 && top > 0 && r.Scores[c] == top {
+				choice.Winner = true
+				leaders++
+				results.Winner = helpers.Ptr(c + 1)
+			}
+
+			item.Choices = append(item.Choices, choice)
+		}
+		if leaders > 1 {
+			results.Winner = nil
+			for c := range item.Choices {
+				item.Choices[c].Winner = false
+			}
+		}
+
+		if r.Eliminated > 0 {
+			item.Eliminated = helpers.Ptr(r.Eliminated)
+			eliminated[r.Eliminated-1] = true
+		}
+
+		results.Rounds = append(results.Rounds, item)
+	}
+
+	return results
+}
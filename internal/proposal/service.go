@@ -27,22 +27,38 @@ type DaoProvider interface {
 	GetDaoByIDs(ctx context.Context, ids ...string) (map[string]*dao.DAO, error)
 }
 
+// RevisionRecorder keeps versions of proposals loaded from the core
+type RevisionRecorder interface {
+	Record(pr *proposal.Proposal)
+}
+
 type Service struct {
-	cache *Cache
-	dp    DataProvider
-	dao   DaoProvider
-	aip   AIProvider
+	cache    *Cache
+	dp       DataProvider
+	dao      DaoProvider
+	aip      AIProvider
+	featured FeaturedProvider
+	curation *FeaturedStorage
+	revs     RevisionRecorder
 }
 
-func NewService(cache *Cache, dp DataProvider, dao DaoProvider, aip AIProvider) *Service {
+func NewService(cache *Cache, dp DataProvider, dao DaoProvider, aip AIProvider, featured FeaturedProvider, curation *FeaturedStorage, revs RevisionRecorder) *Service {
 	return &Service{
-		cache: cache,
-		dp:    dp,
-		dao:   dao,
-		aip:   aip,
+		cache:    cache,
+		dp:       dp,
+		dao:      dao,
+		aip:      aip,
+		featured: featured,
+		curation: curation,
+		revs:     revs,
 	}
 }
 
+// PurgeCache removes proposals from the local cache, all proposals are removed if ids are empty
+func (s *Service) PurgeCache(ids ...string) int {
+	return s.cache.Purge(ids...)
+}
+
 func (s *Service) GetByID(ctx context.Context, id string) (*proposal.Proposal, error) {
 	item, ok := s.cache.GetByID(id)
 	if ok {
@@ -60,6 +76,7 @@ func (s *Service) GetByID(ctx context.Context, id string) (*proposal.Proposal, e
 	}
 
 	converted := ConvertProposalToInternal(pr, list[pr.DaoID.String()])
+	s.revs.Record(converted)
 	s.cache.AddToCache(converted)
 
 	return converted, nil
@@ -93,6 +110,7 @@ func (s *Service) GetList(ctx context.Context, ids ...string) ([]*proposal.Propo
 		converted := ConvertProposalToInternal(&info, list[info.DaoID.String()])
 		hits = append(hits, converted)
 
+		s.revs.Record(converted)
 		s.cache.AddToCache(converted)
 	}
 
@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+type featuredRequest struct {
+	Pinned []string `json:"pinned"`
+	Hidden []string `json:"hidden"`
+}
+
+type FeaturedForm struct {
+	Pinned []string
+	Hidden []string
+}
+
+func NewFeaturedForm() *FeaturedForm {
+	return &FeaturedForm{}
+}
+
+func (f *FeaturedForm) ParseAndValidate(r *http.Request) (*FeaturedForm, response.Error) {
+	var request *featuredRequest
+	if err := helpers.ReadJSON(r.Body, &request); err != nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.Pinned = validateIDs("pinned", request.Pinned, errors)
+	f.Hidden = validateIDs("hidden", request.Hidden, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func validateIDs(key string, raw []string, errors map[string]response.ErrorMessage) []string {
+	ids := make([]string, 0, len(raw))
+	for i, id := range raw {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			errors[fmt.Sprintf("%s.%d", key, i)] = response.MissedValueError("missed value")
+
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids
+}
@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+type CacheTarget string
+
+const (
+	CacheTargetAll      CacheTarget = "all"
+	CacheTargetDao      CacheTarget = "dao"
+	CacheTargetProposal CacheTarget = "proposal"
+)
+
+type purgeCacheRequest struct {
+	Target string   `json:"target"`
+	IDs    []string `json:"ids"`
+}
+
+type PurgeCacheForm struct {
+	Target CacheTarget
+	IDs    []string
+}
+
+func NewPurgeCacheForm() *PurgeCacheForm {
+	return &PurgeCacheForm{}
+}
+
+func (f *PurgeCacheForm) ParseAndValidate(r *http.Request) (*PurgeCacheForm, response.Error) {
+	var request *purgeCacheRequest
+	if err := helpers.ReadJSON(r.Body, &request); err != nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetTarget(request, errors)
+	f.IDs = validateIDs("ids", request.IDs, errors)
+
+	if f.Target == CacheTargetAll && len(f.IDs) > 0 {
+		errors["ids"] = response.WrongValueError("ids are not supported for all caches")
+	}
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *PurgeCacheForm) validateAndSetTarget(request *purgeCacheRequest, errors map[string]response.ErrorMessage) {
+	target := CacheTarget(strings.TrimSpace(request.Target))
+	switch target {
+	case "":
+		f.Target = CacheTargetAll
+	case CacheTargetAll, CacheTargetDao, CacheTargetProposal:
+		f.Target = target
+	default:
+		errors["target"] = response.WrongValueError("unsupported target")
+	}
+}
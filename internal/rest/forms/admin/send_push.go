@@ -0,0 +1,136 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+type Segment string
+
+const (
+	// SegmentDaoSubscribers targets all subscribers of the dao
+	SegmentDaoSubscribers Segment = "dao_subscribers"
+)
+
+const maxPushRecipients = 1000
+
+type segmentRequest struct {
+	Type  string `json:"type"`
+	DaoID string `json:"dao_id"`
+}
+
+type sendPushRequest struct {
+	Title         string          `json:"title"`
+	Body          string          `json:"body"`
+	ImageURL      string          `json:"image_url"`
+	CustomPayload string          `json:"custom_payload"`
+	UserIDs       []string        `json:"user_ids"`
+	Segment       *segmentRequest `json:"segment"`
+}
+
+type SendPushForm struct {
+	Title         string
+	Body          string
+	ImageURL      string
+	CustomPayload []byte
+	UserIDs       []uuid.UUID
+	Segment       Segment
+	DaoID         string
+}
+
+func NewSendPushForm() *SendPushForm {
+	return &SendPushForm{}
+}
+
+func (f *SendPushForm) ParseAndValidate(r *http.Request) (*SendPushForm, response.Error) {
+	var request *sendPushRequest
+	if err := helpers.ReadJSON(r.Body, &request); err != nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetContent(request, errors)
+	f.validateAndSetUserIDs(request, errors)
+	f.validateAndSetSegment(request, errors)
+
+	if len(f.UserIDs) == 0 && f.Segment == "" && len(errors) == 0 {
+		errors["user_ids"] = response.MissedValueError("user_ids or segment must be provided")
+	}
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *SendPushForm) validateAndSetContent(req *sendPushRequest, errors map[string]response.ErrorMessage) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		errors["title"] = response.MissedValueError("missed value")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		errors["body"] = response.MissedValueError("missed value")
+	}
+
+	f.Title = title
+	f.Body = body
+	f.ImageURL = strings.TrimSpace(req.ImageURL)
+	if req.CustomPayload != "" {
+		f.CustomPayload = []byte(req.CustomPayload)
+	}
+}
+
+func (f *SendPushForm) validateAndSetUserIDs(req *sendPushRequest, errors map[string]response.ErrorMessage) {
+	if len(req.UserIDs) > maxPushRecipients {
+		errors["user_ids"] = response.WrongValueError(fmt.Sprintf("max number of users is %d", maxPushRecipients))
+
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.UserIDs))
+	for i, raw := range req.UserIDs {
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			errors[fmt.Sprintf("user_ids.%d", i)] = response.WrongValueError("wrong id format")
+
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	f.UserIDs = ids
+}
+
+func (f *SendPushForm) validateAndSetSegment(req *sendPushRequest, errors map[string]response.ErrorMessage) {
+	if req.Segment == nil {
+		return
+	}
+
+	switch Segment(req.Segment.Type) {
+	case SegmentDaoSubscribers:
+		daoID, err := uuid.Parse(strings.TrimSpace(req.Segment.DaoID))
+		if err != nil {
+			errors["segment.dao_id"] = response.WrongValueError("wrong id format")
+
+			return
+		}
+
+		f.Segment = SegmentDaoSubscribers
+		f.DaoID = daoID.String()
+	default:
+		errors["segment.type"] = response.WrongValueError("unsupported segment")
+	}
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const defaultFeedTokenName = "Feed reader"
+
+type createFeedTokenRequest struct {
+	Name string `json:"name"`
+}
+
+type CreateFeedTokenForm struct {
+	Name string
+}
+
+func NewCreateFeedTokenForm() *CreateFeedTokenForm {
+	return &CreateFeedTokenForm{}
+}
+
+func (f *CreateFeedTokenForm) ParseAndValidate(r *http.Request) (*CreateFeedTokenForm, response.Error) {
+	// the name is optional, so the body may be omitted
+	request := &createFeedTokenRequest{}
+	if r.ContentLength != 0 {
+		if err := helpers.ReadJSON(r.Body, &request); err != nil || request == nil {
+			ve := response.NewValidationError()
+			ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+			return nil, ve
+		}
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetName(request, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *CreateFeedTokenForm) validateAndSetName(request *createFeedTokenRequest, errors map[string]response.ErrorMessage) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		f.Name = defaultFeedTokenName
+
+		return
+	}
+
+	if len(name) > maxTokenNameLength {
+		errors["name"] = response.WrongValueError(fmt.Sprintf("max length is %d", maxTokenNameLength))
+
+		return
+	}
+
+	f.Name = name
+}
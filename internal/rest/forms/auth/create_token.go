@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	authsrv "github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const maxTokenNameLength = 64
+
+type createTokenRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt *string  `json:"expires_at"`
+}
+
+type CreateTokenForm struct {
+	Name      string
+	Scopes    []authsrv.Scope
+	ExpiresAt *time.Time
+}
+
+func NewCreateTokenForm() *CreateTokenForm {
+	return &CreateTokenForm{}
+}
+
+func (f *CreateTokenForm) ParseAndValidate(r *http.Request) (*CreateTokenForm, response.Error) {
+	var request *createTokenRequest
+	if err := helpers.ReadJSON(r.Body, &request); err != nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetName(request, errors)
+	f.validateAndSetScopes(request, errors)
+	f.validateAndSetExpiresAt(request, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *CreateTokenForm) validateAndSetName(request *createTokenRequest, errors map[string]response.ErrorMessage) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		errors["name"] = response.MissedValueError("missed value")
+
+		return
+	}
+
+	if len(name) > maxTokenNameLength {
+		errors["name"] = response.WrongValueError(fmt.Sprintf("max length is %d", maxTokenNameLength))
+
+		return
+	}
+
+	f.Name = name
+}
+
+func (f *CreateTokenForm) validateAndSetScopes(request *createTokenRequest, errors map[string]response.ErrorMessage) {
+	if len(request.Scopes) == 0 {
+		errors["scopes"] = response.MissedValueError("missed value")
+
+		return
+	}
+
+	scopes := make([]authsrv.Scope, 0, len(request.Scopes))
+	for i, raw := range request.Scopes {
+		scope := authsrv.Scope(strings.TrimSpace(raw))
+		if !slices.Contains(authsrv.Scopes, scope) {
+			errors[fmt.Sprintf("scopes.%d", i)] = response.WrongValueError("unsupported scope")
+
+			continue
+		}
+
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	f.Scopes = scopes
+}
+
+func (f *CreateTokenForm) validateAndSetExpiresAt(request *createTokenRequest, errors map[string]response.ErrorMessage) {
+	if request.ExpiresAt == nil {
+		return
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, *request.ExpiresAt)
+	if err != nil {
+		errors["expires_at"] = response.WrongFormatError("wrong time format")
+
+		return
+	}
+
+	if expiresAt.Before(time.Now()) {
+		errors["expires_at"] = response.WrongValueError("must be in the future")
+
+		return
+	}
+
+	f.ExpiresAt = &expiresAt
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshForm struct {
+	RefreshToken string
+}
+
+func NewRefreshForm() *RefreshForm {
+	return &RefreshForm{}
+}
+
+func (f *RefreshForm) ParseAndValidate(r *http.Request) (*RefreshForm, response.Error) {
+	var request *refreshRequest
+	if err := helpers.ReadJSON(r.Body, &request); err != nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetRefreshToken(request, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *RefreshForm) validateAndSetRefreshToken(req *refreshRequest, errors map[string]response.ErrorMessage) {
+	token := strings.TrimSpace(req.RefreshToken)
+	if token == "" {
+		errors["refresh_token"] = response.MissedValueError("missed value")
+
+		return
+	}
+
+	f.RefreshToken = token
+}
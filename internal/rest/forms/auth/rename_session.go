@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const maxSessionNameLength = 64
+
+type renameSessionRequest struct {
+	Name string `json:"name"`
+}
+
+type RenameSessionForm struct {
+	RevokeSessionForm
+
+	Name string
+}
+
+func NewRenameSessionForm() *RenameSessionForm {
+	return &RenameSessionForm{}
+}
+
+func (f *RenameSessionForm) ParseAndValidate(r *http.Request) (*RenameSessionForm, response.Error) {
+	var request *renameSessionRequest
+	if err := helpers.ReadJSON(r.Body, &request); err != nil || request == nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetID(&revokeSessionRequest{ID: mux.Vars(r)["id"]}, errors)
+	f.validateAndSetName(request, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *RenameSessionForm) validateAndSetName(request *renameSessionRequest, errors map[string]response.ErrorMessage) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		errors["name"] = response.MissedValueError("missed value")
+
+		return
+	}
+
+	if len(name) > maxSessionNameLength {
+		errors["name"] = response.WrongValueError(fmt.Sprintf("max length is %d", maxSessionNameLength))
+
+		return
+	}
+
+	f.Name = name
+}
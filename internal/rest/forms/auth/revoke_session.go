@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+type revokeSessionRequest struct {
+	ID string
+}
+
+type RevokeSessionForm struct {
+	ID uuid.UUID
+}
+
+func NewRevokeSessionForm() *RevokeSessionForm {
+	return &RevokeSessionForm{}
+}
+
+func (f *RevokeSessionForm) ParseAndValidate(r *http.Request) (*RevokeSessionForm, response.Error) {
+	request := &revokeSessionRequest{
+		ID: mux.Vars(r)["id"],
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetID(request, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *RevokeSessionForm) validateAndSetID(req *revokeSessionRequest, errors map[string]response.ErrorMessage) {
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		errors["id"] = response.MissedValueError("missed value")
+
+		return
+	}
+
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		errors["id"] = response.WrongValueError("wrong id format")
+
+		return
+	}
+
+	f.ID = parsed
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+type revokeTokenRequest struct {
+	ID string
+}
+
+type RevokeTokenForm struct {
+	ID uuid.UUID
+}
+
+func NewRevokeTokenForm() *RevokeTokenForm {
+	return &RevokeTokenForm{}
+}
+
+func (f *RevokeTokenForm) ParseAndValidate(r *http.Request) (*RevokeTokenForm, response.Error) {
+	request := &revokeTokenRequest{
+		ID: mux.Vars(r)["id"],
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetID(request, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *RevokeTokenForm) validateAndSetID(req *revokeTokenRequest, errors map[string]response.ErrorMessage) {
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		errors["id"] = response.MissedValueError("missed value")
+
+		return
+	}
+
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		errors["id"] = response.WrongValueError("wrong id format")
+
+		return
+	}
+
+	f.ID = parsed
+}
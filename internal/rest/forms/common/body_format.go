@@ -0,0 +1,61 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+var bodyFormats = []common.ContentType{common.Markdown, common.HTML, common.Text}
+
+// BodyFormat is the list of requested renderings of proposal bodies, markdown is returned by default
+type BodyFormat struct {
+	Formats []common.ContentType
+}
+
+func NewBodyFormat() *BodyFormat {
+	return &BodyFormat{}
+}
+
+func (f *BodyFormat) ParseAndValidate(r *http.Request) (*BodyFormat, response.Error) {
+	errors := make(map[string]response.ErrorMessage)
+
+	f.ValidateAndSetBodyFormat(r, errors)
+
+	if len(errors) > 0 {
+		ve := response.NewValidationError(errors)
+
+		return nil, ve
+	}
+
+	return f, nil
+}
+
+func (f *BodyFormat) ValidateAndSetBodyFormat(r *http.Request, errors map[string]response.ErrorMessage) {
+	value := r.FormValue("body_format")
+	if value == "" {
+		f.Formats = []common.ContentType{common.Markdown}
+
+		return
+	}
+
+	formats := make([]common.ContentType, 0, len(bodyFormats))
+	for _, part := range strings.Split(value, ",") {
+		format := common.ContentType(strings.ToLower(strings.TrimSpace(part)))
+		if !slices.Contains(bodyFormats, format) {
+			errors["body_format"] = response.WrongValueError(fmt.Sprintf("unsupported format: %s", part))
+
+			return
+		}
+
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+
+	f.Formats = formats
+}
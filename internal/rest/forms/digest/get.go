@@ -0,0 +1,48 @@
+package digest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/digest"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+type getRequest struct {
+	Period string
+}
+
+type GetForm struct {
+	Period digest.Period
+}
+
+func NewGetForm() *GetForm {
+	return &GetForm{}
+}
+
+func (f *GetForm) ParseAndValidate(r *http.Request) (*GetForm, response.Error) {
+	req := &getRequest{
+		Period: r.URL.Query().Get("period"),
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetPeriod(req, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *GetForm) validateAndSetPeriod(req *getRequest, errors map[string]response.ErrorMessage) {
+	period := digest.Period(strings.ToLower(strings.TrimSpace(req.Period)))
+	switch period {
+	case "":
+		f.Period = digest.PeriodWeek
+	case digest.PeriodDay, digest.PeriodWeek:
+		f.Period = period
+	default:
+		errors["period"] = response.WrongValueError("should be day or week")
+	}
+}
@@ -0,0 +1,129 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+type archiveBatchRequest struct {
+	filtersRequest
+	ID     []string `json:"id"`
+	Before string   `json:"before"`
+}
+
+// ArchiveBatchForm archives items by ids, by the date or by filters of the feed listing.
+// One of them is required, so the whole feed isn't archived by the empty request.
+type ArchiveBatchForm struct {
+	IDs     []uuid.UUID
+	Before  *time.Time
+	Filters Filters
+}
+
+func NewArchiveBatchForm() *ArchiveBatchForm {
+	return &ArchiveBatchForm{}
+}
+
+func (f *ArchiveBatchForm) ParseAndValidate(r *http.Request) (*ArchiveBatchForm, response.Error) {
+	var request *archiveBatchRequest
+	if err := helpers.ReadJSON(r.Body, &request); err != nil || request == nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.IDs = validateBatchIDs(request.ID, errors)
+	f.validateAndSetBefore(request, errors)
+	f.Filters.validateAndSet(&request.filtersRequest, errors)
+	validateFiltersUsage(&f.Filters, len(f.IDs) > 0 || f.Before != nil, errors)
+
+	if len(errors) == 0 && len(f.IDs) == 0 && f.Before == nil && f.Filters.IsEmpty() {
+		errors["id"] = response.MissedValueError("ids, before or filters are required")
+	}
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *ArchiveBatchForm) validateAndSetBefore(req *archiveBatchRequest, errors map[string]response.ErrorMessage) {
+	beforeRAW := strings.TrimSpace(req.Before)
+	if beforeRAW == "" {
+		return
+	}
+
+	before, err := time.Parse(time.RFC3339, beforeRAW)
+	if err != nil {
+		errors["before"] = response.WrongValueError("wrong value")
+
+		return
+	}
+
+	f.Before = &before
+}
+
+type unarchiveBatchRequest struct {
+	filtersRequest
+	ID []string `json:"id"`
+}
+
+// UnarchiveBatchForm restores items by ids or by filters of the feed listing
+type UnarchiveBatchForm struct {
+	IDs     []uuid.UUID
+	Filters Filters
+}
+
+func NewUnarchiveBatchForm() *UnarchiveBatchForm {
+	return &UnarchiveBatchForm{}
+}
+
+func (f *UnarchiveBatchForm) ParseAndValidate(r *http.Request) (*UnarchiveBatchForm, response.Error) {
+	var request *unarchiveBatchRequest
+	if err := helpers.ReadJSON(r.Body, &request); err != nil || request == nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.IDs = validateBatchIDs(request.ID, errors)
+	f.Filters.validateAndSet(&request.filtersRequest, errors)
+	validateFiltersUsage(&f.Filters, len(f.IDs) > 0, errors)
+
+	if len(errors) == 0 && len(f.IDs) == 0 && f.Filters.IsEmpty() {
+		errors["id"] = response.MissedValueError("ids or filters are required")
+	}
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func validateBatchIDs(raw []string, errors map[string]response.ErrorMessage) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(raw))
+	for i, id := range raw {
+		parsed, err := uuid.Parse(strings.TrimSpace(id))
+		if err != nil {
+			errors[fmt.Sprintf("id.%d", i)] = response.WrongValueError("wrong value")
+
+			return nil
+		}
+
+		ids = append(ids, parsed)
+	}
+
+	return ids
+}
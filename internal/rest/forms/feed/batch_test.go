@@ -0,0 +1,104 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const batchItemID = "0d5c9a43-7b6a-4a4e-9f8e-3f1c2a0b9d11"
+
+func newBatchRequest(body string) *http.Request {
+	return httptest.NewRequest(http.MethodPost, "/feed/batch", strings.NewReader(body))
+}
+
+// validationErrors returns keys of invalid fields
+func validationErrors(t *testing.T, err response.Error) []string {
+	require.NotNil(t, err)
+
+	ve, ok := err.(*response.ValidationError)
+	require.True(t, ok)
+
+	keys := make([]string, 0, len(ve.Errors()))
+	for key := range ve.Errors() {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func TestArchiveBatchForm(t *testing.T) {
+	t.Run("filters", func(t *testing.T) {
+		f, err := NewArchiveBatchForm().ParseAndValidate(newBatchRequest(`{"dao": ["aave.eth"], "state": ["closed"], "voted": false}`))
+		require.Nil(t, err)
+		assert.Empty(t, f.IDs)
+		assert.Equal(t, []string{"aave.eth"}, f.Filters.DAOs)
+		assert.Equal(t, []proposal.State{proposal.ClosedState}, f.Filters.States)
+		require.NotNil(t, f.Filters.Voted)
+		assert.False(t, *f.Filters.Voted)
+	})
+
+	t.Run("ids", func(t *testing.T) {
+		f, err := NewArchiveBatchForm().ParseAndValidate(newBatchRequest(`{"id": ["` + batchItemID + `"]}`))
+		require.Nil(t, err)
+		require.Len(t, f.IDs, 1)
+		assert.Equal(t, batchItemID, f.IDs[0].String())
+		assert.True(t, f.Filters.IsEmpty())
+	})
+
+	for name, tc := range map[string]struct {
+		body string
+		keys []string
+	}{
+		"empty request archives nothing":  {body: `{}`, keys: []string{"id"}},
+		"filters with ids":                {body: `{"id": ["` + batchItemID + `"], "event": ["proposal.created"]}`, keys: []string{response.GeneralErrorKey}},
+		"filters with the date":           {body: `{"before": "2024-01-01T00:00:00Z", "state": ["active"]}`, keys: []string{response.GeneralErrorKey}},
+		"unknown state":                   {body: `{"state": ["unknown"]}`, keys: []string{"state.0"}},
+		"wrong id":                        {body: `{"id": ["` + batchItemID + `", "wrong"]}`, keys: []string{"id.1"}},
+		"created_before before the after": {body: `{"created_after": "2024-02-01T00:00:00Z", "created_before": "2024-01-01T00:00:00Z"}`, keys: []string{"created_before"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewArchiveBatchForm().ParseAndValidate(newBatchRequest(tc.body))
+			assert.ElementsMatch(t, tc.keys, validationErrors(t, err))
+		})
+	}
+}
+
+func TestUnarchiveBatchForm(t *testing.T) {
+	f, err := NewUnarchiveBatchForm().ParseAndValidate(newBatchRequest(`{"event": ["proposal.created"]}`))
+	require.Nil(t, err)
+	assert.False(t, f.Filters.IsEmpty())
+
+	_, err = NewUnarchiveBatchForm().ParseAndValidate(newBatchRequest(`{}`))
+	assert.ElementsMatch(t, []string{"id"}, validationErrors(t, err))
+
+	_, err = NewUnarchiveBatchForm().ParseAndValidate(newBatchRequest(`{"id": ["` + batchItemID + `"], "dao": ["aave.eth"]}`))
+	assert.ElementsMatch(t, []string{response.GeneralErrorKey}, validationErrors(t, err))
+}
+
+func TestMarkAsReadBatchForm(t *testing.T) {
+	// the empty request marks the whole feed as read
+	f, err := NewMarkAsReadBatchForm().ParseAndValidate(newBatchRequest(`{}`))
+	require.Nil(t, err)
+	assert.Empty(t, f.IDs)
+	assert.Nil(t, f.Before)
+	assert.True(t, f.Filters.IsEmpty())
+
+	f, err = NewMarkAsReadBatchForm().ParseAndValidate(newBatchRequest(`{"dao": ["aave.eth"], "created_after": "2024-01-01T00:00:00Z"}`))
+	require.Nil(t, err)
+	assert.Equal(t, []string{"aave.eth"}, f.Filters.DAOs)
+	require.NotNil(t, f.Filters.CreatedAfter)
+
+	_, err = NewMarkAsReadBatchForm().ParseAndValidate(newBatchRequest(`{"id": ["` + batchItemID + `"], "voted": true}`))
+	assert.ElementsMatch(t, []string{response.GeneralErrorKey}, validationErrors(t, err))
+
+	_, err = NewMarkAsReadBatchForm().ParseAndValidate(newBatchRequest(`{"event": ["unknown"]}`))
+	assert.ElementsMatch(t, []string{"event.0"}, validationErrors(t, err))
+}
@@ -0,0 +1,176 @@
+package feed
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	feedentity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+var (
+	allowedEvents = []feedentity.Event{
+		feedentity.DaoCreated,
+		feedentity.ProposalCreated,
+		feedentity.ProposalUpdated,
+		feedentity.ProposalUpdatedState,
+		feedentity.ProposalVotingStartsSoon,
+		feedentity.ProposalVotingStarted,
+		feedentity.ProposalVotingReachedQuorum,
+		feedentity.ProposalVotingFinishesSoon,
+		feedentity.ProposalVotingEndsSoon,
+		feedentity.ProposalVotingEnded,
+	}
+
+	allowedStates = []proposal.State{
+		proposal.PendingState,
+		proposal.ActiveState,
+		proposal.ClosedState,
+		proposal.FinalState,
+	}
+)
+
+type filtersRequest struct {
+	Dao           []string `json:"dao"`
+	Event         []string `json:"event"`
+	State         []string `json:"state"`
+	Voted         *bool    `json:"voted"`
+	CreatedAfter  string   `json:"created_after"`
+	CreatedBefore string   `json:"created_before"`
+}
+
+// Filters narrow down the feed. DAOs are passed by ids or aliases and resolved by the server.
+// Listings apply filters to the latest feed items only, the number of them is sent in the X-Filter-Window header.
+type Filters struct {
+	DAOs          []string
+	Events        []feedentity.Event
+	States        []proposal.State
+	Voted         *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// IsEmpty reports whether the feed can be loaded from the storage without filtering
+func (f *Filters) IsEmpty() bool {
+	return len(f.DAOs) == 0 &&
+		len(f.Events) == 0 &&
+		len(f.States) == 0 &&
+		f.Voted == nil &&
+		f.CreatedAfter == nil &&
+		f.CreatedBefore == nil
+}
+
+// newFiltersRequestFromQuery reads list values passed both as repeated params and as comma separated values
+func newFiltersRequestFromQuery(query url.Values, errors map[string]response.ErrorMessage) *filtersRequest {
+	req := &filtersRequest{
+		Dao:           splitQueryValues(query["dao"]),
+		Event:         splitQueryValues(query["event"]),
+		State:         splitQueryValues(query["state"]),
+		CreatedAfter:  query.Get("created_after"),
+		CreatedBefore: query.Get("created_before"),
+	}
+
+	if value := strings.TrimSpace(query.Get("voted")); value != "" {
+		voted, err := strconv.ParseBool(value)
+		if err != nil {
+			errors["voted"] = response.WrongValueError("should be boolean")
+		} else {
+			req.Voted = &voted
+		}
+	}
+
+	return req
+}
+
+func splitQueryValues(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				list = append(list, part)
+			}
+		}
+	}
+
+	return list
+}
+
+func (f *Filters) validateAndSet(req *filtersRequest, errors map[string]response.ErrorMessage) {
+	f.validateAndSetDAOs(req, errors)
+	f.validateAndSetEvents(req, errors)
+	f.validateAndSetStates(req, errors)
+	f.Voted = req.Voted
+	f.CreatedAfter = validateTime(req.CreatedAfter, "created_after", errors)
+	f.CreatedBefore = validateTime(req.CreatedBefore, "created_before", errors)
+
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && !f.CreatedAfter.Before(*f.CreatedBefore) {
+		errors["created_before"] = response.WrongValueError("should be after created_after")
+	}
+}
+
+func (f *Filters) validateAndSetDAOs(req *filtersRequest, errors map[string]response.ErrorMessage) {
+	for i, dao := range req.Dao {
+		dao = strings.TrimSpace(dao)
+		if dao == "" {
+			errors[fmt.Sprintf("dao.%d", i)] = response.MissedValueError("missed value")
+
+			return
+		}
+
+		f.DAOs = append(f.DAOs, dao)
+	}
+}
+
+func (f *Filters) validateAndSetEvents(req *filtersRequest, errors map[string]response.ErrorMessage) {
+	for i, value := range req.Event {
+		event := feedentity.Event(strings.ToLower(strings.TrimSpace(value)))
+		if !slices.Contains(allowedEvents, event) {
+			errors[fmt.Sprintf("event.%d", i)] = response.WrongValueError("unknown event")
+
+			return
+		}
+
+		f.Events = append(f.Events, event)
+	}
+}
+
+func (f *Filters) validateAndSetStates(req *filtersRequest, errors map[string]response.ErrorMessage) {
+	for i, value := range req.State {
+		state := proposal.State(strings.ToLower(strings.TrimSpace(value)))
+		if !slices.Contains(allowedStates, state) {
+			errors[fmt.Sprintf("state.%d", i)] = response.WrongValueError("unknown state")
+
+			return
+		}
+
+		f.States = append(f.States, state)
+	}
+}
+
+func validateTime(value, field string, errors map[string]response.ErrorMessage) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		errors[field] = response.WrongValueError("wrong value")
+
+		return nil
+	}
+
+	return &parsed
+}
+
+// validateFiltersUsage forbids combining filters with explicit ids or dates, the result would be ambiguous
+func validateFiltersUsage(filters *Filters, explicit bool, errors map[string]response.ErrorMessage) {
+	if explicit && !filters.IsEmpty() {
+		errors[response.GeneralErrorKey] = response.WrongValueError("filters can't be combined with ids or dates")
+	}
+}
@@ -33,6 +33,7 @@ func (s FieldState) AsProto() inboxapi.GetUserFeedRequest_State {
 type GetFeedForm struct {
 	Unread   FieldState
 	Archived FieldState
+	Filters  Filters
 }
 
 func NewGetFeedForm() *GetFeedForm {
@@ -43,6 +44,13 @@ func (f *GetFeedForm) ParseAndValidate(r *http.Request) (*GetFeedForm, response.
 	f.Unread = extractStateField(r.URL.Query(), "unread", FieldInclude)
 	f.Archived = extractStateField(r.URL.Query(), "archived", FieldExclude)
 
+	errors := make(map[string]response.ErrorMessage)
+	f.Filters.validateAndSet(newFiltersRequestFromQuery(r.URL.Query(), errors), errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
 	return f, nil
 }
 
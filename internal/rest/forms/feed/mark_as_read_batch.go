@@ -13,13 +13,16 @@ import (
 )
 
 type markAsReadBatchRequest struct {
+	filtersRequest
 	ID     []string `json:"id"`
 	Before string   `json:"before"`
 }
 
+// MarkAsReadBatchForm marks items by ids, by the date or by filters of the feed listing
 type MarkAsReadBatchForm struct {
-	IDs    []uuid.UUID
-	Before *time.Time
+	IDs     []uuid.UUID
+	Before  *time.Time
+	Filters Filters
 }
 
 func NewMarkAsReadBatchForm() *MarkAsReadBatchForm {
@@ -38,6 +41,8 @@ func (f *MarkAsReadBatchForm) ParseAndValidate(r *http.Request) (*MarkAsReadBatc
 	errors := make(map[string]response.ErrorMessage)
 	f.validateAndSetIDs(request, errors)
 	f.validateAndSetBefore(request, errors)
+	f.Filters.validateAndSet(&request.filtersRequest, errors)
+	validateFiltersUsage(&f.Filters, len(f.IDs) > 0 || f.Before != nil, errors)
 
 	if len(errors) > 0 {
 		return nil, response.NewValidationError(errors)
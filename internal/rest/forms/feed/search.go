@@ -0,0 +1,66 @@
+package feed
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	helpers "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/search"
+)
+
+const maxSearchQueryLength = 256
+
+type searchRequest struct {
+	Query string
+}
+
+type SearchForm struct {
+	helpers.Pagination
+
+	Query string
+}
+
+func NewSearchForm() *SearchForm {
+	return &SearchForm{}
+}
+
+func (f *SearchForm) ParseAndValidate(r *http.Request) (*SearchForm, response.Error) {
+	req := &searchRequest{
+		Query: r.URL.Query().Get("q"),
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetQuery(req, errors)
+	f.ValidateAndSetPagination(r, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *SearchForm) validateAndSetQuery(req *searchRequest, errors map[string]response.ErrorMessage) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		errors["q"] = response.MissedValueError("missed value")
+
+		return
+	}
+
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		errors["q"] = response.WrongValueError("query is too long")
+
+		return
+	}
+
+	if len(search.Terms(query)) == 0 {
+		errors["q"] = response.WrongValueError("query has no words to search")
+
+		return
+	}
+
+	f.Query = query
+}
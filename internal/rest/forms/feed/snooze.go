@@ -0,0 +1,112 @@
+package feed
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/snooze"
+)
+
+type snoozeRequest struct {
+	ID         string  `json:"-"`
+	ProposalID *string `json:"proposal_id"`
+	Until      *string `json:"until"`
+	Preset     *string `json:"preset"`
+	Remind     *bool   `json:"remind"`
+}
+
+// SnoozeForm contains either the absolute time or the preset resolved by the handler. The proposal of the feed item
+// is passed by the client: it's required by presets relative to the voting window and used as the reminder title.
+type SnoozeForm struct {
+	MarkUnmarkItemForm
+
+	ProposalID string
+	Until      *time.Time
+	Preset     snooze.Preset
+	Remind     bool
+}
+
+func NewSnoozeForm() *SnoozeForm {
+	return &SnoozeForm{}
+}
+
+func (f *SnoozeForm) ParseAndValidate(r *http.Request) (*SnoozeForm, response.Error) {
+	var request *snoozeRequest
+	if err := helpers.ReadJSON(r.Body, &request); err != nil || request == nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+	request.ID = mux.Vars(r)["id"]
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetID(&markUnmarkItemRequest{ID: request.ID}, errors)
+	f.validateAndSetTime(request, errors)
+	f.validateAndSetProposalID(request, errors)
+	f.Remind = request.Remind != nil && *request.Remind
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *SnoozeForm) validateAndSetTime(req *snoozeRequest, errors map[string]response.ErrorMessage) {
+	if req.Until == nil && req.Preset == nil {
+		errors["until"] = response.MissedValueError("until or preset is required")
+
+		return
+	}
+
+	if req.Until != nil && req.Preset != nil {
+		errors[response.GeneralErrorKey] = response.WrongValueError("until and preset can't be used together")
+
+		return
+	}
+
+	if req.Preset != nil {
+		preset := snooze.Preset(strings.TrimSpace(*req.Preset))
+		if !slices.Contains(snooze.Presets, preset) {
+			errors["preset"] = response.WrongValueError("unsupported preset")
+
+			return
+		}
+
+		f.Preset = preset
+
+		return
+	}
+
+	until, err := time.Parse(time.RFC3339, strings.TrimSpace(*req.Until))
+	if err != nil {
+		errors["until"] = response.WrongValueError("wrong time format, RFC3339 is expected")
+
+		return
+	}
+
+	if err := snooze.Validate(time.Now(), until); err != nil {
+		errors["until"] = response.WrongValueError(err.Error())
+
+		return
+	}
+
+	f.Until = &until
+}
+
+func (f *SnoozeForm) validateAndSetProposalID(req *snoozeRequest, errors map[string]response.ErrorMessage) {
+	if req.ProposalID != nil {
+		f.ProposalID = strings.TrimSpace(*req.ProposalID)
+	}
+
+	if f.ProposalID == "" && f.Preset.RequiresVoting() {
+		errors["proposal_id"] = response.MissedValueError("proposal_id is required by the preset")
+	}
+}
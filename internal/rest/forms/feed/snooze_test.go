@@ -0,0 +1,50 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/snooze"
+)
+
+func newSnoozeRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/feed/"+batchItemID+"/snooze", strings.NewReader(body))
+
+	return mux.SetURLVars(r, map[string]string{"id": batchItemID})
+}
+
+func TestSnoozeForm(t *testing.T) {
+	t.Run("voting preset", func(t *testing.T) {
+		f, err := NewSnoozeForm().ParseAndValidate(newSnoozeRequest(`{"preset": "voting_starts", "proposal_id": " 0x1 ", "remind": true}`))
+		require.Nil(t, err)
+		assert.Equal(t, batchItemID, f.ID.String())
+		assert.Equal(t, snooze.PresetVotingStarts, f.Preset)
+		assert.Equal(t, "0x1", f.ProposalID)
+		assert.True(t, f.Remind)
+	})
+
+	for name, tc := range map[string]struct {
+		body string
+		keys []string
+	}{
+		"voting preset without proposal": {body: `{"preset": "voting_starts"}`, keys: []string{"proposal_id"}},
+		"time without proposal":          {body: `{"preset": "1h"}`},
+		"missing time":                   {body: `{"proposal_id": "0x1"}`, keys: []string{"until"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewSnoozeForm().ParseAndValidate(newSnoozeRequest(tc.body))
+			if len(tc.keys) == 0 {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.ElementsMatch(t, tc.keys, validationErrors(t, err))
+		})
+	}
+}
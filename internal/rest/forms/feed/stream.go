@@ -0,0 +1,59 @@
+package feed
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const LastEventIDHeader = "Last-Event-ID"
+
+type streamRequest struct {
+	LastEventID string
+}
+
+type StreamForm struct {
+	LastEventID *uint64
+}
+
+func NewStreamForm() *StreamForm {
+	return &StreamForm{}
+}
+
+// ParseAndValidate reads the last event id from the header, which is sent by EventSource on reconnection,
+// or from the query for clients which can't set headers, e.g. browser WebSocket
+func (f *StreamForm) ParseAndValidate(r *http.Request) (*StreamForm, response.Error) {
+	request := &streamRequest{
+		LastEventID: r.Header.Get(LastEventIDHeader),
+	}
+	if request.LastEventID == "" {
+		request.LastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+	f.validateAndSetLastEventID(request, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *StreamForm) validateAndSetLastEventID(req *streamRequest, errors map[string]response.ErrorMessage) {
+	id := strings.TrimSpace(req.LastEventID)
+	if id == "" {
+		return
+	}
+
+	parsed, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		errors["last_event_id"] = response.WrongValueError("wrong last event id")
+
+		return
+	}
+
+	f.LastEventID = &parsed
+}
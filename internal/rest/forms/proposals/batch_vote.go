@@ -0,0 +1,189 @@
+package proposals
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+// MaxBatchVotes limits the number of proposals voted in one batch
+const MaxBatchVotes = 20
+
+type batchPrepareVoteRequest struct {
+	Voter string                        `json:"voter"`
+	Items []batchPrepareVoteItemRequest `json:"items"`
+}
+
+type batchPrepareVoteItemRequest struct {
+	ProposalID string          `json:"proposal_id"`
+	Choice     json.RawMessage `json:"choice"`
+	Reason     *string         `json:"reason,omitempty"`
+}
+
+type BatchPrepareVote struct {
+	Voter common.Voter
+	Items []BatchPrepareVoteItem
+}
+
+type BatchPrepareVoteItem struct {
+	ProposalID string
+	Choice     common.Choice
+	Reason     *string
+}
+
+func NewBatchPrepareVoteForm() *BatchPrepareVote {
+	return &BatchPrepareVote{}
+}
+
+func (f *BatchPrepareVote) ParseAndValidate(r *http.Request) (*BatchPrepareVote, response.Error) {
+	var req *batchPrepareVoteRequest
+	if err := helpers.ReadJSON(r.Body, &req); err != nil || req == nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+
+	f.Voter.ValidateAndSet(req.Voter, errors)
+	f.validateAndSetItems(req, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *BatchPrepareVote) validateAndSetItems(req *batchPrepareVoteRequest, errors map[string]response.ErrorMessage) {
+	if !validateBatchSize(len(req.Items), errors) {
+		return
+	}
+
+	ids := make([]string, 0, len(req.Items))
+	f.Items = make([]BatchPrepareVoteItem, 0, len(req.Items))
+	for i, info := range req.Items {
+		id := strings.TrimSpace(info.ProposalID)
+		switch {
+		case id == "":
+			errors[fmt.Sprintf("items.%d.proposal_id", i)] = response.MissedValueError("missing proposal id")
+		case slices.Contains(ids, id):
+			errors[fmt.Sprintf("items.%d.proposal_id", i)] = response.WrongValueError("duplicated proposal id")
+		}
+		ids = append(ids, id)
+
+		item := BatchPrepareVoteItem{
+			ProposalID: id,
+			Reason:     info.Reason,
+		}
+
+		itemErrors := make(map[string]response.ErrorMessage)
+		item.Choice.ValidateAndSet(info.Choice, itemErrors)
+		for key, msg := range itemErrors {
+			errors[fmt.Sprintf("items.%d.%s", i, key)] = msg
+		}
+
+		f.Items = append(f.Items, item)
+	}
+}
+
+func (f *BatchPrepareVote) ConvertToMap() map[string]interface{} {
+	ids := make([]string, 0, len(f.Items))
+	for _, item := range f.Items {
+		ids = append(ids, item.ProposalID)
+	}
+
+	return map[string]interface{}{
+		"voter":        f.Voter,
+		"proposal_ids": ids,
+	}
+}
+
+type batchVoteRequest struct {
+	Items []VoteRequest `json:"items"`
+}
+
+type BatchVote struct {
+	Items []Vote
+}
+
+func NewBatchVoteForm() *BatchVote {
+	return &BatchVote{}
+}
+
+func (f *BatchVote) ParseAndValidate(r *http.Request) (*BatchVote, response.Error) {
+	var req *batchVoteRequest
+	if err := helpers.ReadJSON(r.Body, &req); err != nil || req == nil {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.InvalidRequestStructure, "invalid request structure")
+
+		return nil, ve
+	}
+
+	errors := make(map[string]response.ErrorMessage)
+
+	f.validateAndSetItems(req, errors)
+
+	if len(errors) > 0 {
+		return nil, response.NewValidationError(errors)
+	}
+
+	return f, nil
+}
+
+func (f *BatchVote) validateAndSetItems(req *batchVoteRequest, errors map[string]response.ErrorMessage) {
+	if !validateBatchSize(len(req.Items), errors) {
+		return
+	}
+
+	f.Items = make([]Vote, 0, len(req.Items))
+	for i, info := range req.Items {
+		item := Vote{
+			ID:  strings.TrimSpace(info.ID),
+			Sig: strings.TrimSpace(info.Sig),
+		}
+
+		if item.ID == "" {
+			errors[fmt.Sprintf("items.%d.id", i)] = response.MissedValueError("missing id")
+		} else if slices.ContainsFunc(f.Items, func(v Vote) bool { return v.ID == item.ID }) {
+			errors[fmt.Sprintf("items.%d.id", i)] = response.WrongValueError("duplicated id")
+		}
+
+		if item.Sig == "" {
+			errors[fmt.Sprintf("items.%d.sig", i)] = response.MissedValueError("missing sig")
+		}
+
+		f.Items = append(f.Items, item)
+	}
+}
+
+func (f *BatchVote) ConvertToMap() map[string]interface{} {
+	ids := make([]string, 0, len(f.Items))
+	for _, item := range f.Items {
+		ids = append(ids, item.ID)
+	}
+
+	return map[string]interface{}{
+		"ids": ids,
+	}
+}
+
+func validateBatchSize(size int, errors map[string]response.ErrorMessage) bool {
+	switch {
+	case size == 0:
+		errors["items"] = response.MissedValueError("missing items")
+	case size > MaxBatchVotes:
+		errors["items"] = response.WrongValueError(fmt.Sprintf("should be up to %d items", MaxBatchVotes))
+	default:
+		return true
+	}
+
+	return false
+}
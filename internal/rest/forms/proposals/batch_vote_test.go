@@ -0,0 +1,161 @@
+package proposals
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const batchVoter = "0x4C7909d6F029b3a5798143C843F4f8e5341a3473"
+
+func newBatchRequest(body string) *http.Request {
+	return httptest.NewRequest(http.MethodPost, "/proposals/votes/batch", strings.NewReader(body))
+}
+
+// validationCodes returns codes of invalid fields
+func validationCodes(t *testing.T, err response.Error) map[string]response.ErrCode {
+	require.NotNil(t, err)
+
+	ve, ok := err.(*response.ValidationError)
+	require.True(t, ok)
+
+	codes := make(map[string]response.ErrCode, len(ve.Errors()))
+	for key, msg := range ve.Errors() {
+		codes[key] = msg.Code
+	}
+
+	return codes
+}
+
+// batchItems joins the same item repeated with different ids
+func batchItems(count int, item string) string {
+	items := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		items = append(items, fmt.Sprintf(item, i))
+	}
+
+	return `{"voter": "` + batchVoter + `", "items": [` + strings.Join(items, ",") + `]}`
+}
+
+func TestBatchPrepareVoteForm(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		f, err := NewBatchPrepareVoteForm().ParseAndValidate(newBatchRequest(`{"voter": "` + batchVoter + `", "items": [
+			{"proposal_id": " 0x1 ", "choice": 1, "reason": "for"},
+			{"proposal_id": "0x2", "choice": [2, 1]}
+		]}`))
+		require.Nil(t, err)
+		assert.Equal(t, batchVoter, string(f.Voter))
+		require.Len(t, f.Items, 2)
+		assert.Equal(t, "0x1", f.Items[0].ProposalID)
+		assert.JSONEq(t, `1`, string(f.Items[0].Choice))
+		require.NotNil(t, f.Items[0].Reason)
+		assert.Equal(t, "for", *f.Items[0].Reason)
+		assert.Equal(t, "0x2", f.Items[1].ProposalID)
+		assert.Nil(t, f.Items[1].Reason)
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		f, err := NewBatchPrepareVoteForm().ParseAndValidate(newBatchRequest(batchItems(MaxBatchVotes, `{"proposal_id": "0x%d", "choice": 1}`)))
+		require.Nil(t, err)
+		assert.Len(t, f.Items, MaxBatchVotes)
+	})
+
+	for name, tc := range map[string]struct {
+		body  string
+		codes map[string]response.ErrCode
+	}{
+		"invalid structure": {
+			body:  `{"items": {}}`,
+			codes: map[string]response.ErrCode{response.GeneralErrorKey: response.InvalidRequestStructure},
+		},
+		"no items": {
+			body:  `{"voter": "` + batchVoter + `", "items": []}`,
+			codes: map[string]response.ErrCode{"items": response.MissedValue},
+		},
+		"too many items": {
+			body:  batchItems(MaxBatchVotes+1, `{"proposal_id": "0x%d", "choice": 1}`),
+			codes: map[string]response.ErrCode{"items": response.WrongValue},
+		},
+		"missing voter": {
+			body:  `{"items": [{"proposal_id": "0x1", "choice": 1}]}`,
+			codes: map[string]response.ErrCode{"voter": response.MissedValue},
+		},
+		"duplicated proposal": {
+			body: `{"voter": "` + batchVoter + `", "items": [
+				{"proposal_id": "0x1", "choice": 1},
+				{"proposal_id": "0x2", "choice": 1},
+				{"proposal_id": " 0x1", "choice": 2}
+			]}`,
+			codes: map[string]response.ErrCode{"items.2.proposal_id": response.WrongValue},
+		},
+		"errors of items": {
+			body: `{"voter": "` + batchVoter + `", "items": [
+				{"proposal_id": "0x1", "choice": 1},
+				{"proposal_id": "", "choice": 1},
+				{"proposal_id": "0x3"}
+			]}`,
+			codes: map[string]response.ErrCode{
+				"items.1.proposal_id": response.MissedValue,
+				"items.2.choice":      response.WrongFormat,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewBatchPrepareVoteForm().ParseAndValidate(newBatchRequest(tc.body))
+			assert.Equal(t, tc.codes, validationCodes(t, err))
+		})
+	}
+}
+
+func TestBatchVoteForm(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		f, err := NewBatchVoteForm().ParseAndValidate(newBatchRequest(`{"items": [
+			{"id": " 1 ", "sig": " 0xsig1 "},
+			{"id": "2", "sig": "0xsig2"}
+		]}`))
+		require.Nil(t, err)
+		assert.Equal(t, []Vote{{ID: "1", Sig: "0xsig1"}, {ID: "2", Sig: "0xsig2"}}, f.Items)
+		assert.Equal(t, map[string]interface{}{"ids": []string{"1", "2"}}, f.ConvertToMap())
+	})
+
+	for name, tc := range map[string]struct {
+		body  string
+		codes map[string]response.ErrCode
+	}{
+		"no items": {
+			body:  `{}`,
+			codes: map[string]response.ErrCode{"items": response.MissedValue},
+		},
+		"too many items": {
+			body:  `{"items": [` + strings.Repeat(`{"id": "1", "sig": "0xsig"},`, MaxBatchVotes) + `{"id": "2", "sig": "0xsig"}]}`,
+			codes: map[string]response.ErrCode{"items": response.WrongValue},
+		},
+		"duplicated id": {
+			body:  `{"items": [{"id": "1", "sig": "0xsig1"}, {"id": "1 ", "sig": "0xsig2"}]}`,
+			codes: map[string]response.ErrCode{"items.1.id": response.WrongValue},
+		},
+		"errors of items": {
+			body: `{"items": [
+				{"id": "1", "sig": "0xsig1"},
+				{"id": "", "sig": "0xsig2"},
+				{"id": "3", "sig": " "}
+			]}`,
+			codes: map[string]response.ErrCode{
+				"items.1.id":  response.MissedValue,
+				"items.2.sig": response.MissedValue,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewBatchVoteForm().ParseAndValidate(newBatchRequest(tc.body))
+			assert.Equal(t, tc.codes, validationCodes(t, err))
+		})
+	}
+}
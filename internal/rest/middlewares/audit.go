@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/pkg/ctxfields"
+)
+
+// Audit writes the audit record for each request: who did the action, with which arguments and the result of it
+func Audit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error().Err(err).Msg("unable to read request body")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewBuffer(body))
+
+		wrapped := NewResponseWriterWrapper(w)
+		next.ServeHTTP(wrapped, r)
+
+		status := wrapped.StatusCode
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		var userID string
+		if session, ok := appctx.ExtractUserSession(r.Context()); ok {
+			userID = session.UserID.String()
+		}
+
+		var action string
+		if route := mux.CurrentRoute(r); route != nil {
+			action = route.GetName()
+		}
+
+		log.Info().
+			Bool("audit", true).
+			Str("action", action).
+			Str("user_id", userID).
+			Str("method", r.Method).
+			Str("url", r.URL.String()).
+			Str("body", string(body)).
+			Int("status", status).
+			Str("ip", ctxfields.ExtractRequestIP(r.Context())).
+			Msg("admin action")
+	})
+}
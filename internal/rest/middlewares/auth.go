@@ -1,19 +1,29 @@
 package middlewares
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
+	forms "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/auth"
 )
 
-const AuthTokenHeader = "Authorization"
+const (
+	AuthTokenHeader = "Authorization"
+	bearerPrefix    = "Bearer "
+)
 
 type AuthService interface {
 	GetSession(sessionID auth.SessionID, callback func(id auth.UserID)) (auth.Session, error)
+	GetTokenSession(raw string, callback func(id auth.UserID)) (auth.Session, []auth.Scope, error)
+	VerifyAccessToken(raw string, callback func(id auth.UserID)) (auth.Session, profile.Role, error)
+	TrackDevice(sessionID auth.SessionID, appPlatform, appVersion string)
 }
 
 func Auth(storage AuthService, callback func(id auth.UserID)) func(next http.Handler) http.Handler {
@@ -25,6 +35,42 @@ func Auth(storage AuthService, callback func(id auth.UserID)) func(next http.Han
 				return
 			}
 
+			if auth.IsPersonalToken(token) {
+				session, scopes, err := storage.GetTokenSession(token, callback)
+				if errors.Is(err, auth.ErrTokenNotFound) || errors.Is(err, auth.ErrTokenExpired) {
+					log.Warn().Err(err).Msg("wrong personal token")
+
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				if err != nil {
+					log.Error().Err(err).Msg("get personal token session")
+
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				ctx := appctx.EnrichWithTokenScopes(appctx.EnrichWithUserSession(r.Context(), session), scopes)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			if strings.HasPrefix(token, bearerPrefix) {
+				session, role, err := storage.VerifyAccessToken(strings.TrimPrefix(token, bearerPrefix), callback)
+				if err != nil {
+					log.Warn().Err(err).Msg("wrong access token")
+
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+
+				storage.TrackDevice(session.ID, r.Header.Get(forms.AppPlatformHeader), r.Header.Get(forms.AppVersionHeader))
+
+				ctx := appctx.EnrichWithUserRole(appctx.EnrichWithUserSession(r.Context(), session), role)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			tokenUUID, err := uuid.Parse(token)
 			if err != nil {
 				log.Warn().
@@ -41,6 +87,8 @@ func Auth(storage AuthService, callback func(id auth.UserID)) func(next http.Han
 				return
 			}
 
+			storage.TrackDevice(session.ID, r.Header.Get(forms.AppPlatformHeader), r.Header.Get(forms.AppVersionHeader))
+
 			ctx := appctx.EnrichWithUserSession(r.Context(), session)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
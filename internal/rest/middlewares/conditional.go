@@ -0,0 +1,130 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+// ConditionalGet adds validators to successful GET responses and replies with 304 Not Modified when
+// the client has the same version. The weak ETag is computed from the body unless the handler sets it,
+// Last-Modified is set by handlers of bodies built from one entity with the update time. Responses of public routes
+// without the session are cached publicly for the max age, all other responses are private unless the handler
+// sets Cache-Control. The response is buffered, so stream routes have to be excluded.
+func ConditionalGet(routes *PublicRoutes, publicMaxAge time.Duration) func(next http.Handler) http.Handler {
+	public := "public, no-cache"
+	if publicMaxAge > 0 {
+		public = fmt.Sprintf("public, max-age=%d", int(publicMaxAge.Seconds()))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buffered := &bufferedWriter{writer: w}
+			next.ServeHTTP(buffered, r)
+
+			if buffered.status != 0 && buffered.status != http.StatusOK {
+				buffered.flush()
+				return
+			}
+
+			header := w.Header()
+			header.Add("Vary", "Authorization")
+			if header.Get(response.HeaderETag) == "" {
+				sum := sha256.Sum256(buffered.body.Bytes())
+				header.Set(response.HeaderETag, fmt.Sprintf(`W/"%s"`, hex.EncodeToString(sum[:16])))
+			}
+			if header.Get(response.HeaderCacheControl) == "" {
+				if _, ok := appctx.ExtractUserSession(r.Context()); !ok && routes.contains(r) {
+					header.Set(response.HeaderCacheControl, public)
+				} else {
+					header.Set(response.HeaderCacheControl, response.CachePrivate)
+				}
+			}
+
+			if notModified(r, header.Get(response.HeaderETag), header.Get(response.HeaderLastModified)) {
+				header.Del("Content-Type")
+				header.Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			buffered.flush()
+		})
+	}
+}
+
+// notModified checks conditional headers of the request, If-None-Match takes precedence and
+// is compared weakly
+func notModified(r *http.Request, etag, lastModified string) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		etag = strings.TrimPrefix(etag, "W/")
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if lastModified == "" {
+		return false
+	}
+
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since)
+}
+
+type bufferedWriter struct {
+	writer http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) Header() http.Header {
+	return w.writer.Header()
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+}
+
+func (w *bufferedWriter) flush() {
+	if w.status != 0 {
+		w.writer.WriteHeader(w.status)
+	}
+
+	if _, err := w.writer.Write(w.body.Bytes()); err != nil {
+		log.Error().Err(err).Msg("unable to write response to the client")
+	}
+}
@@ -0,0 +1,91 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+func TestConditionalGet(t *testing.T) {
+	updated := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	public := NewPublicRoutes()
+	handler := mux.NewRouter()
+	handler.Use(ConditionalGet(public, time.Minute))
+	public.Register(handler.HandleFunc("/dao/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		response.AddLastModifiedHeader(w, updated)
+		response.SendJSON(w, http.StatusOK, &map[string]string{"id": "1"})
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dao/1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"id":"1"}`, rec.Body.String())
+	assert.Equal(t, "public, max-age=60", rec.Header().Get(response.HeaderCacheControl))
+
+	etag := rec.Header().Get(response.HeaderETag)
+	require.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+
+	req := httptest.NewRequest(http.MethodGet, "/dao/1", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get(response.HeaderETag))
+
+	// If-Modified-Since is ignored when If-None-Match is sent
+	req = httptest.NewRequest(http.MethodGet, "/dao/1", nil)
+	req.Header.Set("If-None-Match", `W/"other"`)
+	req.Header.Set("If-Modified-Since", updated.Format(http.TimeFormat))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/dao/1", nil)
+	req.Header.Set("If-Modified-Since", updated.Add(time.Second).Format(http.TimeFormat))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
+func TestConditionalGetSkipsErrors(t *testing.T) {
+	handler := ConditionalGet(NewPublicRoutes(), 0)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		response.SendError(w, http.StatusBadRequest, "wrong")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/dao/1", nil)
+	req.Header.Set("If-None-Match", "*")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, rec.Header().Get(response.HeaderETag))
+	assert.JSONEq(t, `{"message":"wrong"}`, rec.Body.String())
+}
+
+func TestConditionalGetPrivateByDefault(t *testing.T) {
+	handler := mux.NewRouter()
+	handler.Use(ConditionalGet(NewPublicRoutes(), time.Minute))
+	handler.HandleFunc("/auth/siwe/nonce", func(w http.ResponseWriter, _ *http.Request) {
+		response.AddCacheControlHeader(w, response.CacheNoStore)
+		response.SendJSON(w, http.StatusOK, &map[string]string{"nonce": "1"})
+	})
+	handler.HandleFunc("/dao/recent", func(w http.ResponseWriter, _ *http.Request) {
+		response.SendJSON(w, http.StatusOK, &[]string{})
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/auth/siwe/nonce", nil))
+	assert.Equal(t, response.CacheNoStore, rec.Header().Get(response.HeaderCacheControl))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dao/recent", nil))
+	assert.Equal(t, response.CachePrivate, rec.Header().Get(response.HeaderCacheControl))
+}
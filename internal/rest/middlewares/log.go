@@ -1,16 +1,39 @@
 package middlewares
 
 import (
+	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
 
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
 	"github.com/goverland-labs/goverland-inbox-web-api/pkg/ctxfields"
 )
 
+const (
+	tokenVar      = "token"
+	redactedValue = "redacted"
+	// redactedHashSize is the number of hex characters of the hash which identify the token in logs
+	redactedHashSize = 8
+)
+
+// credentialRoutes have refresh tokens, signatures or device ids in the body, which grant access by themselves
+var credentialRoutes = map[string]struct{}{
+	"auth_guest":        {},
+	"auth_siwe":         {},
+	"auth_siwe_upgrade": {},
+	"auth_refresh":      {},
+	"store_push_token":  {},
+}
+
 func Log(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, err := io.ReadAll(r.Body)
@@ -27,9 +50,9 @@ func Log(next http.Handler) http.Handler {
 		next.ServeHTTP(wrapped, r)
 
 		log.Info().
-			Str("url", r.URL.String()).
-			Str("session", r.Header.Get("authorization")).
-			Str("body", string(body)).
+			Str("url", redactURL(r)).
+			Str("session", redactAuthorization(r.Header.Get(AuthTokenHeader))).
+			Str("body", redactBody(r, body)).
 			Str("method", r.Method).
 			Int("status", wrapped.StatusCode).
 			Str("ip", ctxfields.ExtractRequestIP(r.Context())).
@@ -38,6 +61,52 @@ func Log(next http.Handler) http.Handler {
 	})
 }
 
+// redactURL hides secrets of feed and calendar URLs, they are used by feed readers and calendar apps
+// instead of the Authorization header
+func redactURL(r *http.Request) string {
+	u := *r.URL
+	if token := mux.Vars(r)[tokenVar]; token != "" {
+		u.Path = strings.Replace(u.Path, token, redactedValue, 1)
+		u.RawPath = ""
+	}
+
+	if query := u.Query(); query.Has(tokenVar) {
+		query.Set(tokenVar, redactedValue)
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+// redactBody hides bodies of routes with credentials
+func redactBody(r *http.Request, body []byte) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if _, ok := credentialRoutes[route.GetName()]; ok && len(body) > 0 {
+			return redactedValue
+		}
+	}
+
+	return string(body)
+}
+
+// redactAuthorization replaces personal and access tokens by the short hash, so requests of the same token
+// can be matched in logs without exposing it. Session ids are kept as is.
+func redactAuthorization(value string) string {
+	var scheme string
+	switch {
+	case auth.IsPersonalToken(value):
+		scheme = "gvt_"
+	case strings.HasPrefix(value, bearerPrefix):
+		scheme = bearerPrefix
+	default:
+		return value
+	}
+
+	sum := sha256.Sum256([]byte(value))
+
+	return scheme + hex.EncodeToString(sum[:])[:redactedHashSize]
+}
+
 type ResponseWriterWrapper struct {
 	StatusCode int
 	writer     http.ResponseWriter
@@ -59,3 +128,16 @@ func (w *ResponseWriterWrapper) WriteHeader(statusCode int) {
 	w.StatusCode = statusCode
 	w.writer.WriteHeader(statusCode)
 }
+
+// Unwrap allows http.ResponseController to reach the original writer for flushing and deadlines
+func (w *ResponseWriterWrapper) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
+func (w *ResponseWriterWrapper) Flush() {
+	_ = http.NewResponseController(w.writer).Flush()
+}
+
+func (w *ResponseWriterWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.writer).Hijack()
+}
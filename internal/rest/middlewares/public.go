@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// PublicRoutes holds read routes which responses are the same for all anonymous clients,
+// so they can be cached by browsers and CDNs
+type PublicRoutes struct {
+	routes map[*mux.Route]struct{}
+}
+
+func NewPublicRoutes() *PublicRoutes {
+	return &PublicRoutes{
+		routes: make(map[*mux.Route]struct{}),
+	}
+}
+
+// Register declares the route as public. Routes must be registered before the server is started.
+func (p *PublicRoutes) Register(route *mux.Route) *mux.Route {
+	p.routes[route] = struct{}{}
+
+	return route
+}
+
+func (p *PublicRoutes) contains(r *http.Request) bool {
+	_, ok := p.routes[mux.CurrentRoute(r)]
+
+	return ok
+}
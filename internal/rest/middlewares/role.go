@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
+)
+
+type RoleResolver interface {
+	GetRole(userID auth.UserID) (profile.Role, error)
+}
+
+// RequireRole allows the request only for users with one of the roles. It's opted in by routes or route groups.
+func RequireRole(resolver RoleResolver, roles ...profile.Role) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session, ok := appctx.ExtractUserSession(r.Context())
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			// the role of access tokens is signed on issuing, so it's trusted until the token expires
+			role, ok := appctx.ExtractUserRole(r.Context())
+			if !ok {
+				var err error
+				role, err = resolver.GetRole(session.UserID)
+				if err != nil {
+					log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("resolve user role")
+
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+			}
+
+			if !slices.Contains(roles, role) {
+				log.Warn().
+					Str("user_id", session.UserID.String()).
+					Str("role", string(role)).
+					Str("url", r.URL.String()).
+					Msg("access denied by role")
+
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
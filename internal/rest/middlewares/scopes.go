@@ -0,0 +1,58 @@
+package middlewares
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+)
+
+// RouteScopes holds scopes required by routes for requests authorized by personal tokens
+type RouteScopes struct {
+	scopes map[*mux.Route]auth.Scope
+}
+
+func NewRouteScopes() *RouteScopes {
+	return &RouteScopes{
+		scopes: make(map[*mux.Route]auth.Scope),
+	}
+}
+
+// Require declares the scope of the route. Routes must be registered before the server is started.
+func (s *RouteScopes) Require(scope auth.Scope, route *mux.Route) *mux.Route {
+	s.scopes[route] = scope
+
+	return route
+}
+
+// Scopes denies requests by personal tokens without the scope of the route.
+// Routes without declared scope are not available for personal tokens at all.
+func Scopes(routes *RouteScopes) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, byToken := appctx.ExtractTokenScopes(r.Context())
+			if !byToken {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route := mux.CurrentRoute(r)
+			required, declared := routes.scopes[route]
+			if !declared || !slices.Contains(scopes, required) {
+				log.Warn().
+					Str("route", route.GetName()).
+					Str("required", string(required)).
+					Msg("personal token scope")
+
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
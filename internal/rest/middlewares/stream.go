@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// StreamRoutes holds long-lived routes which write the response until the client disconnects
+type StreamRoutes struct {
+	routes map[*mux.Route]struct{}
+}
+
+func NewStreamRoutes() *StreamRoutes {
+	return &StreamRoutes{
+		routes: make(map[*mux.Route]struct{}),
+	}
+}
+
+// Register declares the route as the stream. Routes must be registered before the server is started.
+func (s *StreamRoutes) Register(route *mux.Route) *mux.Route {
+	s.routes[route] = struct{}{}
+
+	return route
+}
+
+// Except skips the middleware for stream routes, e.g. timeouts or middlewares which buffer the response
+func (s *StreamRoutes) Except(middleware func(next http.Handler) http.Handler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := middleware(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := s.routes[mux.CurrentRoute(r)]; ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
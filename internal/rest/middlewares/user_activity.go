@@ -18,7 +18,8 @@ func UserActivity(service UserActivityService) func(next http.Handler) http.Hand
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			session, exist := appctx.ExtractUserSession(r.Context())
-			if exist {
+			_, byToken := appctx.ExtractTokenScopes(r.Context())
+			if exist && !byToken {
 				err := service.Track(r.Context(), session)
 				if err != nil {
 					log.Error().Err(err).Msg("track user activity")
@@ -0,0 +1,114 @@
+package request
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	CursorField = "cursor"
+
+	cursorSignatureSize = 16
+	cursorSecretSize    = 32
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points to the last item of the page. Lists are ordered by created_at and id descending,
+// so the next page starts right after the item regardless of items added on top of the list.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+	// Offset is the position of the item following the cursor when it was issued, it's used as a hint
+	// for loading the next page from offset based sources
+	Offset int `json:"o"`
+}
+
+// Follows reports whether the item with the cursor goes after the anchor in the list
+func (c Cursor) Follows(anchor Cursor) bool {
+	if !c.CreatedAt.Equal(anchor.CreatedAt) {
+		return c.CreatedAt.Before(anchor.CreatedAt)
+	}
+
+	return c.ID < anchor.ID
+}
+
+// CursorCodec encodes cursors into opaque strings signed by the secret, so clients can't forge the offset hint
+type CursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec creates the codec, the random secret is generated if the secret is empty,
+// cursors issued by other instances are rejected in this case
+func NewCursorCodec(secret string) (*CursorCodec, error) {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, cursorSecretSize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generate cursor secret: %w", err)
+		}
+	}
+
+	return &CursorCodec{secret: key}, nil
+}
+
+func (c *CursorCodec) Encode(cursor Cursor) string {
+	payload, _ := json.Marshal(cursor)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+func (c *CursorCodec) Decode(value string) (Cursor, error) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, c.sign(encoded)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err = json.Unmarshal(payload, &cursor); err != nil || cursor.Offset < 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func (c *CursorCodec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+
+	return mac.Sum(nil)[:cursorSignatureSize]
+}
+
+// ExtractCursor returns the cursor from the query, nil is returned if the cursor isn't set
+func ExtractCursor(r *http.Request, codec *CursorCodec) (*Cursor, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(CursorField))
+	if value == "" {
+		return nil, nil
+	}
+
+	cursor, err := codec.Decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArguments, err)
+	}
+
+	return &cursor, nil
+}
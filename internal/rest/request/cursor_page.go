@@ -0,0 +1,153 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// cursorScanLimit limits requests to the source for locating the page after the cursor
+	cursorScanLimit = 5
+)
+
+var (
+	// ErrCursorExpired is returned if the page after the cursor isn't located within the scan limit,
+	// the client has to load the list from the first page
+	ErrCursorExpired = errors.New("cursor is expired")
+	// ErrUnorderedSource is returned if the source breaks the order the cursor relies on
+	ErrUnorderedSource = errors.New("page source isn't ordered by created_at and id descending")
+)
+
+// PageSource loads items by offset. Items must be ordered by created_at descending and by id descending
+// within the same created_at, the order is checked on each load.
+type PageSource[T any] func(offset, limit int) (items []T, total int, err error)
+
+// SliceSource serves items already loaded into memory
+func SliceSource[T any](items []T) PageSource[T] {
+	return func(offset, limit int) ([]T, int, error) {
+		if offset >= len(items) {
+			return nil, len(items), nil
+		}
+
+		return items[offset:min(offset+limit, len(items))], len(items), nil
+	}
+}
+
+type Page[T any] struct {
+	Items []T
+	// Offset is the position of the first item of the page in the list
+	Offset int
+	Total  int
+	// Next is nil if there are no more items
+	Next *Cursor
+}
+
+// LoadPage loads the page by the cursor from the offset based source. The offset stored in the cursor is
+// only the hint: the page is located by the last seen item, so items added or removed before the cursor
+// don't lead to duplicates or gaps. Without the cursor the page is loaded by the offset.
+// ErrCursorExpired is returned if more items are added or removed before the cursor than the scan covers.
+func LoadPage[T any](cursor *Cursor, offset, limit int, source PageSource[T], key func(T) Cursor) (Page[T], error) {
+	if cursor == nil {
+		items, total, err := source(offset, limit)
+		if err != nil {
+			return Page[T]{}, err
+		}
+		if err := checkOrder(items, key); err != nil {
+			return Page[T]{}, err
+		}
+
+		return newPage(items, offset, total, key), nil
+	}
+
+	// new items shift the anchor to the end of the list, so the scan starts a bit before the hint
+	start := max(cursor.Offset-limit, 0)
+	located := start == 0
+	window := 2 * limit
+
+	var (
+		items []T
+		first = -1
+		total int
+		// exhausted reports that the scan reached the end of the list
+		exhausted bool
+	)
+	for scan := 0; scan < cursorScanLimit && len(items) < limit; scan++ {
+		batch, cnt, err := source(start, window)
+		if err != nil {
+			return Page[T]{}, err
+		}
+		if err := checkOrder(batch, key); err != nil {
+			return Page[T]{}, err
+		}
+		total = cnt
+
+		// items before the anchor were removed, the window is moved back until it contains the anchor
+		if !located && len(batch) > 0 && key(batch[0]).Follows(*cursor) {
+			start = max(start-window, 0)
+			located = start == 0
+
+			continue
+		}
+		located = true
+
+		for i, item := range batch {
+			if len(items) == limit {
+				break
+			}
+
+			if !key(item).Follows(*cursor) {
+				continue
+			}
+
+			if first < 0 {
+				first = start + i
+			}
+			items = append(items, item)
+		}
+
+		if len(batch) == 0 || start+len(batch) >= total {
+			exhausted = true
+
+			break
+		}
+		start += len(batch)
+	}
+
+	if first < 0 {
+		if !exhausted {
+			return Page[T]{}, fmt.Errorf("%w: the anchor isn't found in %d items", ErrCursorExpired, cursorScanLimit*window)
+		}
+
+		first = min(cursor.Offset, total)
+	}
+
+	return newPage(items, first, total, key), nil
+}
+
+// checkOrder asserts the order of items the cursor relies on, otherwise pages would skip or repeat items silently
+func checkOrder[T any](items []T, key func(T) Cursor) error {
+	for i := 1; i < len(items); i++ {
+		if !key(items[i]).Follows(key(items[i-1])) {
+			return fmt.Errorf("%w: item %d goes before the previous one", ErrUnorderedSource, i)
+		}
+	}
+
+	return nil
+}
+
+func newPage[T any](items []T, offset, total int, key func(T) Cursor) Page[T] {
+	page := Page[T]{
+		Items:  items,
+		Offset: offset,
+		Total:  total,
+	}
+
+	next := offset + len(items)
+	if len(items) > 0 && next < total {
+		cursor := key(items[len(items)-1])
+		cursor.Offset = next
+		page.Next = &cursor
+	}
+
+	return page
+}
@@ -0,0 +1,122 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	id        string
+	createdAt time.Time
+}
+
+func testItemCursor(item testItem) Cursor {
+	return Cursor{CreatedAt: item.createdAt, ID: item.id}
+}
+
+// newTestItems returns items in descending order, the newest item has the highest number
+func newTestItems(from, to int) []testItem {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]testItem, 0, to-from+1)
+	for i := to; i >= from; i-- {
+		items = append(items, testItem{id: fmt.Sprintf("%03d", i), createdAt: base.Add(time.Duration(i) * time.Minute)})
+	}
+
+	return items
+}
+
+func ids(items []testItem) []string {
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, item.id)
+	}
+
+	return list
+}
+
+func TestCursorCodec(t *testing.T) {
+	codec, err := NewCursorCodec("secret")
+	require.NoError(t, err)
+
+	cursor := Cursor{CreatedAt: time.Date(2024, 1, 1, 10, 0, 0, 5, time.UTC), ID: "abc", Offset: 20}
+	encoded := codec.Encode(cursor)
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+	assert.Equal(t, cursor.Offset, decoded.Offset)
+
+	other, err := NewCursorCodec("other")
+	require.NoError(t, err)
+	_, err = other.Decode(encoded)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	for _, value := range []string{"", "abc", encoded + "x", "x" + encoded} {
+		_, err = codec.Decode(value)
+		assert.ErrorIs(t, err, ErrInvalidCursor, value)
+	}
+}
+
+func TestLoadPage(t *testing.T) {
+	items := newTestItems(1, 50)
+	source := SliceSource(items)
+
+	first, err := LoadPage(nil, 0, 10, source, testItemCursor)
+	require.NoError(t, err)
+	assert.Equal(t, ids(newTestItems(41, 50)), ids(first.Items))
+	require.NotNil(t, first.Next)
+	assert.Equal(t, 10, first.Next.Offset)
+
+	t.Run("stable", func(t *testing.T) {
+		page, err := LoadPage(first.Next, 0, 10, source, testItemCursor)
+		require.NoError(t, err)
+		assert.Equal(t, ids(newTestItems(31, 40)), ids(page.Items))
+		assert.Equal(t, 10, page.Offset)
+	})
+
+	t.Run("items added on top", func(t *testing.T) {
+		shifted := append(newTestItems(51, 75), items...)
+		page, err := LoadPage(first.Next, 0, 10, SliceSource(shifted), testItemCursor)
+		require.NoError(t, err)
+		assert.Equal(t, ids(newTestItems(31, 40)), ids(page.Items))
+		assert.Equal(t, 35, page.Offset)
+	})
+
+	t.Run("items removed before the cursor", func(t *testing.T) {
+		page, err := LoadPage(first.Next, 0, 10, source, testItemCursor)
+		require.NoError(t, err)
+
+		removed := append(newTestItems(31, 35), newTestItems(1, 10)...)
+		next, err := LoadPage(page.Next, 0, 10, SliceSource(removed), testItemCursor)
+		require.NoError(t, err)
+		assert.Equal(t, ids(newTestItems(1, 10)), ids(next.Items))
+		assert.Nil(t, next.Next)
+	})
+
+	t.Run("too many items added on top", func(t *testing.T) {
+		shifted := append(newTestItems(51, 200), items...)
+		_, err := LoadPage(first.Next, 0, 10, SliceSource(shifted), testItemCursor)
+		assert.ErrorIs(t, err, ErrCursorExpired)
+	})
+
+	t.Run("unordered source", func(t *testing.T) {
+		unordered := append(newTestItems(1, 5), newTestItems(6, 10)...)
+		_, err := LoadPage(nil, 0, 10, SliceSource(unordered), testItemCursor)
+		assert.ErrorIs(t, err, ErrUnorderedSource)
+
+		_, err = LoadPage(first.Next, 0, 10, SliceSource(unordered), testItemCursor)
+		assert.ErrorIs(t, err, ErrUnorderedSource)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		page, err := LoadPage(&Cursor{CreatedAt: items[44].createdAt, ID: items[44].id, Offset: 45}, 0, 10, source, testItemCursor)
+		require.NoError(t, err)
+		assert.Equal(t, ids(newTestItems(1, 5)), ids(page.Items))
+		assert.Nil(t, page.Next)
+	})
+}
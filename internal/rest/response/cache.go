@@ -0,0 +1,31 @@
+package response
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	HeaderETag         = "ETag"
+	HeaderLastModified = "Last-Modified"
+	HeaderCacheControl = "Cache-Control"
+
+	// CachePrivate is used for responses depending on the user, clients have to revalidate them on every request
+	CachePrivate = "private, no-cache"
+	// CacheNoStore is used for single-use responses like nonces, they mustn't be reused by any cache
+	CacheNoStore = "no-store"
+)
+
+// AddLastModifiedHeader sets the time of the last update of the entity, zero time is ignored. It's used only when
+// the whole body comes from the entity, otherwise changes of other parts are hidden by 304 responses.
+func AddLastModifiedHeader(w http.ResponseWriter, updatedAt time.Time) {
+	if updatedAt.IsZero() {
+		return
+	}
+
+	w.Header().Set(HeaderLastModified, updatedAt.UTC().Format(http.TimeFormat))
+}
+
+func AddCacheControlHeader(w http.ResponseWriter, value string) {
+	w.Header().Set(HeaderCacheControl, value)
+}
@@ -12,6 +12,22 @@ const (
 	UnsupportedValue  ErrCode = 11002
 	WrongFormat       ErrCode = 11003
 	UnsupportedAction ErrCode = 11004
+	CursorExpired     ErrCode = 11005
+	RoundsUnavailable ErrCode = 11006
+
+	// ---- Part of sign in errors. Each code points to the field of SIWE message which is failed ----
+
+	SiweDomainNotAllowed ErrCode = 12000
+	SiweURINotAllowed    ErrCode = 12001
+	SiweChainNotAllowed  ErrCode = 12002
+	SiweNonceInvalid     ErrCode = 12003
+	SiweIssuedAtInvalid  ErrCode = 12004
+	SiweExpired          ErrCode = 12005
+	SiweNotYetValid      ErrCode = 12006
+	SiweSignatureInvalid ErrCode = 12007
+	SiweAddressMismatch  ErrCode = 12008
+	SiweNonceAlreadyUsed ErrCode = 12009
+	SiweMessageMalformed ErrCode = 12010
 )
 
 type ErrCode uint
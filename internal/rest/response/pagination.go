@@ -7,17 +7,25 @@ import (
 )
 
 const (
-	HeaderTotalCount   = "X-Total-Count"
-	HeaderUnreadCount  = "X-Unread-Count"
-	HeaderTotalAvgVp   = "X-Total-Avg-Vp"
-	HeaderTotalVp      = "X-Total-Vp"
-	HeaderOffset       = "X-Offset"
-	HeaderLimit        = "X-Limit"
-	HeaderPrevPageLink = "X-Prev-Page"
-	HeaderNextPageLink = "X-Next-Page"
+	HeaderTotalCount     = "X-Total-Count"
+	HeaderUnreadCount    = "X-Unread-Count"
+	HeaderTotalAvgVp     = "X-Total-Avg-Vp"
+	HeaderTotalVp        = "X-Total-Vp"
+	HeaderOffset         = "X-Offset"
+	HeaderLimit          = "X-Limit"
+	HeaderPrevPageLink   = "X-Prev-Page"
+	HeaderNextPageLink   = "X-Next-Page"
+	HeaderNextCursor     = "X-Next-Cursor"
+	HeaderNextCursorLink = "X-Next-Cursor-Page"
+	// HeaderTotalTruncated is set when the total count is calculated for the part of the collection only
+	HeaderTotalTruncated = "X-Total-Truncated"
+	// HeaderFilterWindow is the number of the latest items filters are applied to
+	HeaderFilterWindow = "X-Filter-Window"
 )
 
-func AddPaginationHeaders(w http.ResponseWriter, r *http.Request, offset, limit, totalCnt int) {
+// AddPaginationHeaders sets offset based pagination headers. Routes supporting cursors pass the cursor
+// of the next page, it's sent alongside offset headers until clients migrate to cursors.
+func AddPaginationHeaders(w http.ResponseWriter, r *http.Request, offset, limit, totalCnt int, nextCursor ...string) {
 	w.Header().Set(HeaderTotalCount, fmt.Sprintf("%d", totalCnt))
 	w.Header().Set(HeaderOffset, fmt.Sprintf("%d", offset))
 	w.Header().Set(HeaderLimit, fmt.Sprintf("%d", limit))
@@ -34,6 +42,24 @@ func AddPaginationHeaders(w http.ResponseWriter, r *http.Request, offset, limit,
 
 		w.Header().Set(HeaderPrevPageLink, replaceGetParameter(r.URL, "offset", fmt.Sprintf("%d", prevOffset)).String())
 	}
+
+	if len(nextCursor) > 0 && nextCursor[0] != "" {
+		link := replaceGetParameter(r.URL, "cursor", nextCursor[0])
+		query := link.Query()
+		query.Del("offset")
+		link.RawQuery = query.Encode()
+
+		w.Header().Set(HeaderNextCursor, nextCursor[0])
+		w.Header().Set(HeaderNextCursorLink, link.String())
+	}
+}
+
+func AddTruncatedHeader(w http.ResponseWriter) {
+	w.Header().Set(HeaderTotalTruncated, "true")
+}
+
+func AddFilterWindowHeader(w http.ResponseWriter, size int) {
+	w.Header().Set(HeaderFilterWindow, fmt.Sprintf("%d", size))
 }
 
 func AddUnreadHeader(w http.ResponseWriter, count int) {
@@ -17,15 +17,28 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/bookmark"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/broadcast"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/cachepurge"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/chain"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
 	internaldao "github.com/goverland-labs/goverland-inbox-web-api/internal/dao"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/dao"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/export"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/feedstream"
 	internalproposal "github.com/goverland-labs/goverland-inbox-web-api/internal/proposal"
+	adminform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/admin"
+	feedform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/feed"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/middlewares"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/request"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/revision"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/search"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/snooze"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/tracking"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/upgrade"
 	"github.com/goverland-labs/goverland-inbox-web-api/pkg/middleware"
 )
 
@@ -46,11 +59,30 @@ type Server struct {
 	analyticsClient   internalapi.AnalyticsClient
 	userClient        inboxapi.UserClient
 	ibxProposalClient inboxapi.ProposalClient
+	delegateClient    inboxapi.DelegateClient
 
 	daoService   *internaldao.Service
 	prService    *internalproposal.Service
 	publisher    *natsclient.Publisher
 	chainService *chain.Service
+	siweVerifier *auth.SiweVerifier
+	exports      *export.Jobs
+	broadcasts   *broadcast.Jobs
+	purges       *cachepurge.Broker
+	feedBroker   *feedstream.Broker
+	streamCfg    config.FeedStream
+	cursors      *request.CursorCodec
+
+	syndicationCfg config.Syndication
+	calendarCfg    config.Calendar
+	snoozes        *snooze.Storage
+	bookmarks      *bookmark.Storage
+	upgrades       *upgrade.Worker
+	revisions      *revision.Storage
+	searchIndex    *search.Index
+	resultsCache   *internalproposal.ResultsCache[proposal.Results]
+	roundsCache    *internalproposal.ResultsCache[proposal.RunoffResults]
+	liveTallies    chan struct{}
 
 	siweTTL time.Duration
 }
@@ -71,14 +103,38 @@ func NewServer(
 	delegateClient inboxapi.DelegateClient,
 	userActivityService *tracking.UserActivityService,
 	pb *natsclient.Publisher,
-	siweTTL time.Duration,
+	cfgSiwe config.Siwe,
+	cfgStream config.FeedStream,
+	cfgSyndication config.Syndication,
+	cfgCalendar config.Calendar,
+	cfgSearch config.Search,
+	snoozes *snooze.Storage,
+	bookmarks *bookmark.Storage,
+	upgrades *upgrade.Worker,
+	revisions *revision.Storage,
+	exports *export.Jobs,
+	broadcasts *broadcast.Jobs,
+	curation *internalproposal.FeaturedStorage,
+	purges *cachepurge.Broker,
+	feedBroker *feedstream.Broker,
 ) (*Server, error) {
 	chainService, err := chain.NewService(cfgChain)
 	if err != nil {
 		return nil, fmt.Errorf("chain.NewService: %w", err)
 	}
+	siweVerifier, err := auth.NewSiweVerifier(cfgSiwe, chainService)
+	if err != nil {
+		return nil, fmt.Errorf("auth.NewSiweVerifier: %w", err)
+	}
+	cursors, err := request.NewCursorCodec(cfg.CursorSecret)
+	if err != nil {
+		return nil, fmt.Errorf("request.NewCursorCodec: %w", err)
+	}
+	if cfg.CursorSecret == "" {
+		log.Warn().Msg("cursor secret isn't set, cursors are valid only for the current instance")
+	}
 	ds := internaldao.NewService(internaldao.NewCache(), cl, authService, chainService, delegateClient)
-	ps := internalproposal.NewService(internalproposal.NewCache(), cl, ds, ibxProposalClient)
+	ps := internalproposal.NewService(internalproposal.NewCache(), cl, ds, ibxProposalClient, ibxProposalClient, curation, revisions)
 	srv := &Server{
 		authService:       authService,
 		coreclient:        cl,
@@ -90,23 +146,49 @@ func NewServer(
 		analyticsClient:   analyticsClient,
 		userClient:        userClient,
 		ibxProposalClient: ibxProposalClient,
+		delegateClient:    delegateClient,
 		daoService:        ds,
 		prService:         ps,
 		publisher:         pb,
-		siweTTL:           siweTTL,
+		siweTTL:           cfgSiwe.TTL,
 		chainService:      chainService,
+		siweVerifier:      siweVerifier,
+		exports:           exports,
+		broadcasts:        broadcasts,
+		purges:            purges,
+		feedBroker:        feedBroker,
+		streamCfg:         cfgStream,
+		cursors:           cursors,
+		syndicationCfg:    cfgSyndication,
+		calendarCfg:       cfgCalendar,
+		snoozes:           snoozes,
+		bookmarks:         bookmarks,
+		upgrades:          upgrades,
+		revisions:         revisions,
+		searchIndex:       search.NewIndex(cfgSearch.IndexSize),
+		resultsCache:      internalproposal.NewResultsCache[proposal.Results](),
+		roundsCache:       internalproposal.NewResultsCache[proposal.RunoffResults](),
+		liveTallies:       make(chan struct{}, maxLiveTallies),
 	}
+	purges.OnPurge(func(target string, ids []string) {
+		srv.purgeLocalCache(adminform.CacheTarget(target), ids)
+	})
 
+	scopes := middlewares.NewRouteScopes()
+	streams := middlewares.NewStreamRoutes()
+	public := middlewares.NewPublicRoutes()
 	handler := mux.NewRouter()
 	handler.Use(
 		middleware.Panic,
 		middleware.RequestID(),
 		middleware.RequestIP(),
-		resthelpers.Prometheus,
-		middleware.Timeout(cfg.Timeout),
+		streams.Except(resthelpers.Prometheus),
+		streams.Except(middleware.Timeout(cfg.Timeout)),
 		middlewares.Log,
 		middlewares.Auth(authService, srv.getSubscriptions),
+		middlewares.Scopes(scopes),
 		middlewares.UserActivity(userActivityService),
+		streams.Except(middlewares.ConditionalGet(public, cfg.PublicCacheMaxAge)),
 	)
 
 	srv.httpServer = &http.Server{
@@ -119,9 +201,26 @@ func NewServer(
 
 	handler.HandleFunc("/auth/guest", srv.guestAuth).Methods(http.MethodPost).Name("auth_guest")
 	handler.HandleFunc("/auth/siwe", srv.siweAuth).Methods(http.MethodPost).Name("auth_siwe")
+	handler.HandleFunc("/auth/siwe/nonce", srv.siweNonce).Methods(http.MethodGet).Name("auth_siwe_nonce")
+	handler.HandleFunc("/auth/siwe/upgrade", srv.siweUpgrade).Methods(http.MethodPost).Name("auth_siwe_upgrade")
+	handler.HandleFunc("/auth/refresh", srv.refreshAuth).Methods(http.MethodPost).Name("auth_refresh")
 	handler.HandleFunc("/logout", srv.logout).Methods(http.MethodPost).Name("auth_logout")
 	handler.HandleFunc("/me", srv.getMe).Methods(http.MethodGet).Name("auth_get_me")
 	handler.HandleFunc("/me", srv.deleteMe).Methods(http.MethodDelete).Name("auth_delete_me")
+	handler.HandleFunc("/me/export", srv.exportMe).Methods(http.MethodGet).Name("export_me")
+	scopes.Require(auth.ScopeFeedRead, handler.HandleFunc("/me/digest", srv.getDigest).Methods(http.MethodGet).Name("get_me_digest"))
+	scopes.Require(auth.ScopeFeedRead, handler.HandleFunc("/me/calendar.ics", srv.getMeCalendar).Methods(http.MethodGet).Name("get_me_calendar"))
+	handler.HandleFunc("/me/sessions", srv.listSessions).Methods(http.MethodGet).Name("get_me_sessions")
+	handler.HandleFunc("/me/sessions/revoke-others", srv.revokeOtherSessions).Methods(http.MethodPost).Name("revoke_other_sessions")
+	handler.HandleFunc("/me/sessions/{id}", srv.revokeSession).Methods(http.MethodDelete).Name("revoke_session")
+	handler.HandleFunc("/me/sessions/{id}", srv.renameSession).Methods(http.MethodPut).Name("rename_session")
+	handler.HandleFunc("/me/tokens", srv.listTokens).Methods(http.MethodGet).Name("get_me_tokens")
+	handler.HandleFunc("/me/tokens", srv.createToken).Methods(http.MethodPost).Name("create_token")
+	handler.HandleFunc("/me/tokens/{id}", srv.revokeToken).Methods(http.MethodDelete).Name("revoke_token")
+	handler.HandleFunc("/me/feed-tokens", srv.listFeedTokens).Methods(http.MethodGet).Name("get_me_feed_tokens")
+	handler.HandleFunc("/me/feed-tokens", srv.createFeedToken).Methods(http.MethodPost).Name("create_feed_token")
+	handler.HandleFunc("/me/feed-tokens/{id}", srv.revokeFeedToken).Methods(http.MethodDelete).Name("revoke_feed_token")
+	handler.HandleFunc("/me/bookmarks", srv.listBookmarks).Methods(http.MethodGet).Name("get_me_bookmarks")
 	handler.HandleFunc("/me/votes", srv.getUserVotes).Methods(http.MethodGet).Name("get_user_votes")
 	handler.HandleFunc("/me/can-vote", srv.getMeCanVote).Methods(http.MethodGet).Name("get_me_can_vote")
 	handler.HandleFunc("/me/vote-now", srv.getVoteNow).Methods(http.MethodGet).Name("get_vote_now")
@@ -129,20 +228,21 @@ func NewServer(
 	handler.HandleFunc("/user/{address}/delegates", srv.getAllDelegates).Methods(http.MethodGet).Name("get_delegates")
 	handler.HandleFunc("/user/{address}/delegators/top", srv.getTopDelegators).Methods(http.MethodGet).Name("get_top_delegates")
 	handler.HandleFunc("/user/{address}/delegators/{dao_id}/list", srv.getDelegatorsList).Methods(http.MethodGet).Name("get_delegators_list")
-	handler.HandleFunc("/user/{address}", srv.getUser).Methods(http.MethodGet).Name("get_user")
-	handler.HandleFunc("/user/{address}/votes", srv.getPublicUserVotes).Methods(http.MethodGet).Name("get_public_user_votes")
-	handler.HandleFunc("/user/{address}/participated-daos", srv.getParticipatedDaos).Methods(http.MethodGet).Name("get_participated_daos")
+	public.Register(handler.HandleFunc("/user/{address}", srv.getUser).Methods(http.MethodGet).Name("get_user"))
+	public.Register(handler.HandleFunc("/user/{address}/votes", srv.getPublicUserVotes).Methods(http.MethodGet).Name("get_public_user_votes"))
+	public.Register(handler.HandleFunc("/user/{address}/participated-daos", srv.getParticipatedDaos).Methods(http.MethodGet).Name("get_participated_daos"))
 
 	handler.HandleFunc("/tools/address-vp", srv.getAddressVotingPower).Methods(http.MethodPost).Name("get_address_voting_power")
 
 	handler.HandleFunc("/me/achievements", srv.getAchievementsList).Methods(http.MethodGet).Name("achievement_get_list")
 	handler.HandleFunc("/me/achievements/{id}/mark-as-read", srv.markAchievementItemAsViewed).Methods(http.MethodPost).Name("achievement_mark_as_viewed")
 
-	handler.HandleFunc("/dao", srv.listDAOs).Methods(http.MethodGet).Name("get_dao_list")
-	handler.HandleFunc("/dao/top", srv.listTopDAOs).Methods(http.MethodGet).Name("get_dao_top")
+	public.Register(handler.HandleFunc("/dao", srv.listDAOs).Methods(http.MethodGet).Name("get_dao_list"))
+	public.Register(handler.HandleFunc("/dao/top", srv.listTopDAOs).Methods(http.MethodGet).Name("get_dao_top"))
 	handler.HandleFunc("/dao/recent", srv.recentDao).Methods(http.MethodGet).Name("get_recent_dao")
-	handler.HandleFunc("/dao/{id}/feed", srv.getDAOFeed).Methods(http.MethodGet).Name("get_dao_feed")
-	handler.HandleFunc("/dao/{id}", srv.getDAO).Methods(http.MethodGet).Name("get_dao_item")
+	public.Register(handler.HandleFunc("/dao/{id}/calendar.ics", srv.getDAOCalendar).Methods(http.MethodGet).Name("get_dao_calendar"))
+	scopes.Require(auth.ScopeFeedRead, handler.HandleFunc("/dao/{id}/feed", srv.getDAOFeed).Methods(http.MethodGet).Name("get_dao_feed"))
+	public.Register(handler.HandleFunc("/dao/{id}", srv.getDAO).Methods(http.MethodGet).Name("get_dao_item"))
 	handler.HandleFunc("/dao/{id}/delegates", srv.getDelegates).Methods(http.MethodGet).Name("get_dao_delegates")
 	handler.HandleFunc("/dao/{id}/delegate/{address}", srv.getSpecificDelegate).Methods(http.MethodGet).Name("get_dao_delegates")
 	handler.HandleFunc("/dao/{id}/user-delegation", srv.getDelegateProfile).Methods(http.MethodGet).Name("get_dao_user_delegation")
@@ -151,32 +251,51 @@ func NewServer(
 
 	handler.HandleFunc("/chain/{id}/{tx_hash}", srv.getTxStatus).Methods(http.MethodGet).Name("get_chain_tx_status")
 
-	handler.HandleFunc("/proposals", srv.listProposals).Methods(http.MethodGet).Name("get_proposal_list")
-	handler.HandleFunc("/proposals/top", srv.proposalsTop).Methods(http.MethodGet).Name("get_proposal_top")
-	handler.HandleFunc("/proposals/{id}", srv.getProposal).Methods(http.MethodGet).Name("get_proposal_item")
-	handler.HandleFunc("/proposals/{id}/summary", srv.getProposalSummary).Methods(http.MethodGet).Name("get_proposal_summary")
-	handler.HandleFunc("/proposals/{id}/votes", srv.getProposalVotes).Methods(http.MethodGet).Name("get_proposal_votes")
+	public.Register(handler.HandleFunc("/proposals", srv.listProposals).Methods(http.MethodGet).Name("get_proposal_list"))
+	public.Register(handler.HandleFunc("/proposals/top", srv.proposalsTop).Methods(http.MethodGet).Name("get_proposal_top"))
+	public.Register(handler.HandleFunc("/proposals/{id}", srv.getProposal).Methods(http.MethodGet).Name("get_proposal_item"))
+	public.Register(handler.HandleFunc("/proposals/{id}/summary", srv.getProposalSummary).Methods(http.MethodGet).Name("get_proposal_summary"))
+	public.Register(handler.HandleFunc("/proposals/{id}/results", srv.getProposalResults).Methods(http.MethodGet).Name("get_proposal_results"))
+	public.Register(handler.HandleFunc("/proposals/{id}/results/rounds", srv.getProposalRounds).Methods(http.MethodGet).Name("get_proposal_rounds"))
+	public.Register(handler.HandleFunc("/proposals/{id}/revisions", srv.listProposalRevisions).Methods(http.MethodGet).Name("get_proposal_revisions"))
+	public.Register(handler.HandleFunc("/proposals/{id}/revisions/{from:[-0-9A-Za-z]+}..{to:[-0-9A-Za-z]+}/diff", srv.getProposalRevisionsDiff).Methods(http.MethodGet).Name("get_proposal_revisions_diff"))
+	public.Register(handler.HandleFunc("/proposals/{id}/votes", srv.getProposalVotes).Methods(http.MethodGet).Name("get_proposal_votes"))
+	handler.HandleFunc("/proposals/{id}/bookmark", srv.bookmarkProposal).Methods(http.MethodPost).Name("bookmark_proposal")
+	handler.HandleFunc("/proposals/{id}/bookmark", srv.unbookmarkProposal).Methods(http.MethodDelete).Name("unbookmark_proposal")
 	handler.HandleFunc("/proposals/{id}/vps", srv.getProposalVpList).Methods(http.MethodGet).Name("get_proposal_vps")
-	handler.HandleFunc("/proposals/{id}/votes/validate", srv.validateVote).Methods(http.MethodPost).Name("proposal_vote_validate")
-	handler.HandleFunc("/proposals/{id}/votes/prepare", srv.prepareVote).Methods(http.MethodPost).Name("proposal_vote_prepare")
+	scopes.Require(auth.ScopeVotePrepare, handler.HandleFunc("/proposals/{id}/votes/validate", srv.validateVote).Methods(http.MethodPost).Name("proposal_vote_validate"))
+	scopes.Require(auth.ScopeVotePrepare, handler.HandleFunc("/proposals/{id}/votes/prepare", srv.prepareVote).Methods(http.MethodPost).Name("proposal_vote_prepare"))
 	handler.HandleFunc("/proposals/votes", srv.vote).Methods(http.MethodPost).Name("proposal_vote")
+	scopes.Require(auth.ScopeVotePrepare, handler.HandleFunc("/proposals/votes/batch/prepare", srv.prepareVotesBatch).Methods(http.MethodPost).Name("proposal_vote_batch_prepare"))
+	handler.HandleFunc("/proposals/votes/batch", srv.voteBatch).Methods(http.MethodPost).Name("proposal_vote_batch")
 
-	handler.HandleFunc("/subscriptions", srv.listSubscriptions).Methods(http.MethodGet).Name("get_subscription_list")
-	handler.HandleFunc("/subscriptions", srv.subscribe).Methods(http.MethodPost).Name("create_subscription")
-	handler.HandleFunc("/subscriptions/{id}", srv.getSubscription).Methods(http.MethodGet).Name("get_subscription_item")
-	handler.HandleFunc("/subscriptions/{id}", srv.unsubscribe).Methods(http.MethodDelete).Name("delete_subscription")
+	scopes.Require(auth.ScopeSubscriptionsRead, handler.HandleFunc("/subscriptions", srv.listSubscriptions).Methods(http.MethodGet).Name("get_subscription_list"))
+	scopes.Require(auth.ScopeSubscriptionsWrite, handler.HandleFunc("/subscriptions", srv.subscribe).Methods(http.MethodPost).Name("create_subscription"))
+	scopes.Require(auth.ScopeSubscriptionsRead, handler.HandleFunc("/subscriptions/{id}", srv.getSubscription).Methods(http.MethodGet).Name("get_subscription_item"))
+	scopes.Require(auth.ScopeSubscriptionsWrite, handler.HandleFunc("/subscriptions/{id}", srv.unsubscribe).Methods(http.MethodDelete).Name("delete_subscription"))
 
-	handler.HandleFunc("/feed", srv.getFeed).Methods(http.MethodGet).Name("get_feed")
+	scopes.Require(auth.ScopeFeedRead, handler.HandleFunc("/feed", srv.getFeed).Methods(http.MethodGet).Name("get_feed"))
+	scopes.Require(auth.ScopeFeedRead, streams.Register(handler.HandleFunc("/feed/stream", srv.getFeedStream).Methods(http.MethodGet).Name("get_feed_stream")))
+	scopes.Require(auth.ScopeFeedRead, streams.Register(handler.HandleFunc("/feed/stream/ws", srv.getFeedStreamWS).Methods(http.MethodGet).Name("get_feed_stream_ws")))
+	scopes.Require(auth.ScopeFeedRead, handler.HandleFunc("/feed/search", srv.searchFeed).Methods(http.MethodGet).Name("search_feed"))
+	handler.HandleFunc("/feed/{token:[A-Za-z0-9_-]+}.atom", srv.getFeedAtom).Methods(http.MethodGet).Name("get_feed_atom")
+	handler.HandleFunc("/feed/{token:[A-Za-z0-9_-]+}.rss", srv.getFeedRSS).Methods(http.MethodGet).Name("get_feed_rss")
 	handler.HandleFunc("/feed/settings", srv.storeFeedSettings).Methods(http.MethodPost).Name("store_feed_settings")
-	handler.HandleFunc("/feed/settings", srv.getFeedSettings).Methods(http.MethodGet).Name("get_feed_settings")
+	scopes.Require(auth.ScopeFeedRead, handler.HandleFunc("/feed/settings", srv.getFeedSettings).Methods(http.MethodGet).Name("get_feed_settings"))
 	handler.HandleFunc("/feed/mark-as-read", srv.markAsReadBatch).Methods(http.MethodPost).Name("mark_as_read_batch")
 	handler.HandleFunc("/feed/mark-as-unread", srv.markAsUnreadBatch).Methods(http.MethodPost).Name("mark_as_unnead_batch")
+	handler.HandleFunc("/feed/archive", srv.markAsArchivedBatch).Methods(http.MethodPost).Name("mark_as_archived_batch")
+	handler.HandleFunc("/feed/unarchive", srv.markAsUnarchivedBatch).Methods(http.MethodPost).Name("mark_as_unarchived_batch")
 	handler.HandleFunc("/feed/{id}/mark-as-read", srv.markFeedItemAsRead).Methods(http.MethodPost).Name("mark_feed_item_as_read")
 	handler.HandleFunc("/feed/{id}/mark-as-unread", srv.markFeedItemAsUnread).Methods(http.MethodPost).Name("mark_feed_item_as_unread")
 	handler.HandleFunc("/feed/{id}/archive", srv.markFeedItemAsArchived).Methods(http.MethodPost).Name("mark_feed_item_as_archived")
+	handler.HandleFunc("/feed/snoozed", srv.listSnoozedFeedItems).Methods(http.MethodGet).Name("get_feed_snoozed")
+	handler.HandleFunc("/feed/{id}/snooze", srv.snoozeFeedItem).Methods(http.MethodPost).Name("snooze_feed_item")
+	handler.HandleFunc("/feed/{id}/snooze", srv.unsnoozeFeedItem).Methods(http.MethodDelete).Name("unsnooze_feed_item")
 	handler.HandleFunc("/feed/{id}/unarchive", srv.markFeedItemAsUnarchived).Methods(http.MethodPost).Name("mark_feed_item_as_archived")
 
-	handler.HandleFunc("/notifications", srv.sendCustomPush).Methods(http.MethodPost).Name("send_custom_push")
+	adminOnly := middlewares.RequireRole(authService, profile.AdminRole)
+	handler.Handle("/notifications", adminOnly(middlewares.Audit(http.HandlerFunc(srv.sendCustomPush)))).Methods(http.MethodPost).Name("send_custom_push")
 	handler.HandleFunc("/notifications/mark-as-clicked", srv.markAsClicked).Methods(http.MethodPost).Name("push_mark_as_clicked")
 	handler.HandleFunc("/notifications/settings", srv.storePushToken).Methods(http.MethodPost).Name("store_push_token")
 	handler.HandleFunc("/notifications/settings", srv.tokenExists).Methods(http.MethodGet).Name("push_token_exists")
@@ -184,6 +303,14 @@ func NewServer(
 	handler.HandleFunc("/notifications/settings/details", srv.storeSettings).Methods(http.MethodPost).Name("post_store_settings")
 	handler.HandleFunc("/notifications/settings/details", srv.getSettings).Methods(http.MethodGet).Name("get_settings_details")
 
+	adminRouter := handler.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(adminOnly, middlewares.Audit)
+	adminRouter.HandleFunc("/pushes", srv.adminSendPush).Methods(http.MethodPost).Name("admin_send_push")
+	adminRouter.HandleFunc("/pushes/{id}", srv.adminGetPush).Methods(http.MethodGet).Name("admin_get_push")
+	adminRouter.HandleFunc("/featured-proposals", srv.adminGetFeatured).Methods(http.MethodGet).Name("admin_get_featured_proposals")
+	adminRouter.HandleFunc("/featured-proposals", srv.adminSetFeatured).Methods(http.MethodPut).Name("admin_set_featured_proposals")
+	adminRouter.HandleFunc("/cache/purge", srv.adminPurgeCache).Methods(http.MethodPost).Name("admin_purge_cache")
+
 	handler.HandleFunc("/stats/totals", srv.getStatsTotals).Methods(http.MethodGet).Name("get_stats_totals")
 	handler.HandleFunc("/versions", srv.appVersions).Methods(http.MethodGet).Name("get_app_versions")
 
@@ -238,6 +365,9 @@ func configureCorsHandler(router *mux.Router) http.Handler {
 	handlerAllowedHeaders := handlers.AllowedHeaders([]string{
 		"Content-Type",
 		"Authorization",
+		feedform.LastEventIDHeader,
+		"If-None-Match",
+		"If-Modified-Since",
 	})
 	handlerExposedHeaders := handlers.ExposedHeaders([]string{
 		response.HeaderTotalCount,
@@ -246,6 +376,9 @@ func configureCorsHandler(router *mux.Router) http.Handler {
 		response.HeaderLimit,
 		response.HeaderPrevPageLink,
 		response.HeaderNextPageLink,
+		response.HeaderNextCursor,
+		response.HeaderNextCursorLink,
+		response.HeaderETag,
 	})
 	allowedOrigins := handlers.AllowedOrigins([]string{"*"})
 
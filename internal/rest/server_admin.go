@@ -0,0 +1,253 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	events "github.com/goverland-labs/goverland-platform-events/events/inbox"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/broadcast"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/admin"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	internalproposal "github.com/goverland-labs/goverland-inbox-web-api/internal/proposal"
+	forms "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/admin"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+// adminSendPush starts the background job sending the push to users or the segment and responds with the job,
+// the admin polls the job until it's finished
+func (s *Server) adminSendPush(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f, verr := forms.NewSendPushForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	job, err := s.broadcasts.Start(func(ctx context.Context) (int, int, error) {
+		return s.sendAdminPush(ctx, f)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("start admin push")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Str("job_id", job.ID.String()).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusAccepted, helpers.Ptr(convertPushJobToInternal(job)))
+}
+
+func (s *Server) adminGetPush(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		response.HandleError(response.NewNotFoundError(), w)
+		return
+	}
+
+	job, ok, err := s.broadcasts.Get(id)
+	if err != nil {
+		log.Error().Err(err).Str("job_id", id.String()).Msg("get admin push")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+	if !ok {
+		response.HandleError(response.NewNotFoundError(), w)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("job_id", id.String()).
+		Str("status", string(job.Status)).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, helpers.Ptr(convertPushJobToInternal(job)))
+}
+
+// sendAdminPush publishes the push to every recipient once, failed recipients are skipped
+func (s *Server) sendAdminPush(ctx context.Context, f *forms.SendPushForm) (int, int, error) {
+	recipients := f.UserIDs
+	if f.Segment == forms.SegmentDaoSubscribers {
+		resp, err := s.subclient.FindSubscribers(ctx, &inboxapi.FindSubscribersRequest{DaoId: f.DaoID})
+		if err != nil {
+			return 0, 0, fmt.Errorf("find dao subscribers: %s: %w", f.DaoID, err)
+		}
+
+		for _, user := range resp.GetUsers() {
+			id, err := uuid.Parse(user.GetUserId())
+			if err != nil {
+				log.Error().Err(err).Str("user_id", user.GetUserId()).Msg("parse subscriber id")
+
+				continue
+			}
+
+			recipients = append(recipients, id)
+		}
+	}
+
+	sent := 0
+	seen := make(map[uuid.UUID]struct{}, len(recipients))
+	for _, userID := range recipients {
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+
+		if err := ctx.Err(); err != nil {
+			return len(seen), sent, err
+		}
+
+		if err := s.publisher.PublishJSON(ctx, events.SubjectPushCreated, events.PushPayload{
+			Title:         f.Title,
+			Body:          f.Body,
+			UserID:        userID,
+			ImageURL:      f.ImageURL,
+			CustomPayload: f.CustomPayload,
+			Version:       events.PushVersionV2,
+		}); err != nil {
+			log.Error().Err(err).Str("user_id", userID.String()).Msg("publish push message")
+
+			continue
+		}
+
+		sent++
+	}
+
+	return len(seen), sent, nil
+}
+
+func (s *Server) adminGetFeatured(w http.ResponseWriter, r *http.Request) {
+	curation, err := s.prService.GetFeaturedCuration()
+	if err != nil {
+		log.Error().Err(err).Msg("get featured curation")
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	s.sendFeatured(w, r, curation)
+}
+
+func (s *Server) adminSetFeatured(w http.ResponseWriter, r *http.Request) {
+	f, verr := forms.NewFeaturedForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	curation := internalproposal.FeaturedCuration{
+		Pinned: f.Pinned,
+		Hidden: f.Hidden,
+	}
+	if err := s.prService.SetFeaturedCuration(curation); err != nil {
+		log.Error().Err(err).Msg("set featured curation")
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	s.sendFeatured(w, r, curation)
+}
+
+func (s *Server) sendFeatured(w http.ResponseWriter, r *http.Request, curation internalproposal.FeaturedCuration) {
+	resolved, err := s.prService.GetFeaturedIDs(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("get featured proposals")
+
+		response.HandleError(response.ResolveError(err), w)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Int("pinned", len(curation.Pinned)).
+		Int("hidden", len(curation.Hidden)).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &admin.FeaturedProposals{
+		Pinned:   nonNil(curation.Pinned),
+		Hidden:   nonNil(curation.Hidden),
+		Resolved: nonNil(resolved),
+	})
+}
+
+func (s *Server) adminPurgeCache(w http.ResponseWriter, r *http.Request) {
+	f, verr := forms.NewPurgeCacheForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	// counters are reported for the current instance, other instances purge their caches by the broadcast
+	purged := s.purgeLocalCache(f.Target, f.IDs)
+	if err := s.purges.Publish(string(f.Target), f.IDs); err != nil {
+		log.Error().Err(err).Msg("broadcast cache purge")
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("target", string(f.Target)).
+		Int("dao", purged.Dao).
+		Int("proposal", purged.Proposal).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &purged)
+}
+
+func (s *Server) purgeLocalCache(target forms.CacheTarget, ids []string) admin.PurgedCache {
+	var purged admin.PurgedCache
+	if target == forms.CacheTargetAll || target == forms.CacheTargetDao {
+		purged.Dao = s.daoService.PurgeCache(ids...)
+	}
+	if target == forms.CacheTargetAll || target == forms.CacheTargetProposal {
+		purged.Proposal = s.prService.PurgeCache(ids...)
+	}
+
+	return purged
+}
+
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+
+	return list
+}
+
+func convertPushJobToInternal(job broadcast.Job) admin.PushJob {
+	var finishedAt *common.Time
+	if job.FinishedAt != nil {
+		finishedAt = common.NewTime(*job.FinishedAt)
+	}
+
+	return admin.PushJob{
+		ID:         job.ID,
+		Status:     string(job.Status),
+		Recipients: job.Recipients,
+		Sent:       job.Sent,
+		CreatedAt:  *common.NewTime(job.CreatedAt),
+		FinishedAt: finishedAt,
+	}
+}
@@ -2,10 +2,10 @@ package rest
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gorilla/mux"
 	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
 	"github.com/rs/zerolog/log"
@@ -14,6 +14,7 @@ import (
 
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
 	authsrv "github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	authentity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/auth"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/auth"
@@ -60,36 +61,56 @@ func (s *Server) siweAuth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	siweMessage, err := siwe.ParseMessage(f.Message)
-	if err != nil {
-		log.Error().Err(err).Msg("siwe parse message")
-
-		response.SendError(w, http.StatusBadRequest, "invalid siwe message")
+	if !s.verifySiweProof(w, r, f) {
 		return
 	}
 
-	pubKey, err := siweMessage.Verify(f.Signature, nil, nil, nil)
+	regularInfo, err := s.authService.Regular(r.Context(), authsrv.RegularSessionRequest{
+		SessionRequest: authsrv.SessionRequest{
+			DeviceID:    f.DeviceID,
+			DeviceName:  f.DeviceName,
+			AppPlatform: f.AppPlatform,
+			AppVersion:  f.AppVersion,
+		},
+		Address: f.Address.Hex(),
+	})
 	if err != nil {
-		log.Error().Err(err).Msg("siwe verify")
+		log.Error().Err(err).Msg("guest session")
 
-		response.SendError(w, http.StatusBadRequest, "invalid siwe signature or expired")
+		response.SendEmpty(w, http.StatusInternalServerError)
 		return
 	}
 
-	issuedAtStr := siweMessage.GetIssuedAt()
-	issuedAt, err := time.Parse(time.RFC3339, issuedAtStr)
+	s.getSubscriptions(regularInfo.Session.UserID)
+	s.enrichProfileInfo(regularInfo.Session, &regularInfo.AuthInfo.Profile)
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", regularInfo.Session.UserID.String()).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &regularInfo.AuthInfo)
+}
+
+// verifySiweProof checks the SIWE message with signature and marks the nonce as used.
+// The error response is already sent if the proof is not valid.
+func (s *Server) verifySiweProof(w http.ResponseWriter, r *http.Request, f *auth.SiweAuthForm) bool {
+	siweMessage, err := siwe.ParseMessage(f.Message)
 	if err != nil {
-		log.Error().Err(err).Msg("siwe parse issued at")
+		log.Error().Err(err).Msg("siwe parse message")
 
-		response.SendError(w, http.StatusBadRequest, "invalid siwe issued at")
-		return
+		ve := response.NewValidationError()
+		ve.SetError("message", response.SiweMessageMalformed, "invalid siwe message")
+		response.HandleError(ve, w)
+		return false
 	}
 
-	if time.Since(issuedAt) > s.siweTTL {
-		log.Error().Err(err).Msg("siwe issued at is expired")
+	issuedAt, err := s.siweVerifier.Verify(r.Context(), siweMessage, f.Signature, f.Address)
+	if err != nil {
+		log.Error().Err(err).Msg("siwe verify")
 
-		response.SendError(w, http.StatusBadRequest, "siwe issued at is expired")
-		return
+		response.HandleError(response.ResolveError(err, siweResponseErrors), w)
+		return false
 	}
 
 	useNonceResp, err := s.userClient.UseAuthNonce(r.Context(), &inboxapi.UseAuthNonceRequest{
@@ -101,49 +122,70 @@ func (s *Server) siweAuth(w http.ResponseWriter, r *http.Request) {
 		log.Error().Err(err).Msg("use nonce")
 
 		response.SendError(w, http.StatusInternalServerError, "failed to use nonce")
-		return
+		return false
 	}
 	if !useNonceResp.GetValid() {
-		log.Error().Err(err).Msg("nonce is not valid")
+		log.Error().Msg("nonce is not valid")
 
-		response.SendError(w, http.StatusBadRequest, "nonce is not valid")
-		return
+		ve := response.NewValidationError()
+		ve.SetError("message.nonce", response.SiweNonceAlreadyUsed, "nonce is already used")
+		response.HandleError(ve, w)
+		return false
 	}
 
-	encodedAddress := crypto.PubkeyToAddress(*pubKey)
-
-	if encodedAddress != f.Address {
-		log.Error().Err(err).Msg("address is not related to sign")
+	return true
+}
 
-		response.SendError(w, http.StatusBadRequest, "address is not related to sign")
+// refreshAuth issues new access and refresh tokens. The refresh token is rotated on each call.
+func (s *Server) refreshAuth(w http.ResponseWriter, r *http.Request) {
+	f, verr := auth.NewRefreshForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
 		return
 	}
 
-	regularInfo, err := s.authService.Regular(r.Context(), authsrv.RegularSessionRequest{
-		SessionRequest: authsrv.SessionRequest{
-			DeviceID:    f.DeviceID,
-			DeviceName:  f.DeviceName,
-			AppPlatform: f.AppPlatform,
-			AppVersion:  f.AppVersion,
-		},
-		Address: f.Address.Hex(),
-	})
-	if err != nil {
-		log.Error().Err(err).Msg("guest session")
+	tokens, err := s.authService.Refresh(r.Context(), f.RefreshToken)
+	switch {
+	case errors.Is(err, authsrv.ErrAccessTokensDisabled):
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.UnsupportedAction, "access tokens are disabled")
+		response.HandleError(ve, w)
+
+		return
+	case errors.Is(err, authsrv.ErrInvalidAccessToken):
+		log.Warn().Err(err).Msg("refresh access token")
+		response.SendEmpty(w, http.StatusUnauthorized)
 
+		return
+	case err != nil:
+		log.Error().Err(err).Msg("refresh access token")
 		response.SendEmpty(w, http.StatusInternalServerError)
+
 		return
 	}
 
-	s.getSubscriptions(regularInfo.Session.UserID)
-	s.enrichProfileInfo(regularInfo.Session, &regularInfo.AuthInfo.Profile)
-
 	log.Info().
 		Str("route", mux.CurrentRoute(r).GetName()).
-		Str("user_id", regularInfo.Session.UserID.String()).
 		Msg("route execution")
 
-	response.SendJSON(w, http.StatusOK, &regularInfo.AuthInfo)
+	response.SendJSON(w, http.StatusOK, &tokens)
+}
+
+func (s *Server) siweNonce(w http.ResponseWriter, _ *http.Request) {
+	nonce, err := s.siweVerifier.IssueNonce()
+	if err != nil {
+		log.Error().Err(err).Msg("issue siwe nonce")
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	// the nonce is single-use, so caches mustn't give the same one to several clients
+	response.AddCacheControlHeader(w, response.CacheNoStore)
+	response.SendJSON(w, http.StatusOK, &authentity.SiweNonce{
+		Nonce:     nonce.Value,
+		ExpiresAt: *common.NewTime(nonce.ExpiresAt),
+	})
 }
 
 func (s *Server) logout(w http.ResponseWriter, r *http.Request) {
@@ -197,6 +239,19 @@ func (s *Server) getMe(w http.ResponseWriter, r *http.Request) {
 	response.SendJSON(w, http.StatusOK, &profileInfo)
 }
 
+// deleteStoredState removes the state kept by the service itself, the user is already deleted, so errors are logged only
+func (s *Server) deleteStoredState(userID authsrv.UserID) {
+	if err := s.exports.Delete(userID); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("delete export")
+	}
+	if err := s.snoozes.DeleteUser(userID); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("delete snoozed items")
+	}
+	if err := s.bookmarks.DeleteUser(userID); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("delete bookmarks")
+	}
+}
+
 func (s *Server) deleteMe(w http.ResponseWriter, r *http.Request) {
 	session, ok := appctx.ExtractUserSession(r.Context())
 	if !ok {
@@ -213,6 +268,8 @@ func (s *Server) deleteMe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.deleteStoredState(session.UserID)
+
 	log.Info().
 		Str("route", mux.CurrentRoute(r).GetName()).
 		Str("user_id", session.UserID.String()).
@@ -228,6 +285,7 @@ func (s *Server) enrichProfileInfo(session authsrv.Session, p *profile.Profile)
 		lastSession := &p.LastSessions[i]
 		if lastSession.ID == session.ID.String() {
 			lastSession.LastActivityAt = common.NewTime(time.Now())
+			lastSession.Current = true
 		}
 	}
 }
@@ -0,0 +1,27 @@
+package rest
+
+import (
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+var siweResponseErrors = map[error]func(err error) response.Error{
+	auth.ErrSiweDomain:      siweValidationError("message.domain", response.SiweDomainNotAllowed, "domain is not allowed"),
+	auth.ErrSiweURI:         siweValidationError("message.uri", response.SiweURINotAllowed, "uri is not allowed"),
+	auth.ErrSiweChainID:     siweValidationError("message.chain_id", response.SiweChainNotAllowed, "chain id is not allowed"),
+	auth.ErrSiweNonce:       siweValidationError("message.nonce", response.SiweNonceInvalid, "nonce is not valid or expired"),
+	auth.ErrSiweIssuedAt:    siweValidationError("message.issued_at", response.SiweIssuedAtInvalid, "invalid issued at"),
+	auth.ErrSiweExpired:     siweValidationError("message.expiration_time", response.SiweExpired, "message is expired"),
+	auth.ErrSiweNotYetValid: siweValidationError("message.not_before", response.SiweNotYetValid, "message is not yet valid"),
+	auth.ErrSiweSignature:   siweValidationError("signature", response.SiweSignatureInvalid, "invalid signature"),
+	auth.ErrSiweAddress:     siweValidationError("address", response.SiweAddressMismatch, "address is not related to sign"),
+}
+
+func siweValidationError(key string, code response.ErrCode, message string) func(err error) response.Error {
+	return func(_ error) response.Error {
+		ve := response.NewValidationError()
+		ve.SetError(key, code, message)
+
+		return ve
+	}
+}
@@ -0,0 +1,358 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	authsrv "github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/upgrade"
+)
+
+const (
+	upgradePageSize = 100
+	// upgradeFeedLimit limits the number of guest feed items which state is moved to the regular account
+	upgradeFeedLimit = 1000
+)
+
+// siweUpgrade converts the guest account of the current session to the regular one. If the wallet user already
+// exists, the guest state is merged into it: the regular account settings have priority over the guest ones.
+// If the migration fails, nothing is returned and the guest session stays valid for another attempt.
+// The migration isn't transactional: the part moved by the failed attempt is merged again on the next one.
+func (s *Server) siweUpgrade(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	guestInfo, err := s.authService.GetProfileInfo(session.UserID)
+	if err != nil {
+		log.Error().Err(err).Msg("get profile info")
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	if guestInfo.Role != profile.GuestRole {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.UnsupportedAction, "only guest account can be upgraded")
+		response.HandleError(ve, w)
+		return
+	}
+
+	f, verr := auth.NewSiweAuthForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	if !s.verifySiweProof(w, r, f) {
+		return
+	}
+
+	regularInfo, err := s.authService.Regular(r.Context(), authsrv.RegularSessionRequest{
+		SessionRequest: authsrv.SessionRequest{
+			DeviceID:    f.DeviceID,
+			DeviceName:  f.DeviceName,
+			AppPlatform: f.AppPlatform,
+			AppVersion:  f.AppVersion,
+		},
+		Address:        f.Address.Hex(),
+		GuestSessionID: &session.ID,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("regular session")
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	guestID, regularID := session.UserID, regularInfo.Session.UserID
+	if guestID != regularID {
+		// the guest account is kept on failure, so the client is able to retry the upgrade with the same session.
+		// The regular session isn't returned, so it's revoked.
+		if err = s.migrateGuest(r.Context(), guestID, regularID); err != nil {
+			log.Error().Err(err).Str("guest_id", guestID.String()).Msg("migrate guest")
+
+			if err := s.authService.Logout(regularInfo.Session.ID); err != nil {
+				log.Error().Err(err).Str("user_id", regularID.String()).Msg("revoke regular session")
+			}
+
+			response.SendEmpty(w, http.StatusInternalServerError)
+			return
+		}
+
+		if err = s.authService.DeleteUser(guestID); err != nil {
+			log.Error().Err(err).Str("guest_id", guestID.String()).Msg("delete migrated guest")
+		}
+		s.deleteStoredState(guestID)
+	}
+
+	s.getSubscriptions(regularID)
+	s.enrichProfileInfo(regularInfo.Session, &regularInfo.AuthInfo.Profile)
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", regularID.String()).
+		Str("guest_id", guestID.String()).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &regularInfo.AuthInfo)
+}
+
+func (s *Server) migrateGuest(ctx context.Context, guestID, regularID authsrv.UserID) error {
+	// subscriptions go first: feed items of the regular user are created by them
+	if err := s.migrateSubscriptions(ctx, guestID, regularID); err != nil {
+		return fmt.Errorf("subscriptions: %w", err)
+	}
+
+	return errors.Join(
+		s.migrateFeedSettings(ctx, guestID, regularID),
+		s.migratePushSettings(ctx, guestID, regularID),
+		s.scheduleFeedState(ctx, guestID, regularID),
+		s.migrateStoredState(guestID, regularID),
+	)
+}
+
+// migrateStoredState moves the guest state kept by the service itself
+func (s *Server) migrateStoredState(guestID, regularID authsrv.UserID) error {
+	if err := s.bookmarks.Move(guestID, regularID); err != nil {
+		return fmt.Errorf("move bookmarks: %w", err)
+	}
+
+	if err := s.exports.Move(guestID, regularID); err != nil {
+		return fmt.Errorf("move export: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) migrateSubscriptions(ctx context.Context, guestID, regularID authsrv.UserID) error {
+	guestDaos, err := s.listSubscribedDaos(ctx, guestID)
+	if err != nil {
+		return fmt.Errorf("list guest subscriptions: %w", err)
+	}
+
+	regularDaos, err := s.listSubscribedDaos(ctx, regularID)
+	if err != nil {
+		return fmt.Errorf("list regular subscriptions: %w", err)
+	}
+
+	for _, daoID := range guestDaos {
+		if slices.Contains(regularDaos, daoID) {
+			continue
+		}
+
+		_, err := s.subclient.Subscribe(ctx, &inboxapi.SubscribeRequest{
+			SubscriberId: regularID.String(),
+			DaoId:        daoID,
+		})
+		if err != nil {
+			return fmt.Errorf("subscribe %s: %w", daoID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) listSubscribedDaos(ctx context.Context, userID authsrv.UserID) ([]string, error) {
+	var daos []string
+	for offset := 0; ; offset += upgradePageSize {
+		resp, err := s.subclient.ListSubscriptions(ctx, &inboxapi.ListSubscriptionRequest{
+			SubscriberId: userID.String(),
+			Limit:        helpers.Ptr(uint64(upgradePageSize)),
+			Offset:       helpers.Ptr(uint64(offset)),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range resp.GetItems() {
+			daos = append(daos, info.GetDaoId())
+		}
+
+		if offset+upgradePageSize >= int(resp.GetTotalCount()) {
+			return daos, nil
+		}
+	}
+}
+
+func (s *Server) migrateFeedSettings(ctx context.Context, guestID, regularID authsrv.UserID) error {
+	guest, err := s.settings.GetFeedSettings(ctx, &inboxapi.GetFeedSettingsRequest{UserId: guestID.String()})
+	if err != nil {
+		return fmt.Errorf("get guest feed settings: %w", err)
+	}
+
+	regular, err := s.settings.GetFeedSettings(ctx, &inboxapi.GetFeedSettingsRequest{UserId: regularID.String()})
+	if err != nil {
+		return fmt.Errorf("get regular feed settings: %w", err)
+	}
+
+	merged := &inboxapi.FeedSettings{
+		ArchiveProposalAfterVote: mergeOptional(
+			regular.GetFeedSettings().ArchiveProposalAfterVote,
+			guest.GetFeedSettings().ArchiveProposalAfterVote,
+		),
+		AutoarchiveAfterDuration: mergeOptional(
+			regular.GetFeedSettings().AutoarchiveAfterDuration,
+			guest.GetFeedSettings().AutoarchiveAfterDuration,
+		),
+	}
+
+	_, err = s.settings.SetFeedSettings(ctx, &inboxapi.SetFeedSettingsRequest{
+		UserId:       regularID.String(),
+		FeedSettings: merged,
+	})
+	if err != nil {
+		return fmt.Errorf("set feed settings: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) migratePushSettings(ctx context.Context, guestID, regularID authsrv.UserID) error {
+	guest, err := s.settings.GetPushDetails(ctx, &inboxapi.GetPushDetailsRequest{UserId: guestID.String()})
+	if err != nil {
+		return fmt.Errorf("get guest push details: %w", err)
+	}
+
+	regular, err := s.settings.GetPushDetails(ctx, &inboxapi.GetPushDetailsRequest{UserId: regularID.String()})
+	if err != nil {
+		return fmt.Errorf("get regular push details: %w", err)
+	}
+
+	guestDao, regularDao := guest.GetDao(), regular.GetDao()
+	_, err = s.settings.SetPushDetails(ctx, &inboxapi.SetPushDetailsRequest{
+		UserId: regularID.String(),
+		Dao: &inboxapi.PushSettingsDao{
+			NewProposalCreated: mergeOptional(regularDao.NewProposalCreated, guestDao.NewProposalCreated),
+			QuorumReached:      mergeOptional(regularDao.QuorumReached, guestDao.QuorumReached),
+			VoteFinishesSoon:   mergeOptional(regularDao.VoteFinishesSoon, guestDao.VoteFinishesSoon),
+			VoteFinished:       mergeOptional(regularDao.VoteFinished, guestDao.VoteFinished),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("set push details: %w", err)
+	}
+
+	tokens, err := s.settings.GetPushTokenList(ctx, &inboxapi.GetPushTokenListRequest{UserId: guestID.String()})
+	if err != nil {
+		return fmt.Errorf("get guest push tokens: %w", err)
+	}
+
+	for _, token := range tokens.GetTokens() {
+		_, err := s.settings.AddPushToken(ctx, &inboxapi.AddPushTokenRequest{
+			UserId:     regularID.String(),
+			Token:      token.GetToken(),
+			DeviceUuid: token.GetDeviceUuid(),
+		})
+		if err != nil {
+			return fmt.Errorf("add push token for device %s: %w", token.GetDeviceUuid(), err)
+		}
+	}
+
+	return nil
+}
+
+// scheduleFeedState moves read, archived and snoozed marks of the guest feed. Feed items of the regular account
+// are created asynchronously after subscribing, so the state is applied by the upgrade worker when they appear.
+func (s *Server) scheduleFeedState(ctx context.Context, guestID, regularID authsrv.UserID) error {
+	read, err := s.listFeedItems(ctx, guestID, inboxapi.GetUserFeedRequest_ExcludeOther, inboxapi.GetUserFeedRequest_Include, upgradeFeedLimit)
+	if err != nil {
+		return fmt.Errorf("list guest read items: %w", err)
+	}
+
+	archived, err := s.listFeedItems(ctx, guestID, inboxapi.GetUserFeedRequest_Include, inboxapi.GetUserFeedRequest_ExcludeOther, upgradeFeedLimit)
+	if err != nil {
+		return fmt.Errorf("list guest archived items: %w", err)
+	}
+
+	snoozed, err := s.snoozes.List(guestID)
+	if err != nil {
+		return fmt.Errorf("list guest snoozed items: %w", err)
+	}
+
+	state := upgrade.State{CreatedAt: time.Now()}
+	subjects := make(map[string]string, len(read)+len(archived))
+	for _, item := range read {
+		state.Read = append(state.Read, upgrade.Subject(item))
+		subjects[item.GetId()] = upgrade.Subject(item)
+	}
+	for _, item := range archived {
+		state.Archived = append(state.Archived, upgrade.Subject(item))
+		subjects[item.GetId()] = upgrade.Subject(item)
+	}
+
+	// snoozed items are archived, so their subjects are known
+	for _, item := range snoozed {
+		subject, ok := subjects[item.FeedItemID]
+		if !ok {
+			continue
+		}
+
+		state.Snoozed = append(state.Snoozed, upgrade.Snooze{
+			Subject:    subject,
+			ProposalID: item.ProposalID,
+			Title:      item.Title,
+			Until:      item.Until,
+			Remind:     item.Remind,
+			CreatedAt:  item.CreatedAt,
+		})
+	}
+
+	return s.upgrades.Schedule(ctx, regularID, state)
+}
+
+func (s *Server) listFeedItems(ctx context.Context, userID authsrv.UserID, readState, archivedState inboxapi.GetUserFeedRequest_State, limit int) ([]*inboxapi.FeedItem, error) {
+	items, _, err := s.scanFeedItems(ctx, userID, readState, archivedState, 0, limit)
+
+	return items, err
+}
+
+// scanFeedItems loads up to the limit of items starting from the offset, it reports whether the feed has more items
+func (s *Server) scanFeedItems(ctx context.Context, userID authsrv.UserID, readState, archivedState inboxapi.GetUserFeedRequest_State, offset, limit int) ([]*inboxapi.FeedItem, bool, error) {
+	var items []*inboxapi.FeedItem
+	for loaded := 0; loaded < limit; loaded += upgradePageSize {
+		size := min(upgradePageSize, limit-loaded)
+		resp, err := s.feedClient.GetUserFeed(ctx, &inboxapi.GetUserFeedRequest{
+			SubscriberId:  userID.String(),
+			ReadState:     readState,
+			ArchivedState: archivedState,
+			Limit:         uint32(size),
+			Offset:        uint32(offset + loaded),
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		items = append(items, resp.GetList()...)
+		if offset+loaded+size >= int(resp.GetTotalCount()) || len(resp.GetList()) == 0 {
+			return items, false, nil
+		}
+	}
+
+	return items, true, nil
+}
+
+// mergeOptional keeps the value of the target account if it's set
+func mergeOptional[T any](target, source *T) *T {
+	if target != nil {
+		return target
+	}
+
+	return source
+}
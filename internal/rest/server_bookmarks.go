@@ -0,0 +1,173 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/bookmark"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	resthelpers "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/request"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+func (s *Server) bookmarkProposal(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if _, err := s.prService.GetByID(r.Context(), id); err != nil {
+		if errors.Is(err, coresdk.ErrNotFound) {
+			response.SendEmpty(w, http.StatusNotFound)
+			return
+		}
+
+		log.Error().Err(err).Msgf("get proposal by id: %s", id)
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	_, err := s.bookmarks.Add(session.UserID, id)
+	if errors.Is(err, bookmark.ErrLimit) {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.WrongValue, err.Error())
+		response.HandleError(ve, w)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("proposal_id", id).Msg("add bookmark")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("proposal_id", id).
+		Msg("route execution")
+
+	response.SendEmpty(w, http.StatusNoContent)
+}
+
+func (s *Server) unbookmarkProposal(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	err := s.bookmarks.Remove(session.UserID, id)
+	if errors.Is(err, bookmark.ErrNotFound) {
+		response.HandleError(response.NewNotFoundError(), w)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("proposal_id", id).Msg("remove bookmark")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("proposal_id", id).
+		Msg("route execution")
+
+	response.SendEmpty(w, http.StatusNoContent)
+}
+
+// listBookmarks returns bookmarked proposals, the last bookmarked proposals go first
+func (s *Server) listBookmarks(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bf, verr := resthelpers.NewBodyFormat().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	offset, limit, err := request.ExtractPagination(r)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bookmarks, err := s.bookmarks.List(session.UserID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("list bookmarks")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	total := len(bookmarks)
+	bookmarks = bookmarks[min(offset, total):min(offset+limit, total)]
+
+	list := make([]proposal.Proposal, 0, len(bookmarks))
+	if len(bookmarks) > 0 {
+		ids := make([]string, 0, len(bookmarks))
+		for _, info := range bookmarks {
+			ids = append(ids, info.ProposalID)
+		}
+
+		proposals, err := s.fetchProposalsByIds(r.Context(), ids)
+		if err != nil {
+			response.HandleError(response.ResolveError(err), w)
+			return
+		}
+
+		for _, id := range ids {
+			pr, ok := proposals[id]
+			if !ok {
+				log.Warn().Str("proposal_id", id).Msg("bookmarked proposal not found")
+
+				continue
+			}
+
+			list = append(list, *pr)
+		}
+	}
+
+	list = enrichProposalsSubscriptionInfo(session, list)
+	list = s.enrichProposalsVotesInfo(r.Context(), session, list)
+	list = s.enrichProposalsBookmarkInfo(session, list)
+	list = helpers.WrapProposalsIpfsLinks(list)
+	list = helpers.RenderProposalsBody(list, bf.Formats)
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Int("count", len(list)).
+		Int("total", total).
+		Msg("route execution")
+
+	response.AddPaginationHeaders(w, r, offset, limit, total)
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+func convertBookmarksToInternal(list []bookmark.Bookmark) []proposal.Bookmark {
+	result := make([]proposal.Bookmark, 0, len(list))
+	for _, info := range list {
+		result = append(result, proposal.Bookmark{
+			ProposalID: info.ProposalID,
+			CreatedAt:  *common.NewTime(info.CreatedAt),
+		})
+	}
+
+	return result
+}
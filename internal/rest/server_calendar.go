@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	authsrv "github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/calendar"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/dao"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	daoform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/dao"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const (
+	calendarContentType = "text/calendar; charset=utf-8"
+	calendarTokenParam  = "token"
+)
+
+// getMeCalendar returns voting windows of active and upcoming proposals in subscribed daos.
+// Calendar apps can't send the Authorization header, so the secret feed token is accepted in the query.
+func (s *Server) getMeCalendar(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		token := r.URL.Query().Get(calendarTokenParam)
+		if token == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var err error
+		session, err = s.authService.GetFeedTokenSession(token)
+		if errors.Is(err, authsrv.ErrTokenNotFound) {
+			response.HandleError(response.NewNotFoundError(), w)
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("get feed token session")
+			response.SendEmpty(w, http.StatusInternalServerError)
+			return
+		}
+
+		s.getSubscriptions(session.UserID)
+	}
+
+	// the calendar of the user mustn't be cached publicly when it's requested by the token
+	response.AddCacheControlHeader(w, response.CachePrivate)
+
+	daoIDs := make([]string, 0)
+	for _, sub := range subscriptionsStorage.get(session.UserID) {
+		if sub.DAO != nil {
+			daoIDs = append(daoIDs, sub.DAO.ID.String())
+		}
+	}
+
+	var proposals []proposal.Proposal
+	if len(daoIDs) > 0 {
+		daos, err := s.daoService.GetDaoByIDs(r.Context(), daoIDs...)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("get subscribed daos for calendar")
+
+			response.HandleError(response.NewInternalError(), w)
+			return
+		}
+
+		// the calendar is better without some daos than failed, so errors are logged only
+		for _, di := range daos {
+			list, err := s.calendarProposals(r.Context(), di)
+			if err != nil {
+				log.Warn().Err(err).Str("dao_id", di.ID.String()).Msg("get dao proposals for calendar")
+
+				continue
+			}
+
+			proposals = append(proposals, list...)
+		}
+	}
+
+	s.sendCalendar(w, r, "Goverland votes", proposals)
+}
+
+// getDAOCalendar returns voting windows of active and upcoming proposals of the dao
+func (s *Server) getDAOCalendar(w http.ResponseWriter, r *http.Request) {
+	f, verr := daoform.NewGetItemForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	di, err := s.daoService.GetDao(r.Context(), f.ID)
+	if errors.Is(err, coresdk.ErrNotFound) {
+		response.SendEmpty(w, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("get dao by id: %s", f.ID)
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	proposals, err := s.calendarProposals(r.Context(), di)
+	if err != nil {
+		log.Error().Err(err).Str("dao_id", di.ID.String()).Msg("get dao proposals for calendar")
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	s.sendCalendar(w, r, fmt.Sprintf("%s votes", di.Name), proposals)
+}
+
+// calendarProposals returns the last proposals of the dao, voting windows are selected by the calendar package
+func (s *Server) calendarProposals(ctx context.Context, di *dao.DAO) ([]proposal.Proposal, error) {
+	resp, err := s.coreclient.GetProposalList(ctx, coresdk.GetProposalListRequest{
+		Dao:   di.ID.String(),
+		Limit: s.calendarCfg.DaoProposalsLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get proposal list: %w", err)
+	}
+
+	list := make([]proposal.Proposal, 0, len(resp.Items))
+	for _, info := range resp.Items {
+		list = append(list, convertProposalToInternal(&info, di))
+	}
+
+	return list, nil
+}
+
+func (s *Server) sendCalendar(w http.ResponseWriter, r *http.Request, name string, proposals []proposal.Proposal) {
+	link := strings.TrimRight(s.syndicationCfg.AppURL, "/") + "/proposals/%s"
+	events := calendar.ProposalEvents(proposals, time.Now(), link, s.calendarCfg.Alarms)
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Int("count", len(events)).
+		Msg("route execution")
+
+	w.Header().Set("Content-Type", calendarContentType)
+	w.Header().Set("Content-Disposition", `inline; filename="calendar.ics"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(calendar.Render(calendar.Calendar{
+		Name:            name,
+		RefreshInterval: s.calendarCfg.RefreshInterval,
+		Events:          events,
+	}))
+}
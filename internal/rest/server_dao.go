@@ -24,6 +24,7 @@ import (
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	resthelpers "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/common"
 	daoform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/dao"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/request"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
@@ -67,6 +68,7 @@ func (s *Server) getDAO(w http.ResponseWriter, r *http.Request) {
 	item.SubscriptionInfo = getSubscription(session, item.ID)
 	item = helpers.WrapDAOIpfsLinks(item)
 
+	// Last-Modified isn't sent, because the subscription of the user isn't covered by the update time of the dao
 	response.SendJSON(w, http.StatusOK, item)
 }
 
@@ -147,10 +149,33 @@ func (s *Server) getDAOFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := s.coreclient.GetDaoFeed(r.Context(), f.ID, coresdk.GetDaoFeedRequest{
-		Offset: f.Offset,
-		Limit:  f.Limit,
-	})
+	bf, verr := resthelpers.NewBodyFormat().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	cursor, err := request.ExtractCursor(r, s.cursors)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := request.LoadPage(cursor, f.Offset, f.Limit, func(offset, limit int) ([]corefeed.Item, int, error) {
+		resp, err := s.coreclient.GetDaoFeed(r.Context(), f.ID, coresdk.GetDaoFeedRequest{
+			Offset: offset,
+			Limit:  limit,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return resp.Items, resp.TotalCnt, nil
+	}, daoFeedItemCursor)
+	if errors.Is(err, request.ErrCursorExpired) {
+		response.HandleError(cursorExpiredError(), w)
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Msgf("get dao feed by id: %s", f.ID.String())
 
@@ -158,8 +183,8 @@ func (s *Server) getDAOFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	daoIDs := make([]string, 0, len(resp.Items))
-	for _, info := range resp.Items {
+	daoIDs := make([]string, 0, len(page.Items))
+	for _, info := range page.Items {
 		daoIDs = append(daoIDs, info.DaoID.String())
 	}
 
@@ -171,8 +196,8 @@ func (s *Server) getDAOFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ids := make([]string, 0, len(resp.Items))
-	for _, info := range resp.Items {
+	ids := make([]string, 0, len(page.Items))
+	for _, info := range page.Items {
 		ids = append(ids, info.ProposalID)
 	}
 
@@ -182,17 +207,18 @@ func (s *Server) getDAOFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	list := make([]feed.Item, len(resp.Items))
-	for i, info := range resp.Items {
+	list := make([]feed.Item, len(page.Items))
+	for i, info := range page.Items {
 		list[i] = s.convertFeedToInternal(r.Context(), session, &info, pl[info.ProposalID], daoList[info.DaoID.String()])
 	}
+	list = helpers.RenderFeedItemsBody(list, bf.Formats)
 
 	log.Info().
 		Str("route", mux.CurrentRoute(r).GetName()).
 		Int("count", len(list)).
 		Msg("route execution")
 
-	response.AddPaginationHeaders(w, r, resp.Offset, resp.Limit, resp.TotalCnt)
+	response.AddPaginationHeaders(w, r, page.Offset, f.Limit, page.Total, s.encodeCursor(page.Next))
 	response.SendJSON(w, http.StatusOK, &list)
 }
 
@@ -500,6 +526,7 @@ func (s *Server) convertFeedToInternal(
 	var pr proposal.Proposal
 	if pi != nil {
 		pr = s.enrichProposalVotesInfo(ctx, session, *pi)
+		pr = s.enrichProposalBookmarkInfo(session, pr)
 		pr = helpers.WrapProposalIpfsLinks(pr)
 		pr.Timeline = convertFeedTimelineToProposal(fi.Timeline)
 	}
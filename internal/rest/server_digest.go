@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/digest"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/dao"
+	digestentity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/digest"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	digestform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/digest"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+// getDigest summarizes the feed of the user for the period grouped by dao
+func (s *Server) getDigest(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f, verr := digestform.NewGetForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	now := time.Now().UTC()
+	since := now.Add(-f.Period.Duration())
+
+	proposals, err := s.collectDigestProposals(r.Context(), session, since)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("collect digest proposals")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	result := digest.Build(f.Period, now, proposals, s.collectDigestDelegations(r.Context(), session.UserID, since))
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("period", string(f.Period)).
+		Int("groups", len(result.Groups)).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &result)
+}
+
+// collectDigestProposals returns proposals of feed items updated since the time enriched by the user votes.
+// Archived items are included: the digest covers the period regardless of the state of the inbox.
+func (s *Server) collectDigestProposals(ctx context.Context, session auth.Session, since time.Time) ([]proposal.Proposal, error) {
+	items, err := s.listFeedItems(ctx, session.UserID, inboxapi.GetUserFeedRequest_Include, inboxapi.GetUserFeedRequest_Include, feedFilterScanLimit)
+	if err != nil {
+		return nil, fmt.Errorf("list feed items: %w", err)
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.GetType() != "proposal" || item.ProposalId == nil || item.GetUpdatedAt().AsTime().Before(since) {
+			continue
+		}
+
+		if !slices.Contains(ids, item.GetProposalId()) {
+			ids = append(ids, item.GetProposalId())
+		}
+	}
+
+	proposals := make([]proposal.Proposal, 0, len(ids))
+	for chunk := range slices.Chunk(ids, feedFilterChunkSize) {
+		pl, err := s.fetchProposalsByIds(ctx, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("fetch proposals: %w", err)
+		}
+
+		list := make([]proposal.Proposal, 0, len(chunk))
+		for _, id := range chunk {
+			if pr, ok := pl[id]; ok {
+				list = append(list, *pr)
+			}
+		}
+
+		proposals = append(proposals, s.enrichProposalsVotesInfo(ctx, session, list)...)
+	}
+
+	return proposals, nil
+}
+
+// collectDigestDelegations returns the last delegations of the user in subscribed daos made since the time.
+// Delegations are optional for the digest, so errors are logged only.
+func (s *Server) collectDigestDelegations(ctx context.Context, userID auth.UserID, since time.Time) []digest.Delegation {
+	s.getSubscriptions(userID)
+
+	var list []digest.Delegation
+	for _, sub := range subscriptionsStorage.get(userID) {
+		if sub.DAO == nil {
+			continue
+		}
+
+		resp, err := s.delegateClient.GetLastDelegation(ctx, &inboxapi.GetLastDelegationRequest{
+			UserId: userID.String(),
+			DaoId:  sub.DAO.ID.String(),
+		})
+		if err != nil {
+			if status.Code(err) != codes.NotFound {
+				log.Warn().Err(err).Str("dao_id", sub.DAO.ID.String()).Msg("get last delegation for digest")
+			}
+
+			continue
+		}
+
+		if resp.GetCreatedAt().AsTime().Before(since) {
+			continue
+		}
+
+		var delegates []dao.PreparedDelegate
+		if err := json.Unmarshal([]byte(resp.GetDelegates()), &delegates); err != nil {
+			log.Warn().Err(err).Str("dao_id", sub.DAO.ID.String()).Msg("unmarshal last delegates for digest")
+		}
+
+		var expiration *common.Time
+		if resp.GetExpiration() != nil {
+			expiration = common.NewTime(resp.GetExpiration().AsTime())
+		}
+
+		list = append(list, digest.Delegation{
+			DAO: *sub.DAO,
+			Info: digestentity.DelegationInfo{
+				CreatedAt:  *common.NewTime(resp.GetCreatedAt().AsTime()),
+				TxHash:     resp.GetTxHash(),
+				Delegates:  delegates,
+				Expiration: expiration,
+			},
+		})
+	}
+
+	return list
+}
@@ -0,0 +1,381 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
+	coredelegation "github.com/goverland-labs/goverland-core-sdk-go/delegate"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	conv "github.com/goverland-labs/goverland-inbox-web-api/internal/achievements"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/dao"
+	models "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/achievements"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/delegations"
+	exportentity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/export"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/export"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const (
+	exportPageSize = 100
+	// exportFeedLimit, exportVotesLimit and exportDelegationsLimit limit the number of items in the archive
+	// for extremely large accounts, the delegations limit is applied to each DAO
+	exportFeedLimit        = 10000
+	exportVotesLimit       = 10000
+	exportDelegationsLimit = 10000
+)
+
+type exportDelegations struct {
+	Delegates  []delegations.DelegatesList  `json:"delegates"`
+	Delegators []delegations.DelegatorsList `json:"delegators"`
+	// Truncated reports that delegations over the limits aren't exported
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// exportMe returns the archive with the user data if it's ready, otherwise starts collecting it in background
+// and responds with the job status. The client polls the route until the archive is returned.
+func (s *Server) exportMe(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	job, ok, err := s.exports.Get(session.UserID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("get export")
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	if !ok || job.Status == export.StatusFailed {
+		job, err = s.exports.Start(session.UserID, func(ctx context.Context) ([]byte, error) {
+			return s.collectExport(ctx, session)
+		})
+		if err != nil {
+			log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("start export")
+
+			response.SendEmpty(w, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Str("status", string(job.Status)).
+		Msg("route execution")
+
+	if job.Status != export.StatusReady {
+		response.SendJSON(w, http.StatusAccepted, helpers.Ptr(convertExportJobToInternal(job)))
+
+		return
+	}
+
+	archive, err := s.exports.Archive(job)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("get export archive")
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("goverland-export-%s.zip", job.FinishedAt.Format(time.DateOnly))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(archive)
+}
+
+func (s *Server) collectExport(ctx context.Context, session auth.Session) ([]byte, error) {
+	profileInfo, err := s.authService.GetProfileInfo(session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get profile info: %w", err)
+	}
+
+	sessions, err := s.authService.ListSessions(session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+
+	s.getSubscriptions(session.UserID)
+	subs := subscriptionsStorage.get(session.UserID)
+	if subs == nil {
+		subs = []Subscription{}
+	}
+
+	pushSettings, err := s.settings.GetPushDetails(ctx, &inboxapi.GetPushDetailsRequest{UserId: session.UserID.String()})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, fmt.Errorf("get push details: %w", err)
+	}
+
+	feedSettings, err := s.settings.GetFeedSettings(ctx, &inboxapi.GetFeedSettingsRequest{UserId: session.UserID.String()})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, fmt.Errorf("get feed settings: %w", err)
+	}
+
+	achievementList, err := s.achievementClient.GetUserAchievementList(ctx, &inboxapi.GetUserAchievementListRequest{
+		UserId: session.UserID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get achievements: %w", err)
+	}
+
+	achievementItems := make([]models.Item, 0, len(achievementList.GetList()))
+	for _, achievement := range achievementList.GetList() {
+		achievementItems = append(achievementItems, conv.ConvertItemToInternal(achievement))
+	}
+
+	feedItems, err := s.listFeedItems(ctx, session.UserID, inboxapi.GetUserFeedRequest_Include, inboxapi.GetUserFeedRequest_Include, exportFeedLimit)
+	if err != nil {
+		return nil, fmt.Errorf("list feed items: %w", err)
+	}
+
+	votes := make([]proposal.Vote, 0)
+	delegationsInfo := exportDelegations{
+		Delegates:  []delegations.DelegatesList{},
+		Delegators: []delegations.DelegatorsList{},
+	}
+	if address, ok := s.getUserAddress(session); ok {
+		if votes, err = s.collectUserVotes(ctx, address); err != nil {
+			return nil, fmt.Errorf("collect votes: %w", err)
+		}
+
+		if err = s.collectDelegates(ctx, address, &delegationsInfo); err != nil {
+			return nil, fmt.Errorf("collect delegates: %w", err)
+		}
+
+		if err = s.collectDelegators(ctx, address, &delegationsInfo); err != nil {
+			return nil, fmt.Errorf("collect delegators: %w", err)
+		}
+	}
+
+	bookmarks, err := s.bookmarks.List(session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("list bookmarks: %w", err)
+	}
+
+	archive := export.NewArchive()
+	files := []struct {
+		name  string
+		value any
+	}{
+		{name: "profile.json", value: profileInfo},
+		{name: "sessions.json", value: sessions},
+		{name: "subscriptions.json", value: wrapSubscriptionsIpfsLinks(subs)},
+		{name: "settings/push.json", value: convertPushDetailsToInternal(pushSettings)},
+		{name: "settings/feed.json", value: convertFeedDetailsToInternal(feedSettings)},
+		{name: "achievements.json", value: achievementItems},
+		{name: "feed.json", value: convertExportFeedItems(feedItems)},
+		{name: "votes.json", value: votes},
+		{name: "delegations.json", value: delegationsInfo},
+		{name: "bookmarks.json", value: convertBookmarksToInternal(bookmarks)},
+	}
+	for _, file := range files {
+		if err := archive.AddJSON(file.name, file.value); err != nil {
+			return nil, err
+		}
+	}
+
+	return archive.Bytes()
+}
+
+func (s *Server) collectUserVotes(ctx context.Context, address string) ([]proposal.Vote, error) {
+	votes := make([]proposal.Vote, 0)
+	for offset := 0; offset < exportVotesLimit; offset += exportPageSize {
+		resp, err := s.coreclient.GetUserVotes(ctx, address, coresdk.GetUserVotesRequest{
+			Offset: offset,
+			Limit:  exportPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		votes = append(votes, ConvertVoteToInternal(resp.Items)...)
+		if offset+exportPageSize >= resp.TotalCnt {
+			break
+		}
+	}
+
+	return votes, nil
+}
+
+// collectDelegates pages through full lists of delegates in DAOs of the top list. The top list is limited
+// by the number of DAOs, so delegations in DAOs left out of it are reported as truncated.
+func (s *Server) collectDelegates(ctx context.Context, address string, info *exportDelegations) error {
+	top, err := s.coreclient.GetTopDelegatesByAddress(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	collected := 0
+	for _, summary := range top.List {
+		daoInfo, err := s.daoService.GetDao(ctx, summary.Dao.ID.String())
+		if err != nil {
+			return err
+		}
+
+		list, truncated, err := collectDelegationPages(func(offset, limit int) ([]coredelegation.DelegationDetails, int, error) {
+			resp, err := s.coreclient.GetDelegatesList(ctx, coresdk.GetDelegatesListRequest{
+				Address: address,
+				DaoID:   summary.Dao.ID.String(),
+				Offset:  offset,
+				Limit:   limit,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+
+			return resp.List, resp.TotalCount, nil
+		})
+		if err != nil {
+			return fmt.Errorf("list delegates in %s: %w", summary.Dao.ID, err)
+		}
+
+		collected += len(list)
+		info.Truncated = info.Truncated || truncated
+		info.Delegates = append(info.Delegates, delegations.DelegatesList{
+			Dao:            dao.ConvertDaoToShort(daoInfo),
+			List:           convertToDelegatesSummary(list),
+			TotalCount:     len(list),
+			DelegationType: delegations.DelegationTypeSplitDelegation,
+		})
+	}
+	info.Truncated = info.Truncated || collected < top.TotalCount
+
+	return nil
+}
+
+// collectDelegators pages through full lists of delegators in DAOs of the top list the same way as delegates
+func (s *Server) collectDelegators(ctx context.Context, address string, info *exportDelegations) error {
+	top, err := s.coreclient.GetTopDelegatorsByAddress(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	collected := 0
+	for _, summary := range top.List {
+		daoInfo, err := s.daoService.GetDao(ctx, summary.Dao.ID.String())
+		if err != nil {
+			return err
+		}
+
+		list, truncated, err := collectDelegationPages(func(offset, limit int) ([]coredelegation.DelegationDetails, int, error) {
+			resp, err := s.coreclient.GetDelegatorsList(ctx, coresdk.GetDelegatorsListRequest{
+				Address: address,
+				DaoID:   summary.Dao.ID.String(),
+				Offset:  offset,
+				Limit:   limit,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+
+			return resp.List, resp.TotalCount, nil
+		})
+		if err != nil {
+			return fmt.Errorf("list delegators in %s: %w", summary.Dao.ID, err)
+		}
+
+		collected += len(list)
+		info.Truncated = info.Truncated || truncated
+		info.Delegators = append(info.Delegators, delegations.DelegatorsList{
+			Dao:            dao.ConvertDaoToShort(daoInfo),
+			List:           convertToDelegatesSummary(list),
+			TotalCount:     len(list),
+			DelegationType: delegations.DelegationTypeSplitDelegation,
+		})
+	}
+	info.Truncated = info.Truncated || collected < top.TotalCount
+
+	return nil
+}
+
+// collectDelegationPages loads the list up to exportDelegationsLimit, it reports whether the list has more items
+func collectDelegationPages(load func(offset, limit int) ([]coredelegation.DelegationDetails, int, error)) ([]coredelegation.DelegationDetails, bool, error) {
+	list := make([]coredelegation.DelegationDetails, 0)
+	for offset := 0; ; offset += exportPageSize {
+		if offset >= exportDelegationsLimit {
+			return list, true, nil
+		}
+
+		items, total, err := load(offset, exportPageSize)
+		if err != nil {
+			return nil, false, err
+		}
+
+		list = append(list, items...)
+		if offset+exportPageSize >= total || len(items) == 0 {
+			return list, false, nil
+		}
+	}
+}
+
+// convertExportFeedItems keeps the state of feed items without enriching them by proposals,
+// proposals are public and aren't a part of the user data
+func convertExportFeedItems(list []*inboxapi.FeedItem) []feed.Item {
+	items := make([]feed.Item, 0, len(list))
+	for _, item := range list {
+		feedID, err := uuid.Parse(item.GetId())
+		if err != nil {
+			log.Error().Err(err).Str("id", item.GetId()).Msg("unable to parse feed id")
+
+			continue
+		}
+
+		daoID, _ := uuid.Parse(item.GetDaoId())
+
+		var readAt, archivedAt *common.Time
+		if item.ReadAt != nil {
+			readAt = common.NewTime(item.ReadAt.AsTime())
+		}
+		if item.ArchivedAt != nil {
+			archivedAt = common.NewTime(item.ArchivedAt.AsTime())
+		}
+
+		items = append(items, feed.Item{
+			ID:           feedID,
+			CreatedAt:    *common.NewTime(item.GetCreatedAt().AsTime()),
+			UpdatedAt:    *common.NewTime(item.GetUpdatedAt().AsTime()),
+			ReadAt:       readAt,
+			ArchivedAt:   archivedAt,
+			DaoID:        daoID,
+			ProposalID:   item.GetProposalId(),
+			DiscussionID: item.GetDiscussionId(),
+			Type:         item.GetType(),
+			Action:       item.GetAction(),
+		})
+	}
+
+	return items
+}
+
+func convertExportJobToInternal(job export.Job) exportentity.Job {
+	var finishedAt *common.Time
+	if job.FinishedAt != nil {
+		finishedAt = common.NewTime(*job.FinishedAt)
+	}
+
+	return exportentity.Job{
+		ID:         job.ID,
+		Status:     string(job.Status),
+		CreatedAt:  *common.NewTime(job.CreatedAt),
+		FinishedAt: finishedAt,
+	}
+}
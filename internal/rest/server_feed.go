@@ -19,10 +19,13 @@ import (
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/feedstream"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	resthelpers "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/common"
 	feedform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/feed"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/request"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/snooze"
 )
 
 func (s *Server) getFeed(w http.ResponseWriter, r *http.Request) {
@@ -32,27 +35,56 @@ func (s *Server) getFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	bf, verr := resthelpers.NewBodyFormat().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
 	offset, limit, err := request.ExtractPagination(r)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	f, _ := feedform.NewGetFeedForm().ParseAndValidate(r)
+	cursor, err := request.ExtractCursor(r, s.cursors)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	resp, err := s.feedClient.GetUserFeed(context.TODO(), &inboxapi.GetUserFeedRequest{
-		SubscriberId:  session.UserID.String(),
-		ReadState:     f.Unread.AsProto(),
-		ArchivedState: f.Archived.AsProto(),
-		Limit:         uint32(limit),
-		Offset:        uint32(offset),
-	})
+	f, verr := feedform.NewGetFeedForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	filter, verr := s.newFeedFilter(r.Context(), f.Filters)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	var (
+		page        request.Page[*inboxapi.FeedItem]
+		unreadCount int
+		truncated   bool
+	)
+	if f.Filters.IsEmpty() {
+		page, unreadCount, err = s.loadFeedPage(r.Context(), session, f, cursor, offset, limit)
+	} else {
+		page, unreadCount, truncated, err = s.loadFilteredFeedPage(r.Context(), session, f, filter, cursor, offset, limit)
+	}
+	if errors.Is(err, request.ErrCursorExpired) {
+		response.HandleError(cursorExpiredError(), w)
+		return
+	}
 	if err != nil {
 		response.SendError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	feedList := resp.GetList()
+	feedList := page.Items
 	proposalIds := make([]string, 0, len(feedList))
 	for _, info := range feedList {
 		if info.ProposalId != nil {
@@ -67,19 +99,54 @@ func (s *Server) getFeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	list := helpers.WrapFeedItemsIpfsLinks(s.convertInboxFeedListToInternal(r.Context(), session, feedList, pl))
-	totalCount := int(resp.GetTotalCount())
+	list = helpers.RenderFeedItemsBody(list, bf.Formats)
+	totalCount := page.Total
 
 	log.Info().
 		Str("route", mux.CurrentRoute(r).GetName()).
 		Int("count", len(list)).
 		Int("total", totalCount).
+		Bool("truncated", truncated).
 		Msg("route execution")
 
-	response.AddPaginationHeaders(w, r, offset, limit, totalCount)
-	response.AddUnreadHeader(w, int(resp.UnreadCount))
+	response.AddPaginationHeaders(w, r, page.Offset, limit, totalCount, s.encodeCursor(page.Next))
+	response.AddUnreadHeader(w, unreadCount)
+	if !f.Filters.IsEmpty() {
+		response.AddFilterWindowHeader(w, feedFilterWindow)
+	}
+	if truncated {
+		response.AddTruncatedHeader(w)
+	}
 	response.SendJSON(w, http.StatusOK, &list)
 }
 
+func (s *Server) loadFeedPage(
+	ctx context.Context,
+	session auth.Session,
+	form *feedform.GetFeedForm,
+	cursor *request.Cursor,
+	offset, limit int,
+) (request.Page[*inboxapi.FeedItem], int, error) {
+	var unreadCount int
+	page, err := request.LoadPage(cursor, offset, limit, func(offset, limit int) ([]*inboxapi.FeedItem, int, error) {
+		resp, err := s.feedClient.GetUserFeed(ctx, &inboxapi.GetUserFeedRequest{
+			SubscriberId:  session.UserID.String(),
+			ReadState:     form.Unread.AsProto(),
+			ArchivedState: form.Archived.AsProto(),
+			Limit:         uint32(limit),
+			Offset:        uint32(offset),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		unreadCount = int(resp.GetUnreadCount())
+
+		return resp.GetList(), int(resp.GetTotalCount()), nil
+	}, feedItemCursor)
+
+	return page, unreadCount, err
+}
+
 func (s *Server) markFeedItemAsRead(w http.ResponseWriter, r *http.Request) {
 	session, ok := appctx.ExtractUserSession(r.Context())
 	if !ok {
@@ -103,6 +170,8 @@ func (s *Server) markFeedItemAsRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.publishFeedState(session.UserID, []string{f.ID.String()}, feedstream.StateRead, int(resp.GetUnreadCount()))
+
 	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
 	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
 	response.SendEmpty(w, http.StatusOK)
@@ -131,6 +200,8 @@ func (s *Server) markFeedItemAsArchived(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	s.publishFeedState(session.UserID, []string{f.ID.String()}, feedstream.StateArchived, int(resp.GetUnreadCount()))
+
 	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
 	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
 	response.SendEmpty(w, http.StatusOK)
@@ -159,6 +230,8 @@ func (s *Server) markFeedItemAsUnread(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.publishFeedState(session.UserID, []string{f.ID.String()}, feedstream.StateUnread, int(resp.GetUnreadCount()))
+
 	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
 	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
 	response.SendEmpty(w, http.StatusOK)
@@ -187,6 +260,13 @@ func (s *Server) markFeedItemAsUnarchived(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	s.publishFeedState(session.UserID, []string{f.ID.String()}, feedstream.StateUnarchived, int(resp.GetUnreadCount()))
+
+	// the item restored manually mustn't be resurfaced by the snooze later
+	if _, err := s.snoozes.Cancel(session.UserID, f.ID.String()); err != nil && !errors.Is(err, snooze.ErrNotFound) {
+		log.Error().Err(err).Str("feed_item_id", f.ID.String()).Msg("cancel snooze")
+	}
+
 	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
 	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
 	response.SendEmpty(w, http.StatusOK)
@@ -210,22 +290,47 @@ func (s *Server) markAsReadBatch(w http.ResponseWriter, r *http.Request) {
 		ids = append(ids, id.String())
 	}
 
-	var before *timestamppb.Timestamp
-	if f.Before != nil {
-		before = timestamppb.New(*f.Before)
-	}
+	var (
+		resp *inboxapi.UnreadStats
+		err  error
+	)
+	if f.Filters.IsEmpty() {
+		var before *timestamppb.Timestamp
+		if f.Before != nil {
+			before = timestamppb.New(*f.Before)
+		}
 
-	resp, err := s.feedClient.MarkAsRead(context.TODO(), &inboxapi.MarkAsReadRequest{
-		SubscriberId: session.UserID.String(),
-		Ids:          ids,
-		Before:       before,
-	})
+		resp, err = s.feedClient.MarkAsRead(context.TODO(), &inboxapi.MarkAsReadRequest{
+			SubscriberId: session.UserID.String(),
+			Ids:          ids,
+			Before:       before,
+		})
+	} else {
+		filter, verr := s.newFeedFilter(r.Context(), f.Filters)
+		if verr != nil {
+			response.HandleError(verr, w)
+			return
+		}
+
+		ids, resp, err = s.markFilteredFeedItems(r.Context(), session, filter, func(item *inboxapi.FeedItem) bool {
+			return item.ReadAt == nil
+		}, func(ids []string) (*inboxapi.UnreadStats, error) {
+			return s.feedClient.MarkAsRead(r.Context(), &inboxapi.MarkAsReadRequest{
+				SubscriberId: session.UserID.String(),
+				Ids:          ids,
+			})
+		})
+	}
 
 	if err != nil {
 		response.HandleError(response.ResolveError(err), w)
 		return
 	}
 
+	if f.Filters.IsEmpty() || len(ids) > 0 {
+		s.publishFeedState(session.UserID, ids, feedstream.StateRead, int(resp.GetUnreadCount()))
+	}
+
 	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
 	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
 	response.SendEmpty(w, http.StatusOK)
@@ -265,6 +370,147 @@ func (s *Server) markAsUnreadBatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.publishFeedState(session.UserID, ids, feedstream.StateUnread, int(resp.GetUnreadCount()))
+
+	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
+	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
+	response.SendEmpty(w, http.StatusOK)
+}
+
+// markAsArchivedBatch archives items by ids, by the date or by filters of the feed listing
+func (s *Server) markAsArchivedBatch(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f, verr := feedform.NewArchiveBatchForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	ids := make([]string, 0, len(f.IDs))
+	for _, id := range f.IDs {
+		ids = append(ids, id.String())
+	}
+
+	var (
+		resp *inboxapi.UnreadStats
+		err  error
+	)
+	if f.Filters.IsEmpty() {
+		var before *timestamppb.Timestamp
+		if f.Before != nil {
+			before = timestamppb.New(*f.Before)
+		}
+
+		resp, err = s.feedClient.MarkAsArchived(r.Context(), &inboxapi.MarkAsArchivedRequest{
+			SubscriberId: session.UserID.String(),
+			Ids:          ids,
+			Before:       before,
+		})
+	} else {
+		filter, verr := s.newFeedFilter(r.Context(), f.Filters)
+		if verr != nil {
+			response.HandleError(verr, w)
+			return
+		}
+
+		ids, resp, err = s.markFilteredFeedItems(r.Context(), session, filter, func(item *inboxapi.FeedItem) bool {
+			return item.ArchivedAt == nil
+		}, func(ids []string) (*inboxapi.UnreadStats, error) {
+			return s.feedClient.MarkAsArchived(r.Context(), &inboxapi.MarkAsArchivedRequest{
+				SubscriberId: session.UserID.String(),
+				Ids:          ids,
+			})
+		})
+	}
+
+	if err != nil {
+		response.HandleError(response.ResolveError(err), w)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Int("count", len(ids)).
+		Msg("route execution")
+
+	if f.Filters.IsEmpty() || len(ids) > 0 {
+		s.publishFeedState(session.UserID, ids, feedstream.StateArchived, int(resp.GetUnreadCount()))
+	}
+
+	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
+	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
+	response.SendEmpty(w, http.StatusOK)
+}
+
+// markAsUnarchivedBatch restores archived items by ids or by filters of the feed listing
+func (s *Server) markAsUnarchivedBatch(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f, verr := feedform.NewUnarchiveBatchForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	ids := make([]string, 0, len(f.IDs))
+	for _, id := range f.IDs {
+		ids = append(ids, id.String())
+	}
+
+	var (
+		resp *inboxapi.UnreadStats
+		err  error
+	)
+	if f.Filters.IsEmpty() {
+		resp, err = s.feedClient.MarkAsUnarchived(r.Context(), &inboxapi.MarkAsUnarchivedRequest{
+			SubscriberId: session.UserID.String(),
+			Ids:          ids,
+		})
+	} else {
+		filter, verr := s.newFeedFilter(r.Context(), f.Filters)
+		if verr != nil {
+			response.HandleError(verr, w)
+			return
+		}
+
+		ids, resp, err = s.markFilteredFeedItems(r.Context(), session, filter, func(item *inboxapi.FeedItem) bool {
+			return item.ArchivedAt != nil
+		}, func(ids []string) (*inboxapi.UnreadStats, error) {
+			return s.feedClient.MarkAsUnarchived(r.Context(), &inboxapi.MarkAsUnarchivedRequest{
+				SubscriberId: session.UserID.String(),
+				Ids:          ids,
+			})
+		})
+	}
+
+	if err != nil {
+		response.HandleError(response.ResolveError(err), w)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Int("count", len(ids)).
+		Msg("route execution")
+
+	if len(ids) > 0 {
+		s.publishFeedState(session.UserID, ids, feedstream.StateUnarchived, int(resp.GetUnreadCount()))
+
+		// items restored manually mustn't be resurfaced by snoozes later
+		if err := s.snoozes.CancelAll(session.UserID, ids); err != nil {
+			log.Error().Err(err).Int("count", len(ids)).Msg("cancel snoozes")
+		}
+	}
+
 	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
 	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
 	response.SendEmpty(w, http.StatusOK)
@@ -310,6 +556,7 @@ func (s *Server) convertInboxFeedItemToInternal(
 	}
 
 	enriched := s.enrichProposalVotesInfo(ctx, session, *details)
+	enriched = s.enrichProposalBookmarkInfo(session, enriched)
 	proposalItem = helpers.Ptr(helpers.WrapProposalIpfsLinks(enriched))
 
 	feedID, err := uuid.Parse(item.GetId())
@@ -331,6 +578,14 @@ func (s *Server) convertInboxFeedItemToInternal(
 		proposalItem.Timeline = convertFeedTimelineToProposal(item.Timeline)
 	}
 
+	var changes *proposal.RevisionChanges
+	if feedItemEvent(item) == feed.ProposalUpdated {
+		var err error
+		if changes, err = s.revisions.LastChanges(item.GetProposalId()); err != nil {
+			log.Error().Err(err).Str("proposal_id", item.GetProposalId()).Msg("get last proposal changes")
+		}
+	}
+
 	return feed.Item{
 		ID:           feedID,
 		CreatedAt:    *common.NewTime(item.CreatedAt.AsTime()),
@@ -343,6 +598,7 @@ func (s *Server) convertInboxFeedItemToInternal(
 		Type:         item.GetType(),
 		Action:       item.GetAction(),
 		Proposal:     proposalItem,
+		Changes:      changes,
 	}, nil
 }
 
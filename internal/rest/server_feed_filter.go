@@ -0,0 +1,291 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	feedform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/request"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const (
+	// feedFilterWindow is the number of the latest feed items filters of the listing are applied to. The storage
+	// doesn't support filters, so the window is loaded and filtered in memory for every page, older items are
+	// never returned by filtered listings. The window is sent in the X-Filter-Window header.
+	feedFilterWindow = 300
+	// feedFilterScanLimit limits the number of feed items scanned by search and digest.
+	// Bulk operations scan the whole feed by pages of this size.
+	feedFilterScanLimit = 1000
+	feedFilterChunkSize = 100
+)
+
+// feedFilter is the feed form filter with dao aliases resolved to ids
+type feedFilter struct {
+	daoIDs        []string
+	events        []feed.Event
+	states        []proposal.State
+	voted         *bool
+	createdAfter  *time.Time
+	createdBefore *time.Time
+}
+
+func (s *Server) newFeedFilter(ctx context.Context, filters feedform.Filters) (feedFilter, response.Error) {
+	filter := feedFilter{
+		events:        filters.Events,
+		states:        filters.States,
+		voted:         filters.Voted,
+		createdAfter:  filters.CreatedAfter,
+		createdBefore: filters.CreatedBefore,
+	}
+
+	for i, ref := range filters.DAOs {
+		dao, err := s.daoService.GetDao(ctx, ref)
+		if err != nil {
+			log.Warn().Err(err).Str("dao", ref).Msg("resolve dao for feed filter")
+
+			ve := response.NewValidationError()
+			ve.SetError(fmt.Sprintf("dao.%d", i), response.WrongValue, "unknown dao")
+
+			return feedFilter{}, ve
+		}
+
+		filter.daoIDs = append(filter.daoIDs, dao.ID.String())
+	}
+
+	return filter, nil
+}
+
+// matchItem checks filters available without loading proposals
+func (f feedFilter) matchItem(item *inboxapi.FeedItem) bool {
+	if len(f.daoIDs) > 0 && !slices.Contains(f.daoIDs, item.GetDaoId()) {
+		return false
+	}
+
+	if len(f.events) > 0 && !slices.Contains(f.events, feedItemEvent(item)) {
+		return false
+	}
+
+	createdAt := item.GetCreatedAt().AsTime()
+	if f.createdAfter != nil && createdAt.Before(*f.createdAfter) {
+		return false
+	}
+
+	if f.createdBefore != nil && !createdAt.Before(*f.createdBefore) {
+		return false
+	}
+
+	return true
+}
+
+// matchProposal checks filters of the proposal, it's nil unless the state filter is set
+func (f feedFilter) matchProposal(pr *proposal.Proposal, voted bool) bool {
+	if len(f.states) > 0 && (pr == nil || pr.State == nil || !slices.Contains(f.states, *pr.State)) {
+		return false
+	}
+
+	if f.voted != nil && *f.voted != voted {
+		return false
+	}
+
+	return true
+}
+
+func feedItemEvent(item *inboxapi.FeedItem) feed.Event {
+	if event, ok := feed.ActionSourceMap[feed.ActionSource(item.GetAction())]; ok {
+		return event
+	}
+
+	return feed.Event(item.GetAction())
+}
+
+// loadFilteredFeedPage loads the feed window, applies filters and paginates the result, so the total count
+// and the unread count are calculated for the filtered window. The truncated flag reports that the feed
+// is longer than the window and older items aren't filtered.
+func (s *Server) loadFilteredFeedPage(
+	ctx context.Context,
+	session auth.Session,
+	form *feedform.GetFeedForm,
+	filter feedFilter,
+	cursor *request.Cursor,
+	offset, limit int,
+) (request.Page[*inboxapi.FeedItem], int, bool, error) {
+	items, truncated, err := s.scanFeedItems(ctx, session.UserID, form.Unread.AsProto(), form.Archived.AsProto(), 0, feedFilterWindow)
+	if err != nil {
+		return request.Page[*inboxapi.FeedItem]{}, 0, false, fmt.Errorf("list feed items: %w", err)
+	}
+
+	matched, err := s.filterFeedItems(ctx, session, items, filter)
+	if err != nil {
+		return request.Page[*inboxapi.FeedItem]{}, 0, false, err
+	}
+
+	unreadCount := 0
+	for _, item := range matched {
+		if item.ReadAt == nil {
+			unreadCount++
+		}
+	}
+
+	page, err := request.LoadPage(cursor, offset, limit, request.SliceSource(matched), feedItemCursor)
+	if err != nil {
+		return request.Page[*inboxapi.FeedItem]{}, 0, false, err
+	}
+
+	return page, unreadCount, truncated, nil
+}
+
+// filterFeedItems keeps items matching the filter. Proposals are loaded only for the state filter,
+// items without proposals are skipped then the same way as on converting the feed.
+func (s *Server) filterFeedItems(ctx context.Context, session auth.Session, items []*inboxapi.FeedItem, filter feedFilter) ([]*inboxapi.FeedItem, error) {
+	candidates := make([]*inboxapi.FeedItem, 0, len(items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.GetType() != "proposal" || item.ProposalId == nil || !filter.matchItem(item) {
+			continue
+		}
+
+		candidates = append(candidates, item)
+		if !slices.Contains(ids, item.GetProposalId()) {
+			ids = append(ids, item.GetProposalId())
+		}
+	}
+
+	var proposals map[string]*proposal.Proposal
+	if len(filter.states) > 0 {
+		var err error
+		if proposals, err = s.filterProposals(ctx, ids); err != nil {
+			return nil, fmt.Errorf("fetch proposals: %w", err)
+		}
+	}
+
+	var voted map[string]bool
+	if filter.voted != nil {
+		var err error
+		if voted, err = s.votedProposals(ctx, session, ids); err != nil {
+			return nil, fmt.Errorf("get user votes: %w", err)
+		}
+	}
+
+	matched := make([]*inboxapi.FeedItem, 0, len(candidates))
+	for _, item := range candidates {
+		if !filter.matchProposal(proposals[item.GetProposalId()], voted[item.GetProposalId()]) {
+			continue
+		}
+
+		matched = append(matched, item)
+	}
+
+	return matched, nil
+}
+
+func (s *Server) filterProposals(ctx context.Context, ids []string) (map[string]*proposal.Proposal, error) {
+	proposals := make(map[string]*proposal.Proposal, len(ids))
+	for chunk := range slices.Chunk(ids, feedFilterChunkSize) {
+		pl, err := s.fetchProposalsByIds(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, pr := range pl {
+			proposals[id] = pr
+		}
+	}
+
+	return proposals, nil
+}
+
+// votedProposals returns proposals from the list the user voted for, guests have no votes
+func (s *Server) votedProposals(ctx context.Context, session auth.Session, ids []string) (map[string]bool, error) {
+	voted := make(map[string]bool)
+
+	address, ok := s.getUserAddress(session)
+	if !ok {
+		return voted, nil
+	}
+
+	for chunk := range slices.Chunk(ids, feedFilterChunkSize) {
+		resp, err := s.coreclient.GetUserVotes(ctx, address, coresdk.GetUserVotesRequest{
+			ProposalIDs: chunk,
+			Limit:       len(chunk),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vote := range resp.Items {
+			voted[vote.ProposalID] = true
+		}
+	}
+
+	return voted, nil
+}
+
+// markFilteredFeedItems applies the action to items matching the filter. The whole feed is scanned page by page,
+// each page is marked by chunks of ids before loading the next one. The storage treats the empty list of ids
+// as "all items", so the current counters are returned without calling it if nothing matched.
+func (s *Server) markFilteredFeedItems(
+	ctx context.Context,
+	session auth.Session,
+	filter feedFilter,
+	keep func(item *inboxapi.FeedItem) bool,
+	mark func(ids []string) (*inboxapi.UnreadStats, error),
+) ([]string, *inboxapi.UnreadStats, error) {
+	var (
+		ids   []string
+		stats *inboxapi.UnreadStats
+	)
+	for offset := 0; ; offset += feedFilterScanLimit {
+		items, more, err := s.scanFeedItems(ctx, session.UserID, inboxapi.GetUserFeedRequest_Include, inboxapi.GetUserFeedRequest_Include, offset, feedFilterScanLimit)
+		if err != nil {
+			return nil, nil, fmt.Errorf("list feed items: %w", err)
+		}
+
+		matched, err := s.filterFeedItems(ctx, session, items, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pageIDs := make([]string, 0, len(matched))
+		for _, item := range matched {
+			if keep(item) {
+				pageIDs = append(pageIDs, item.GetId())
+			}
+		}
+
+		for chunk := range slices.Chunk(pageIDs, feedFilterChunkSize) {
+			if stats, err = mark(chunk); err != nil {
+				return nil, nil, err
+			}
+		}
+		ids = append(ids, pageIDs...)
+
+		if !more {
+			break
+		}
+	}
+
+	if len(ids) == 0 {
+		resp, err := s.feedClient.GetUserFeed(ctx, &inboxapi.GetUserFeedRequest{
+			SubscriberId:  session.UserID.String(),
+			ArchivedState: inboxapi.GetUserFeedRequest_Exclude,
+			Limit:         1,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("get feed counters: %w", err)
+		}
+
+		return []string{}, &inboxapi.UnreadStats{TotalCount: resp.GetTotalCount(), UnreadCount: resp.GetUnreadCount()}, nil
+	}
+
+	return ids, stats, nil
+}
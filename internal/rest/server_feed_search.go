@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	resthelpers "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/common"
+	feedform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/search"
+)
+
+// searchFeed searches proposals of the user's feed including archived items. Proposals are indexed
+// on the first search and reindexed only when the feed item is updated.
+func (s *Server) searchFeed(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f, verr := feedform.NewSearchForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	bf, verr := resthelpers.NewBodyFormat().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	items, err := s.listFeedItems(r.Context(), session.UserID, inboxapi.GetUserFeedRequest_Include, inboxapi.GetUserFeedRequest_Include, feedFilterScanLimit)
+	if err != nil {
+		response.HandleError(response.ResolveError(err), w)
+		return
+	}
+
+	// the proposal is represented by the last updated feed item
+	byProposal := make(map[string]*inboxapi.FeedItem, len(items))
+	versions := make(map[string]time.Time, len(items))
+	for _, item := range items {
+		if item.GetType() != "proposal" || item.ProposalId == nil {
+			continue
+		}
+
+		updatedAt := item.GetUpdatedAt().AsTime()
+		if prev, ok := versions[item.GetProposalId()]; ok && !updatedAt.After(prev) {
+			continue
+		}
+
+		byProposal[item.GetProposalId()] = item
+		versions[item.GetProposalId()] = updatedAt
+	}
+
+	if err := s.indexProposals(r.Context(), versions); err != nil {
+		log.Error().Err(err).Msg("index proposals for search")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	ids := make([]string, 0, len(byProposal))
+	for id := range byProposal {
+		ids = append(ids, id)
+	}
+
+	results := s.searchIndex.Search(f.Query, ids)
+	total := len(results)
+	results = results[min(f.Offset, total):min(f.Offset+f.Limit, total)]
+
+	pageIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		pageIDs = append(pageIDs, result.ID)
+	}
+
+	proposals := make(map[string]*proposal.Proposal, len(pageIDs))
+	if len(pageIDs) > 0 {
+		if proposals, err = s.fetchProposalsByIds(r.Context(), pageIDs); err != nil {
+			response.HandleError(response.ResolveError(err), w)
+			return
+		}
+	}
+
+	list := make([]feed.SearchResult, 0, len(results))
+	for _, result := range results {
+		item, err := s.convertInboxFeedItemToInternal(r.Context(), session, byProposal[result.ID], proposals)
+		if err != nil {
+			continue
+		}
+
+		list = append(list, feed.SearchResult{
+			Item:     helpers.RenderFeedItemBody(helpers.WrapFeedItemIpfsLinks(item), bf.Formats),
+			Score:    result.Score,
+			Title:    result.Title,
+			Snippets: result.Snippets,
+		})
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Int("count", len(list)).
+		Int("total", total).
+		Msg("route execution")
+
+	response.AddPaginationHeaders(w, r, f.Offset, f.Limit, total)
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+// indexProposals adds to the search index proposals which aren't indexed or were updated after indexing
+func (s *Server) indexProposals(ctx context.Context, versions map[string]time.Time) error {
+	missing := s.searchIndex.Missing(versions)
+	for chunk := range slices.Chunk(missing, feedFilterChunkSize) {
+		pl, err := s.fetchProposalsByIds(ctx, chunk)
+		if err != nil {
+			return fmt.Errorf("fetch proposals: %w", err)
+		}
+
+		docs := make([]search.Document, 0, len(pl))
+		for id, pr := range pl {
+			docs = append(docs, search.Document{
+				ID:        id,
+				Title:     pr.Title,
+				DAOName:   pr.DAO.Name + " " + pr.DAO.Alias,
+				Body:      markdownBody(pr.Body),
+				UpdatedAt: versions[id],
+			})
+		}
+
+		s.searchIndex.Add(docs...)
+	}
+
+	return nil
+}
+
+func markdownBody(body []common.Content) string {
+	for _, content := range body {
+		if content.Type == common.Markdown {
+			return content.Body
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/feedstream"
+	feedform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/snooze"
+)
+
+// snoozeFeedItem archives the item until the time, the snooze worker returns it as unread. The item isn't loaded:
+// archiving by the id fails with NotFound for items of other users, the snooze is cancelled in this case.
+func (s *Server) snoozeFeedItem(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f, verr := feedform.NewSnoozeForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	snoozed := snooze.Item{
+		UserID:     session.UserID,
+		FeedItemID: f.ID.String(),
+		ProposalID: f.ProposalID,
+		Remind:     f.Remind,
+		CreatedAt:  time.Now(),
+	}
+
+	var votingStart, votingEnd time.Time
+	if f.ProposalID != "" {
+		pr, err := s.prService.GetByID(r.Context(), f.ProposalID)
+		if err != nil && f.Preset.RequiresVoting() {
+			log.Error().Err(err).Str("proposal_id", f.ProposalID).Msg("get proposal for snooze")
+
+			response.HandleError(response.ResolveError(err), w)
+			return
+		}
+		if pr != nil {
+			snoozed.Title = pr.Title
+			if pr.VotingStart.Time != nil {
+				votingStart = *pr.VotingStart.Time
+			}
+			if pr.VotingEnd.Time != nil {
+				votingEnd = *pr.VotingEnd.Time
+			}
+		}
+	}
+
+	if f.Until != nil {
+		snoozed.Until = *f.Until
+	} else {
+		var err error
+		snoozed.Until, err = f.Preset.Resolve(snoozed.CreatedAt, votingStart, votingEnd)
+		if err != nil {
+			ve := response.NewValidationError()
+			ve.SetError("preset", response.WrongValue, err.Error())
+			response.HandleError(ve, w)
+			return
+		}
+	}
+
+	if err := s.snoozes.Snooze(snoozed); err != nil {
+		log.Error().Err(err).Str("feed_item_id", snoozed.FeedItemID).Msg("store snoozed item")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	resp, err := s.feedClient.MarkAsArchived(r.Context(), &inboxapi.MarkAsArchivedRequest{
+		SubscriberId: session.UserID.String(),
+		Ids:          []string{snoozed.FeedItemID},
+	})
+	if err != nil {
+		if _, cerr := s.snoozes.Cancel(session.UserID, snoozed.FeedItemID); cerr != nil {
+			log.Error().Err(cerr).Str("feed_item_id", snoozed.FeedItemID).Msg("cancel snooze")
+		}
+
+		response.HandleError(response.ResolveError(err), w)
+		return
+	}
+
+	s.publishFeedState(session.UserID, []string{snoozed.FeedItemID}, feedstream.StateArchived, int(resp.GetUnreadCount()))
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("feed_item_id", snoozed.FeedItemID).
+		Time("until", snoozed.Until).
+		Msg("route execution")
+
+	result := convertSnoozedToInternal(snoozed)
+
+	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
+	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
+	response.SendJSON(w, http.StatusOK, &result)
+}
+
+// unsnoozeFeedItem cancels the snooze and returns the item to the feed immediately
+func (s *Server) unsnoozeFeedItem(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f, verr := feedform.NewMarkUnmarkItemForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	_, err := s.snoozes.Cancel(session.UserID, f.ID.String())
+	if errors.Is(err, snooze.ErrNotFound) {
+		response.HandleError(response.NewNotFoundError(), w)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("feed_item_id", f.ID.String()).Msg("cancel snooze")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	resp, err := s.feedClient.MarkAsUnarchived(r.Context(), &inboxapi.MarkAsUnarchivedRequest{
+		SubscriberId: session.UserID.String(),
+		Ids:          []string{f.ID.String()},
+	})
+	if err != nil {
+		response.HandleError(response.ResolveError(err), w)
+		return
+	}
+
+	s.publishFeedState(session.UserID, []string{f.ID.String()}, feedstream.StateUnarchived, int(resp.GetUnreadCount()))
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("feed_item_id", f.ID.String()).
+		Msg("route execution")
+
+	response.AddTotalCounterHeaders(w, int(resp.GetTotalCount()))
+	response.AddUnreadHeader(w, int(resp.GetUnreadCount()))
+	response.SendEmpty(w, http.StatusOK)
+}
+
+func (s *Server) listSnoozedFeedItems(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	items, err := s.snoozes.List(session.UserID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("list snoozed items")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	list := make([]feed.Snoozed, 0, len(items))
+	for _, item := range items {
+		list = append(list, convertSnoozedToInternal(item))
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Int("count", len(list)).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+func convertSnoozedToInternal(item snooze.Item) feed.Snoozed {
+	return feed.Snoozed{
+		FeedItemID: item.FeedItemID,
+		ProposalID: item.ProposalID,
+		Until:      *common.NewTime(item.Until),
+		Remind:     item.Remind,
+		CreatedAt:  *common.NewTime(item.CreatedAt),
+	}
+}
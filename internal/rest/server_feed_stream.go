@@ -0,0 +1,271 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/feedstream"
+	feedform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const wsWriteTimeout = 10 * time.Second
+
+var wsUpgrader = websocket.Upgrader{
+	// the api is available for any origin, requests are authorized by the header instead of cookies
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+type feedStreamWriter interface {
+	WriteEvent(event feedstream.Event) error
+	WriteHeartbeat() error
+}
+
+// getFeedStream sends feed updates as Server-Sent Events
+func (s *Server) getFeedStream(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f, verr := feedform.NewStreamForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// the server write timeout is applied to the whole response, so it's disabled for the stream
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn().Err(err).Msg("reset write deadline for feed stream")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		log.Error().Err(err).Msg("feed stream is not supported by the writer")
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Msg("route execution")
+
+	s.serveFeedStream(r.Context(), session, f.LastEventID, &sseWriter{w: w, rc: rc})
+}
+
+// getFeedStreamWS sends feed updates over WebSocket as JSON messages
+func (s *Server) getFeedStreamWS(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f, verr := feedform.NewStreamForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("upgrade feed stream connection")
+		return
+	}
+	defer conn.Close()
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Msg("route execution")
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// incoming messages aren't expected, the reader handles pongs and detects closed connections
+	readTimeout := 2 * s.streamCfg.Heartbeat
+	_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+	go func() {
+		defer cancel()
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	s.serveFeedStream(ctx, session, f.LastEventID, &wsWriter{conn: conn})
+}
+
+func (s *Server) serveFeedStream(ctx context.Context, session auth.Session, lastEventID *uint64, out feedStreamWriter) {
+	hub := s.feedBroker.Hub()
+	sub, missed, reset := hub.Subscribe(session.UserID, s.subscribedDaoFilter(session.UserID), lastEventID)
+	defer hub.Unsubscribe(sub)
+
+	if reset {
+		missed = []feedstream.Event{{Type: feedstream.EventReset, Data: json.RawMessage("{}")}}
+	}
+
+	for _, event := range missed {
+		if err := out.WriteEvent(event); err != nil {
+			return
+		}
+	}
+
+	if err := s.writeUnreadEvent(ctx, session.UserID, out); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(s.streamCfg.Heartbeat)
+	defer heartbeat.Stop()
+
+	// new feed items are created for subscribers asynchronously, so the counter is refreshed with the delay
+	unread := time.NewTimer(s.streamCfg.UnreadDelay)
+	unread.Stop()
+	defer unread.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.Done():
+			return
+		case event := <-sub.Events():
+			if event.Type == feedstream.EventFeedItem {
+				unread.Reset(s.streamCfg.UnreadDelay)
+			}
+
+			err = out.WriteEvent(event)
+		case <-unread.C:
+			err = s.writeUnreadEvent(ctx, session.UserID, out)
+		case <-heartbeat.C:
+			err = out.WriteHeartbeat()
+		}
+
+		if err != nil {
+			log.Debug().Err(err).Str("user_id", session.UserID.String()).Msg("feed stream is closed")
+
+			return
+		}
+	}
+}
+
+// writeUnreadEvent sends the actual unread counter without event id, it isn't replayed on resuming
+func (s *Server) writeUnreadEvent(ctx context.Context, userID auth.UserID, out feedStreamWriter) error {
+	resp, err := s.feedClient.GetUserFeed(ctx, &inboxapi.GetUserFeedRequest{
+		SubscriberId: userID.String(),
+		Limit:        1,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("get unread counter for feed stream")
+
+		return nil
+	}
+
+	data, err := json.Marshal(feedstream.UnreadPayload{UnreadCount: int(resp.GetUnreadCount())})
+	if err != nil {
+		return err
+	}
+
+	return out.WriteEvent(feedstream.Event{Type: feedstream.EventUnread, Data: data})
+}
+
+func (s *Server) subscribedDaoFilter(userID auth.UserID) feedstream.DaoFilter {
+	return func(daoID string) bool {
+		return slices.ContainsFunc(subscriptionsStorage.get(userID), func(sub Subscription) bool {
+			return sub.DAO != nil && sub.DAO.ID.String() == daoID
+		})
+	}
+}
+
+func (s *Server) publishFeedState(userID auth.UserID, ids []string, state feedstream.State, unreadCount int) {
+	if ids == nil {
+		ids = []string{}
+	}
+
+	s.feedBroker.PublishState(feedstream.StatePayload{
+		UserID:      uuid.UUID(userID),
+		IDs:         ids,
+		State:       state,
+		UnreadCount: unreadCount,
+	})
+}
+
+type sseWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (w *sseWriter) WriteEvent(event feedstream.Event) error {
+	var msg []byte
+	if event.ID != 0 {
+		msg = fmt.Appendf(msg, "id: %d\n", event.ID)
+	}
+	msg = fmt.Appendf(msg, "event: %s\ndata: %s\n\n", event.Type, event.Data)
+
+	return w.write(msg)
+}
+
+func (w *sseWriter) WriteHeartbeat() error {
+	return w.write([]byte(": heartbeat\n\n"))
+}
+
+func (w *sseWriter) write(msg []byte) error {
+	if _, err := w.w.Write(msg); err != nil {
+		return err
+	}
+
+	return w.rc.Flush()
+}
+
+type wsMessage struct {
+	ID   string               `json:"id,omitempty"`
+	Type feedstream.EventType `json:"type"`
+	Data json.RawMessage      `json:"data"`
+}
+
+type wsWriter struct {
+	conn *websocket.Conn
+}
+
+func (w *wsWriter) WriteEvent(event feedstream.Event) error {
+	msg := wsMessage{Type: event.Type, Data: event.Data}
+	if event.ID != 0 {
+		msg.ID = strconv.FormatUint(event.ID, 10)
+	}
+
+	if err := w.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+
+	return w.conn.WriteJSON(msg)
+}
+
+func (w *wsWriter) WriteHeartbeat() error {
+	return w.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+}
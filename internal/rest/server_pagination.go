@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"time"
+
+	corefeed "github.com/goverland-labs/goverland-core-sdk-go/feed"
+	coreproposal "github.com/goverland-labs/goverland-core-sdk-go/proposal"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/request"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+func (s *Server) encodeCursor(cursor *request.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	return s.cursors.Encode(*cursor)
+}
+
+// cursorExpiredError asks the client to load the list from the first page
+func cursorExpiredError() response.Error {
+	ve := response.NewValidationError()
+	ve.SetError(request.CursorField, response.CursorExpired, request.ErrCursorExpired.Error())
+
+	return ve
+}
+
+// feedItemCursor follows the order of the inbox feed: created_at and id descending
+func feedItemCursor(item *inboxapi.FeedItem) request.Cursor {
+	return request.Cursor{
+		CreatedAt: item.GetCreatedAt().AsTime(),
+		ID:        item.GetId(),
+	}
+}
+
+// daoFeedItemCursor follows the order of the core DAO feed: created_at and id descending
+func daoFeedItemCursor(item corefeed.Item) request.Cursor {
+	return request.Cursor{
+		CreatedAt: item.CreatedAt,
+		ID:        item.ID.String(),
+	}
+}
+
+// voteCursor follows the order of the core user votes: created and id descending
+func voteCursor(vote coreproposal.Vote) request.Cursor {
+	return request.Cursor{
+		CreatedAt: time.Unix(int64(vote.Created), 0).UTC(),
+		ID:        vote.ID,
+	}
+}
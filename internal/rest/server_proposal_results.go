@@ -0,0 +1,210 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	internalproposal "github.com/goverland-labs/goverland-inbox-web-api/internal/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+const (
+	resultsPageSize = 1000
+	// resultsVotesLimit limits the number of votes counted for active proposals, their larger results are marked
+	// as partial. Votes of closed proposals are counted completely once, the result is cached.
+	resultsVotesLimit = 50000
+	// maxLiveTallies limits the number of results counted at the same time, results of closed proposals are cached
+	maxLiveTallies = 4
+	// liveTalliesRetryAfter is the delay in seconds suggested to clients when all tallies are busy
+	liveTalliesRetryAfter = 5
+)
+
+// getProposalResults counts votes of the proposal by the rules of its voting type, results of closed proposals are cached
+func (s *Server) getProposalResults(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	pr, err := s.prService.GetByID(r.Context(), id)
+	if err != nil && errors.Is(err, coresdk.ErrNotFound) {
+		response.SendEmpty(w, http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msgf("get proposal by id: %s", id)
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	results, cached := s.resultsCache.Get(id)
+	if !cached {
+		release, ok := s.acquireLiveTally()
+		if !ok {
+			response.HandleError(response.NewRateLimitedError(liveTalliesRetryAfter, "too many results are being counted, try again later"), w)
+			return
+		}
+		defer release()
+
+		votes, partial, err := s.collectProposalVotes(r.Context(), id, proposalVotesLimit(pr))
+		if err != nil {
+			log.Error().Err(err).Msgf("get proposal votes by id: %s", id)
+
+			response.SendEmpty(w, http.StatusInternalServerError)
+			return
+		}
+
+		var votingType string
+		if pr.Type != nil {
+			votingType = *pr.Type
+		}
+
+		results, err = internalproposal.Tally(votingType, pr.Choices, pr.Quorum, votes)
+		if errors.Is(err, internalproposal.ErrUnsupportedType) {
+			ve := response.NewValidationError()
+			ve.SetError(response.GeneralErrorKey, response.UnsupportedAction, err.Error())
+			response.HandleError(ve, w)
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Msgf("tally proposal votes: %s", id)
+
+			response.SendEmpty(w, http.StatusInternalServerError)
+			return
+		}
+
+		results.Final = pr.VotingFinished()
+		results.Partial = partial
+
+		if results.Final && !results.Partial {
+			s.resultsCache.Add(id, results)
+		}
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("proposal_id", id).
+		Int("votes", results.VotesCount+results.IgnoredVotes).
+		Bool("cached", cached).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &results)
+}
+
+// acquireLiveTally takes one of the slots for counting results without waiting,
+// every tally of the active proposal loads up to resultsVotesLimit votes from the core
+func (s *Server) acquireLiveTally() (func(), bool) {
+	select {
+	case s.liveTallies <- struct{}{}:
+		return func() { <-s.liveTallies }, true
+	default:
+		return nil, false
+	}
+}
+
+// proposalVotesLimit returns the number of votes counted for the proposal, all votes are counted for closed ones
+func proposalVotesLimit(pr *proposal.Proposal) int {
+	if pr.VotingFinished() {
+		return 0
+	}
+
+	return resultsVotesLimit
+}
+
+// collectProposalVotes loads votes of the proposal page by page, it reports whether the limit was reached.
+// Zero limit loads all votes.
+func (s *Server) collectProposalVotes(ctx context.Context, id string, limit int) ([]proposal.Vote, bool, error) {
+	votes := make([]proposal.Vote, 0)
+	for offset := 0; ; offset += resultsPageSize {
+		if limit > 0 && offset >= limit {
+			return votes, true, nil
+		}
+
+		resp, err := s.coreclient.GetProposalVotes(ctx, id, coresdk.GetProposalVotesRequest{
+			Offset: offset,
+			Limit:  resultsPageSize,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		votes = append(votes, ConvertVoteToInternal(resp.Items)...)
+		if offset+resultsPageSize >= resp.TotalCnt || len(resp.Items) == 0 {
+			return votes, false, nil
+		}
+	}
+}
+
+// getProposalRounds replays the instant runoff of the ranked-choice proposal, rounds of closed proposals are cached.
+// Rounds of partially counted votes would be misleading, so they are unavailable for active proposals over the limit
+// of votes: clients show scores of the proposal until the voting ends.
+func (s *Server) getProposalRounds(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	pr, err := s.prService.GetByID(r.Context(), id)
+	if err != nil && errors.Is(err, coresdk.ErrNotFound) {
+		response.SendEmpty(w, http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msgf("get proposal by id: %s", id)
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	if pr.Type == nil || *pr.Type != internalproposal.TypeRankedChoice {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.UnsupportedAction, "rounds are available for ranked-choice proposals only")
+		response.HandleError(ve, w)
+		return
+	}
+
+	results, cached := s.roundsCache.Get(id)
+	if !cached {
+		release, ok := s.acquireLiveTally()
+		if !ok {
+			response.HandleError(response.NewRateLimitedError(liveTalliesRetryAfter, "too many results are being counted, try again later"), w)
+			return
+		}
+		defer release()
+
+		votes, partial, err := s.collectProposalVotes(r.Context(), id, proposalVotesLimit(pr))
+		if err != nil {
+			log.Error().Err(err).Msgf("get proposal votes by id: %s", id)
+
+			response.SendEmpty(w, http.StatusInternalServerError)
+			return
+		}
+		if partial {
+			ve := response.NewValidationError()
+			ve.SetError(response.GeneralErrorKey, response.RoundsUnavailable,
+				fmt.Sprintf("rounds are unavailable until the voting ends for proposals with more than %d votes, use scores of the proposal", resultsVotesLimit))
+			response.HandleError(ve, w)
+			return
+		}
+
+		results = internalproposal.RunoffResults(pr.Choices, votes)
+		results.Final = pr.VotingFinished()
+
+		if results.Final {
+			s.roundsCache.Add(id, results)
+		}
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("proposal_id", id).
+		Int("rounds", len(results.Rounds)).
+		Bool("cached", cached).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &results)
+}
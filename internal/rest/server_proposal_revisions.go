@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/revision"
+)
+
+// listProposalRevisions returns versions of the proposal noticed by the service, the oldest first
+func (s *Server) listProposalRevisions(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	// loading the proposal records the current version if it's new
+	if !s.checkProposalExists(w, r, id) {
+		return
+	}
+
+	list, err := s.revisions.List(id)
+	if err != nil {
+		log.Error().Err(err).Str("proposal_id", id).Msg("list proposal revisions")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	revisions := make([]proposal.Revision, 0, len(list))
+	for _, rev := range list {
+		revisions = append(revisions, rev.Info())
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("proposal_id", id).
+		Int("revisions", len(revisions)).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &revisions)
+}
+
+// getProposalRevisionsDiff compares revisions referenced by ipfs hashes
+func (s *Server) getProposalRevisionsDiff(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if !s.checkProposalExists(w, r, id) {
+		return
+	}
+
+	diff, err := s.revisions.Diff(id, vars["from"], vars["to"])
+	if errors.Is(err, revision.ErrNotFound) {
+		response.HandleError(response.NewNotFoundError(), w)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("proposal_id", id).Msg("diff proposal revisions")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("proposal_id", id).
+		Str("from", diff.From.Ipfs).
+		Str("to", diff.To.Ipfs).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &diff)
+}
+
+func (s *Server) checkProposalExists(w http.ResponseWriter, r *http.Request, id string) bool {
+	_, err := s.prService.GetByID(r.Context(), id)
+	if err != nil && errors.Is(err, coresdk.ErrNotFound) {
+		response.SendEmpty(w, http.StatusNotFound)
+		return false
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msgf("get proposal by id: %s", id)
+
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return false
+	}
+
+	return true
+}
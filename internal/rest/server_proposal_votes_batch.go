@@ -0,0 +1,166 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/mux"
+	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/proposals"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+// batchVoteConcurrency limits the number of concurrent requests to the core per batch
+const batchVoteConcurrency = 5
+
+// prepareVotesBatch prepares typed data of votes for several proposals, failures are reported per item
+func (s *Server) prepareVotesBatch(w http.ResponseWriter, r *http.Request) {
+	params, verr := proposals.NewBatchPrepareVoteForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+
+		return
+	}
+
+	list := make([]proposal.BatchVotePreparation, len(params.Items))
+	forEachConcurrently(len(params.Items), func(i int) {
+		item := params.Items[i]
+		list[i].ProposalID = item.ProposalID
+
+		prepareResponse, err := s.coreclient.PrepareVote(r.Context(), item.ProposalID, coresdk.PrepareVoteRequest{
+			Voter:  string(params.Voter),
+			Choice: json.RawMessage(item.Choice),
+			Reason: item.Reason,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("proposal_id", item.ProposalID).Str("voter", string(params.Voter)).Msg("prepare proposal vote")
+
+			resolved := response.ResolveError(err)
+			list[i].Status = resolved.GetHTTPStatus()
+			list[i].Error = response.ParseError(resolved)
+
+			return
+		}
+
+		list[i].Status = http.StatusOK
+		list[i].Preparation = &proposal.VotePreparation{
+			ID:        prepareResponse.ID,
+			TypedData: prepareResponse.TypedData,
+		}
+	})
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Fields(params.ConvertToMap()).
+		Int("failed", countFailed(list, func(item proposal.BatchVotePreparation) int { return item.Status })).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+// voteBatch submits signed votes, failures are reported per item
+func (s *Server) voteBatch(w http.ResponseWriter, r *http.Request) {
+	session, exists := appctx.ExtractUserSession(r.Context())
+	if !exists {
+		response.SendEmpty(w, http.StatusForbidden)
+
+		return
+	}
+
+	params, verr := proposals.NewBatchVoteForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+
+		return
+	}
+
+	list := make([]proposal.BatchVoteResult, len(params.Items))
+	voted := make([]string, len(params.Items))
+	forEachConcurrently(len(params.Items), func(i int) {
+		item := params.Items[i]
+		list[i].ID = item.ID
+
+		voteResponse, err := s.coreclient.Vote(r.Context(), coresdk.VoteRequest{
+			ID:  item.ID,
+			Sig: item.Sig,
+		})
+		if err != nil {
+			log.Error().Err(err).Fields(item.ConvertToMap()).Msg("vote proposal")
+
+			resolved := response.ResolveError(err)
+			list[i].Status = resolved.GetHTTPStatus()
+			list[i].Error = response.ParseError(resolved)
+
+			return
+		}
+
+		list[i].Status = http.StatusOK
+		list[i].Vote = &proposal.SuccessfulVote{
+			ID:   voteResponse.ID,
+			IPFS: voteResponse.IPFS,
+			Relayer: proposal.Relayer{
+				Address: voteResponse.Relayer.Address,
+				Receipt: voteResponse.Relayer.Receipt,
+			},
+		}
+		voted[i] = voteResponse.ProposalID
+	})
+
+	failed := countFailed(list, func(item proposal.BatchVoteResult) int { return item.Status })
+	if failed < len(list) {
+		go func() {
+			for _, proposalID := range voted {
+				if proposalID != "" {
+					s.publishVoteCreated(session.UserID, proposalID)
+				}
+			}
+
+			s.getSubscriptions(session.UserID)
+		}()
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Fields(params.ConvertToMap()).
+		Int("failed", failed).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+// forEachConcurrently calls fn for indexes up to count, at most batchVoteConcurrency calls run at once
+func forEachConcurrently(count int, fn func(i int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchVoteConcurrency)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func countFailed[T any](list []T, status func(T) int) int {
+	failed := 0
+	for _, item := range list {
+		if status(item) != http.StatusOK {
+			failed++
+		}
+	}
+
+	return failed
+}
@@ -15,7 +15,6 @@ import (
 	coreproposal "github.com/goverland-labs/goverland-core-sdk-go/proposal"
 	"github.com/goverland-labs/goverland-platform-events/events/inbox"
 	"github.com/rs/zerolog/log"
-	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
@@ -25,6 +24,7 @@ import (
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/ipfs"
+	resthelpers "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/common"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/proposals"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/request"
 	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
@@ -34,6 +34,12 @@ func (s *Server) getProposal(w http.ResponseWriter, r *http.Request) {
 	session, _ := appctx.ExtractUserSession(r.Context())
 	id := mux.Vars(r)["id"]
 
+	bf, verr := resthelpers.NewBodyFormat().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
 	pr, err := s.prService.GetByID(r.Context(), id)
 	if err != nil && errors.Is(err, coresdk.ErrNotFound) {
 		response.SendEmpty(w, http.StatusNotFound)
@@ -49,7 +55,9 @@ func (s *Server) getProposal(w http.ResponseWriter, r *http.Request) {
 
 	item := enrichProposalSubscriptionInfo(session, *pr)
 	item = s.enrichProposalVotesInfo(r.Context(), session, item)
+	item = s.enrichProposalBookmarkInfo(session, item)
 	item = helpers.WrapProposalIpfsLinks(item)
+	item = helpers.RenderProposalBody(item, bf.Formats)
 
 	response.SendJSON(w, http.StatusOK, &item)
 }
@@ -141,6 +149,12 @@ func (s *Server) listProposals(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	bf, verr := resthelpers.NewBodyFormat().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
 	offset, limit, err := request.ExtractPagination(r)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, err.Error())
@@ -149,7 +163,7 @@ func (s *Server) listProposals(w http.ResponseWriter, r *http.Request) {
 
 	var proposalIds []string
 	if f.Featured {
-		featuredProposals, err := s.ibxProposalClient.GetFeaturedProposals(r.Context(), &emptypb.Empty{})
+		featuredIDs, err := s.prService.GetFeaturedIDs(r.Context())
 		if err != nil {
 			log.Error().Err(err).Msg("get featured proposals")
 			response.SendError(w, http.StatusBadRequest, err.Error())
@@ -157,14 +171,14 @@ func (s *Server) listProposals(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if len(featuredProposals.ProposalIds) == 0 {
+		if len(featuredIDs) == 0 {
 			response.AddPaginationHeaders(w, r, offset, limit, 0)
 			response.SendJSON(w, http.StatusOK, &[]proposal.Proposal{})
 
 			return
 		}
 
-		proposalIds = featuredProposals.ProposalIds
+		proposalIds = featuredIDs
 	}
 
 	resp, err := s.coreclient.GetProposalList(r.Context(), coresdk.GetProposalListRequest{
@@ -217,7 +231,9 @@ func (s *Server) listProposals(w http.ResponseWriter, r *http.Request) {
 
 	list = enrichProposalsSubscriptionInfo(session, list)
 	list = s.enrichProposalsVotesInfo(r.Context(), session, list)
+	list = s.enrichProposalsBookmarkInfo(session, list)
 	list = helpers.WrapProposalsIpfsLinks(list)
+	list = helpers.RenderProposalsBody(list, bf.Formats)
 
 	log.Info().
 		Str("route", mux.CurrentRoute(r).GetName()).
@@ -232,6 +248,12 @@ func (s *Server) listProposals(w http.ResponseWriter, r *http.Request) {
 func (s *Server) proposalsTop(w http.ResponseWriter, r *http.Request) {
 	session, _ := appctx.ExtractUserSession(r.Context())
 
+	bf, verr := resthelpers.NewBodyFormat().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
 	offset, limit, err := request.ExtractPagination(r)
 	if err != nil {
 		response.SendError(w, http.StatusBadRequest, err.Error())
@@ -284,7 +306,9 @@ func (s *Server) proposalsTop(w http.ResponseWriter, r *http.Request) {
 
 	list = enrichProposalsSubscriptionInfo(session, list)
 	list = s.enrichProposalsVotesInfo(r.Context(), session, list)
+	list = s.enrichProposalsBookmarkInfo(session, list)
 	list = helpers.WrapProposalsIpfsLinks(list)
+	list = helpers.RenderProposalsBody(list, bf.Formats)
 
 	log.Info().
 		Str("route", mux.CurrentRoute(r).GetName()).
@@ -399,27 +423,30 @@ func (h *Server) vote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	go func() {
-		// todo: use SubjectVoteCreated instead of this subject
-		if err = h.publisher.PublishJSON(context.TODO(), inbox.SubjectRecalculateAchievement, inbox.AchievementRecalculateEvent{
-			UserID: uuid.UUID(session.UserID),
-			Type:   inbox.AchievementTypeVote,
-		}); err != nil {
-			log.Error().Err(err).Msg("publish recalculate event")
-		}
-
-		if err = h.publisher.PublishJSON(context.TODO(), inbox.SubjectVoteCreated, inbox.VotePayload{
-			UserID:     uuid.UUID(session.UserID),
-			ProposalID: voteResponse.ProposalID,
-		}); err != nil {
-			log.Error().Err(err).Msg("publish vote event")
-		}
-
+		h.publishVoteCreated(session.UserID, voteResponse.ProposalID)
 		h.getSubscriptions(session.UserID)
 	}()
 
 	response.SendJSON(w, http.StatusOK, &successfulVote)
 }
 
+func (h *Server) publishVoteCreated(userID auth.UserID, proposalID string) {
+	// todo: use SubjectVoteCreated instead of this subject
+	if err := h.publisher.PublishJSON(context.TODO(), inbox.SubjectRecalculateAchievement, inbox.AchievementRecalculateEvent{
+		UserID: uuid.UUID(userID),
+		Type:   inbox.AchievementTypeVote,
+	}); err != nil {
+		log.Error().Err(err).Msg("publish recalculate event")
+	}
+
+	if err := h.publisher.PublishJSON(context.TODO(), inbox.SubjectVoteCreated, inbox.VotePayload{
+		UserID:     uuid.UUID(userID),
+		ProposalID: proposalID,
+	}); err != nil {
+		log.Error().Err(err).Msg("publish vote event")
+	}
+}
+
 // todo: remove it when moved to proposal service
 func convertProposalToInternal(pr *coreproposal.Proposal, di *internaldao.DAO) proposal.Proposal {
 	alias := pr.Author
@@ -573,6 +600,47 @@ func enrichProposalSubscriptionInfo(session auth.Session, item proposal.Proposal
 
 	return item
 }
+
+func (s *Server) enrichProposalBookmarkInfo(session auth.Session, item proposal.Proposal) proposal.Proposal {
+	if session == auth.EmptySession {
+		return item
+	}
+
+	bookmarked, err := s.bookmarks.Bookmarked(session.UserID, item.ID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("get bookmarks")
+
+		return item
+	}
+
+	item.Bookmarked = bookmarked[item.ID]
+
+	return item
+}
+
+func (s *Server) enrichProposalsBookmarkInfo(session auth.Session, list []proposal.Proposal) []proposal.Proposal {
+	if session == auth.EmptySession || len(list) == 0 {
+		return list
+	}
+
+	ids := make([]string, 0, len(list))
+	for i := range list {
+		ids = append(ids, list[i].ID)
+	}
+
+	bookmarked, err := s.bookmarks.Bookmarked(session.UserID, ids...)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("get bookmarks")
+
+		return list
+	}
+
+	for i := range list {
+		list[i].Bookmarked = bookmarked[list[i].ID]
+	}
+
+	return list
+}
 func (h *Server) enrichProposalVotesInfo(context context.Context, session auth.Session, item proposal.Proposal) proposal.Proposal {
 	address, ok := h.getUserAddress(session)
 	if !ok {
@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	authsrv "github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	authentity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/profile"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+func (s *Server) listSessions(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	list, err := s.authService.ListSessions(session.UserID)
+	if err != nil {
+		log.Error().Err(err).Msg("list sessions")
+		response.SendEmpty(w, http.StatusInternalServerError)
+
+		return
+	}
+
+	if list == nil {
+		list = []profile.Session{}
+	}
+
+	for i := range list {
+		if list[i].ID == session.ID.String() {
+			list[i].LastActivityAt = common.NewTime(time.Now())
+			list[i].Current = true
+		}
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+func (s *Server) revokeSession(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	f, verr := auth.NewRevokeSessionForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+
+		return
+	}
+
+	err := s.authService.RevokeSession(r.Context(), session.UserID, authsrv.SessionID(f.ID))
+	if errors.Is(err, authsrv.ErrSessionNotFound) {
+		response.HandleError(response.NewNotFoundError(), w)
+
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("revoke session")
+		response.HandleError(response.ResolveError(err), w)
+
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Str("session_id", f.ID.String()).
+		Msg("route execution")
+
+	response.SendEmpty(w, http.StatusNoContent)
+}
+
+func (s *Server) renameSession(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	f, verr := auth.NewRenameSessionForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+
+		return
+	}
+
+	err := s.authService.RenameSession(r.Context(), session.UserID, authsrv.SessionID(f.ID), f.Name)
+	if errors.Is(err, authsrv.ErrSessionNotFound) {
+		response.HandleError(response.NewNotFoundError(), w)
+
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("rename session")
+		response.HandleError(response.ResolveError(err), w)
+
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Str("session_id", f.ID.String()).
+		Msg("route execution")
+
+	response.SendEmpty(w, http.StatusNoContent)
+}
+
+func (s *Server) revokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	revoked, err := s.authService.RevokeOtherSessions(session.UserID, session.ID)
+	if err != nil {
+		log.Error().Err(err).Int("revoked", revoked).Msg("revoke other sessions")
+		response.SendEmpty(w, http.StatusInternalServerError)
+
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Int("revoked", revoked).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &authentity.RevokedSessions{RevokedCount: revoked})
+}
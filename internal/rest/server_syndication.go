@@ -0,0 +1,284 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	authsrv "github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	authentity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/auth"
+	feedform "github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/request"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/syndication"
+)
+
+const (
+	syndicationFeedTitle = "Goverland inbox"
+
+	atomContentType = "application/atom+xml; charset=utf-8"
+	rssContentType  = "application/rss+xml; charset=utf-8"
+)
+
+type syndicationRenderer func(feed syndication.Feed) ([]byte, error)
+
+func (s *Server) getFeedAtom(w http.ResponseWriter, r *http.Request) {
+	s.serveSyndicationFeed(w, r, syndication.RenderAtom, atomContentType)
+}
+
+func (s *Server) getFeedRSS(w http.ResponseWriter, r *http.Request) {
+	s.serveSyndicationFeed(w, r, syndication.RenderRSS, rssContentType)
+}
+
+// serveSyndicationFeed renders the last items of the user feed. Feed readers can't send the Authorization header,
+// so the user is authenticated by the secret token from the URL. It accepts the same query as the feed listing.
+func (s *Server) serveSyndicationFeed(w http.ResponseWriter, r *http.Request, render syndicationRenderer, contentType string) {
+	session, err := s.authService.GetFeedTokenSession(mux.Vars(r)["token"])
+	if errors.Is(err, authsrv.ErrTokenNotFound) {
+		response.HandleError(response.NewNotFoundError(), w)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("get feed token session")
+		response.SendEmpty(w, http.StatusInternalServerError)
+		return
+	}
+
+	f, verr := feedform.NewGetFeedForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	filter, verr := s.newFeedFilter(r.Context(), f.Filters)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
+	var page request.Page[*inboxapi.FeedItem]
+	if f.Filters.IsEmpty() {
+		page, _, err = s.loadFeedPage(r.Context(), session, f, nil, 0, s.syndicationCfg.Limit)
+	} else {
+		page, _, _, err = s.loadFilteredFeedPage(r.Context(), session, f, filter, nil, 0, s.syndicationCfg.Limit)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("load syndication feed")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	proposalIds := make([]string, 0, len(page.Items))
+	for _, info := range page.Items {
+		if info.ProposalId != nil {
+			proposalIds = append(proposalIds, *info.ProposalId)
+		}
+	}
+
+	pl, err := s.fetchProposalsByIds(r.Context(), proposalIds)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("fetch syndication feed proposals")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	list := helpers.WrapFeedItemsIpfsLinks(s.convertInboxFeedListToInternal(r.Context(), session, page.Items, pl))
+
+	appURL := strings.TrimRight(s.syndicationCfg.AppURL, "/")
+	out := syndication.Feed{
+		ID:       "urn:uuid:" + uuid.UUID(session.ID).String(),
+		Title:    syndicationFeedTitle,
+		Link:     appURL,
+		SelfLink: s.syndicationPublicURL(r) + r.URL.RequestURI(),
+		// the epoch keeps the empty feed stable for conditional requests
+		Updated: time.Unix(0, 0).UTC(),
+		Entries: make([]syndication.Entry, 0, len(list)),
+	}
+	for _, item := range list {
+		entry := syndication.NewEntry(item, fmt.Sprintf("%s/proposals/%s", appURL, item.ProposalID))
+		if entry.Updated.After(out.Updated) {
+			out.Updated = entry.Updated
+		}
+
+		out.Entries = append(out.Entries, entry)
+	}
+
+	body, err := render(out)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", session.UserID.String()).Msg("render syndication feed")
+
+		response.HandleError(response.NewInternalError(), w)
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Int("count", len(out.Entries)).
+		Msg("route execution")
+
+	// the feed is requested without the session, so it's marked as private explicitly
+	response.AddCacheControlHeader(w, response.CachePrivate)
+	if len(out.Entries) > 0 {
+		response.AddLastModifiedHeader(w, out.Updated)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// syndicationPublicURL returns the external address of the api for links to feeds
+func (s *Server) syndicationPublicURL(r *http.Request) string {
+	if s.syndicationCfg.PublicURL != "" {
+		return strings.TrimRight(s.syndicationCfg.PublicURL, "/")
+	}
+
+	scheme := "https"
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	} else if r.TLS == nil {
+		scheme = "http"
+	}
+
+	return scheme + "://" + r.Host
+}
+
+func (s *Server) listFeedTokens(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	tokens, err := s.authService.ListFeedTokens(session.UserID)
+	if err != nil {
+		log.Error().Err(err).Msg("list feed tokens")
+		response.SendEmpty(w, http.StatusInternalServerError)
+
+		return
+	}
+
+	list := make([]authentity.FeedToken, 0, len(tokens))
+	for _, token := range tokens {
+		list = append(list, convertFeedTokenToInternal(token))
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+func (s *Server) createFeedToken(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	f, verr := auth.NewCreateFeedTokenForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+
+		return
+	}
+
+	raw, token, err := s.authService.CreateFeedToken(session.UserID, f.Name)
+	if errors.Is(err, authsrv.ErrTokensLimit) {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.UnsupportedAction, "tokens limit is reached")
+		response.HandleError(ve, w)
+
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("create feed token")
+		response.SendEmpty(w, http.StatusInternalServerError)
+
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Str("token_id", token.ID.String()).
+		Msg("route execution")
+
+	publicURL := s.syndicationPublicURL(r)
+	response.SendJSON(w, http.StatusCreated, &authentity.CreatedFeedToken{
+		FeedToken:   convertFeedTokenToInternal(token),
+		Secret:      raw,
+		AtomURL:     publicURL + "/feed/" + raw + ".atom",
+		RSSURL:      publicURL + "/feed/" + raw + ".rss",
+		CalendarURL: publicURL + "/me/calendar.ics?" + calendarTokenParam + "=" + raw,
+	})
+}
+
+func (s *Server) revokeFeedToken(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	f, verr := auth.NewRevokeTokenForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+
+		return
+	}
+
+	err := s.authService.RevokeFeedToken(session.UserID, f.ID)
+	if errors.Is(err, authsrv.ErrTokenNotFound) {
+		response.HandleError(response.NewNotFoundError(), w)
+
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("revoke feed token")
+		response.SendEmpty(w, http.StatusInternalServerError)
+
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Str("token_id", f.ID.String()).
+		Msg("route execution")
+
+	response.SendEmpty(w, http.StatusNoContent)
+}
+
+func convertFeedTokenToInternal(token authsrv.Token) authentity.FeedToken {
+	var lastUsedAt *common.Time
+	if token.LastUsedAt != nil {
+		lastUsedAt = common.NewTime(*token.LastUsedAt)
+	}
+
+	return authentity.FeedToken{
+		ID:         token.ID.String(),
+		Name:       token.Name,
+		CreatedAt:  *common.NewTime(token.CreatedAt),
+		LastUsedAt: lastUsedAt,
+	}
+}
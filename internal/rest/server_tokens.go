@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/appctx"
+	authsrv "github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	authentity "github.com/goverland-labs/goverland-inbox-web-api/internal/entities/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/forms/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/rest/response"
+)
+
+func (s *Server) listTokens(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	tokens, err := s.authService.ListTokens(session.UserID)
+	if err != nil {
+		log.Error().Err(err).Msg("list tokens")
+		response.SendEmpty(w, http.StatusInternalServerError)
+
+		return
+	}
+
+	list := make([]authentity.Token, 0, len(tokens))
+	for _, token := range tokens {
+		list = append(list, convertTokenToInternal(token))
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	f, verr := auth.NewCreateTokenForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+
+		return
+	}
+
+	raw, token, err := s.authService.CreateToken(session.UserID, f.Name, f.Scopes, f.ExpiresAt)
+	if errors.Is(err, authsrv.ErrTokensLimit) {
+		ve := response.NewValidationError()
+		ve.SetError(response.GeneralErrorKey, response.UnsupportedAction, "tokens limit is reached")
+		response.HandleError(ve, w)
+
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("create token")
+		response.SendEmpty(w, http.StatusInternalServerError)
+
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Str("token_id", token.ID.String()).
+		Msg("route execution")
+
+	response.SendJSON(w, http.StatusCreated, &authentity.CreatedToken{
+		Token:  convertTokenToInternal(token),
+		Secret: raw,
+	})
+}
+
+func (s *Server) revokeToken(w http.ResponseWriter, r *http.Request) {
+	session, ok := appctx.ExtractUserSession(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	f, verr := auth.NewRevokeTokenForm().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+
+		return
+	}
+
+	err := s.authService.RevokeToken(session.UserID, f.ID)
+	if errors.Is(err, authsrv.ErrTokenNotFound) {
+		response.HandleError(response.NewNotFoundError(), w)
+
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("revoke token")
+		response.SendEmpty(w, http.StatusInternalServerError)
+
+		return
+	}
+
+	log.Info().
+		Str("route", mux.CurrentRoute(r).GetName()).
+		Str("user_id", session.UserID.String()).
+		Str("token_id", f.ID.String()).
+		Msg("route execution")
+
+	response.SendEmpty(w, http.StatusNoContent)
+}
+
+func convertTokenToInternal(token authsrv.Token) authentity.Token {
+	scopes := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	var expiresAt, lastUsedAt *common.Time
+	if token.ExpiresAt != nil {
+		expiresAt = common.NewTime(*token.ExpiresAt)
+	}
+	if token.LastUsedAt != nil {
+		lastUsedAt = common.NewTime(*token.LastUsedAt)
+	}
+
+	return authentity.Token{
+		ID:         token.ID.String(),
+		Name:       token.Name,
+		Scopes:     scopes,
+		CreatedAt:  *common.NewTime(token.CreatedAt),
+		ExpiresAt:  expiresAt,
+		LastUsedAt: lastUsedAt,
+	}
+}
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
@@ -73,17 +74,34 @@ func (s *Server) getUserVotes(w http.ResponseWriter, r *http.Request) {
 		response.SendError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	cursor, err := request.ExtractCursor(r, s.cursors)
+	if err != nil {
+		response.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	var proposalWithVotes []proposal.Proposal
 	total := 0
+	nextCursor := ""
 	session, _ := appctx.ExtractUserSession(r.Context())
 	address, ok := s.getUserAddress(session)
 	if !ok {
 		proposalWithVotes = make([]proposal.Proposal, 0)
 	} else {
-		resp, err := s.coreclient.GetUserVotes(r.Context(), address, coresdk.GetUserVotesRequest{
-			Offset: offset,
-			Limit:  limit,
-		})
+		page, err := request.LoadPage(cursor, offset, limit, func(offset, limit int) ([]coreproposal.Vote, int, error) {
+			resp, err := s.coreclient.GetUserVotes(r.Context(), address, coresdk.GetUserVotesRequest{
+				Offset: offset,
+				Limit:  limit,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+
+			return resp.Items, resp.TotalCnt, nil
+		}, voteCursor)
+		if errors.Is(err, request.ErrCursorExpired) {
+			response.HandleError(cursorExpiredError(), w)
+			return
+		}
 		if err != nil {
 			log.Error().Err(err).Msgf("get user votes by address: %s", address)
 
@@ -91,13 +109,13 @@ func (s *Server) getUserVotes(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		proposalWithVotes = make([]proposal.Proposal, 0)
-		if len(resp.Items) != 0 {
-			userProposals, err := s.collectProposals(resp.Items, r.Context())
+		if len(page.Items) != 0 {
+			userProposals, err := s.collectProposals(page.Items, r.Context())
 			if err != nil {
 				response.SendError(w, http.StatusBadRequest, err.Error())
 				return
 			}
-			list := ConvertVoteToInternal(resp.Items)
+			list := ConvertVoteToInternal(page.Items)
 			for _, info := range list {
 				p, ok := userProposals[info.ProposalID]
 				if !ok {
@@ -107,7 +125,9 @@ func (s *Server) getUserVotes(w http.ResponseWriter, r *http.Request) {
 				proposalWithVotes = append(proposalWithVotes, p)
 			}
 		}
-		total = resp.TotalCnt
+		total = page.Total
+		offset = page.Offset
+		nextCursor = s.encodeCursor(page.Next)
 	}
 	log.Info().
 		Str("route", mux.CurrentRoute(r).GetName()).
@@ -115,7 +135,7 @@ func (s *Server) getUserVotes(w http.ResponseWriter, r *http.Request) {
 		Int("total", total).
 		Msg("route execution")
 
-	response.AddPaginationHeaders(w, r, offset, limit, total)
+	response.AddPaginationHeaders(w, r, offset, limit, total, nextCursor)
 	response.SendJSON(w, http.StatusOK, &proposalWithVotes)
 }
 
@@ -339,6 +359,12 @@ func (s *Server) getMeCanVote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	bf, verr := resthelpers.NewBodyFormat().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
 	proposals, err := s.userClient.GetUserCanVoteProposals(r.Context(), &inboxapi.GetUserCanVoteProposalsRequest{
 		UserId: session.UserID.String(),
 	})
@@ -404,7 +430,9 @@ func (s *Server) getMeCanVote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	list = s.enrichProposalsVotesInfo(r.Context(), session, list)
+	list = s.enrichProposalsBookmarkInfo(session, list)
 	list = helpers.WrapProposalsIpfsLinks(list)
+	list = helpers.RenderProposalsBody(list, bf.Formats)
 
 	proposalsWithoutVotes := make([]proposal.Proposal, 0, len(list))
 	for _, p := range list {
@@ -436,6 +464,12 @@ func (s *Server) getVoteNow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	bf, verr := resthelpers.NewBodyFormat().ParseAndValidate(r)
+	if verr != nil {
+		response.HandleError(verr, w)
+		return
+	}
+
 	var featured bool
 	if featuredStr := r.URL.Query().Get("featured"); featuredStr != "" {
 		featured = featuredStr == "true"
@@ -513,7 +547,9 @@ func (s *Server) getVoteNow(w http.ResponseWriter, r *http.Request) {
 	}
 
 	list = s.enrichProposalsVotesInfo(r.Context(), session, list)
+	list = s.enrichProposalsBookmarkInfo(session, list)
 	list = helpers.WrapProposalsIpfsLinks(list)
+	list = helpers.RenderProposalsBody(list, bf.Formats)
 
 	resultProposals := make([]proposal.Proposal, 0, len(list))
 	for _, p := range list {
@@ -640,18 +676,27 @@ func (s *Server) getAllDelegates(w http.ResponseWriter, r *http.Request) {
 	}
 
 	address := mux.Vars(r)["address"]
-	resp, err := s.coreclient.GetTopDelegatesByAddress(r.Context(), address)
+	list, total, err := s.collectTopDelegates(r.Context(), address)
 	if err != nil {
 		response.HandleError(response.NewInternalError(), w)
 		return
 	}
 
+	response.AddTotalCounterHeaders(w, total)
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+func (s *Server) collectTopDelegates(ctx context.Context, address string) ([]delegations.DelegatesList, int, error) {
+	resp, err := s.coreclient.GetTopDelegatesByAddress(ctx, address)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	list := make([]delegations.DelegatesList, 0, len(resp.List))
 	for _, info := range resp.List {
-		daoInfo, err := s.daoService.GetDao(r.Context(), info.Dao.ID.String())
+		daoInfo, err := s.daoService.GetDao(ctx, info.Dao.ID.String())
 		if err != nil {
-			response.HandleError(response.NewInternalError(), w)
-			return
+			return nil, 0, err
 		}
 
 		list = append(list, delegations.DelegatesList{
@@ -661,8 +706,7 @@ func (s *Server) getAllDelegates(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	response.AddTotalCounterHeaders(w, resp.TotalCount)
-	response.SendJSON(w, http.StatusOK, &list)
+	return list, resp.TotalCount, nil
 }
 
 func (s *Server) getTopDelegators(w http.ResponseWriter, r *http.Request) {
@@ -673,18 +717,27 @@ func (s *Server) getTopDelegators(w http.ResponseWriter, r *http.Request) {
 	}
 
 	address := mux.Vars(r)["address"]
-	resp, err := s.coreclient.GetTopDelegatorsByAddress(r.Context(), address)
+	list, total, err := s.collectTopDelegators(r.Context(), address)
 	if err != nil {
 		response.HandleError(response.NewInternalError(), w)
 		return
 	}
 
+	response.AddTotalCounterHeaders(w, total)
+	response.SendJSON(w, http.StatusOK, &list)
+}
+
+func (s *Server) collectTopDelegators(ctx context.Context, address string) ([]delegations.DelegatorsList, int, error) {
+	resp, err := s.coreclient.GetTopDelegatorsByAddress(ctx, address)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	list := make([]delegations.DelegatorsList, 0, len(resp.List))
 	for _, info := range resp.List {
-		daoInfo, err := s.daoService.GetDao(r.Context(), info.Dao.ID.String())
+		daoInfo, err := s.daoService.GetDao(ctx, info.Dao.ID.String())
 		if err != nil {
-			response.HandleError(response.NewInternalError(), w)
-			return
+			return nil, 0, err
 		}
 
 		list = append(list, delegations.DelegatorsList{
@@ -695,8 +748,7 @@ func (s *Server) getTopDelegators(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	response.AddTotalCounterHeaders(w, resp.TotalCount)
-	response.SendJSON(w, http.StatusOK, &list)
+	return list, resp.TotalCount, nil
 }
 
 func (s *Server) getDelegatorsList(w http.ResponseWriter, r *http.Request) {
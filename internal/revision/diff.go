@@ -0,0 +1,164 @@
+package revision
+
+import (
+	"strings"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+)
+
+const (
+	// diffContext is the number of unchanged lines around changes in hunks
+	diffContext = 3
+	// maxDiffCells limits the size of the lcs table, the rest of large texts is replaced as a whole
+	maxDiffCells = 4_000_000
+)
+
+// DiffLines returns the line-level diff of texts as unified hunks
+func DiffLines(oldText, newText string) []proposal.DiffHunk {
+	return hunks(diffLines(splitLines(oldText), splitLines(newText)))
+}
+
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines compares lines by the longest common subsequence, common prefix and suffix are trimmed first
+func diffLines(a, b []string) []proposal.DiffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]proposal.DiffLine, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		lines = append(lines, newLine(proposal.DiffEqual, a[i], i, i))
+	}
+
+	lines = append(lines, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix, prefix)...)
+
+	for i := 0; i < suffix; i++ {
+		ai, bi := len(a)-suffix+i, len(b)-suffix+i
+		lines = append(lines, newLine(proposal.DiffEqual, a[ai], ai, bi))
+	}
+
+	return lines
+}
+
+func diffMiddle(a, b []string, aOffset, bOffset int) []proposal.DiffLine {
+	lines := make([]proposal.DiffLine, 0, len(a)+len(b))
+	if len(a)*len(b) > maxDiffCells {
+		for i, line := range a {
+			lines = append(lines, newLine(proposal.DiffDelete, line, aOffset+i, -1))
+		}
+		for i, line := range b {
+			lines = append(lines, newLine(proposal.DiffInsert, line, -1, bOffset+i))
+		}
+
+		return lines
+	}
+
+	// lcs[i][j] is the length of the common subsequence of a[i:] and b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, newLine(proposal.DiffEqual, a[i], aOffset+i, bOffset+j))
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			lines = append(lines, newLine(proposal.DiffInsert, b[j], -1, bOffset+j))
+			j++
+		default:
+			lines = append(lines, newLine(proposal.DiffDelete, a[i], aOffset+i, -1))
+			i++
+		}
+	}
+
+	return lines
+}
+
+// newLine creates the line with numbers starting from 1, negative indexes mean the line is absent
+func newLine(op proposal.DiffOp, text string, oldIndex, newIndex int) proposal.DiffLine {
+	line := proposal.DiffLine{Op: op, Text: text}
+	if oldIndex >= 0 {
+		line.OldLine = oldIndex + 1
+	}
+	if newIndex >= 0 {
+		line.NewLine = newIndex + 1
+	}
+
+	return line
+}
+
+// hunks groups changed lines with the context, close groups are merged
+func hunks(lines []proposal.DiffLine) []proposal.DiffHunk {
+	result := make([]proposal.DiffHunk, 0)
+
+	start, end := -1, -1
+	flush := func() {
+		if start < 0 {
+			return
+		}
+
+		hunk := proposal.DiffHunk{Lines: lines[start:end]}
+		for _, line := range hunk.Lines {
+			if line.OldLine > 0 {
+				if hunk.OldStart == 0 {
+					hunk.OldStart = line.OldLine
+				}
+				hunk.OldLines++
+			}
+			if line.NewLine > 0 {
+				if hunk.NewStart == 0 {
+					hunk.NewStart = line.NewLine
+				}
+				hunk.NewLines++
+			}
+		}
+
+		result = append(result, hunk)
+		start, end = -1, -1
+	}
+
+	for i, line := range lines {
+		if line.Op == proposal.DiffEqual {
+			continue
+		}
+
+		from, to := max(0, i-diffContext), min(len(lines), i+diffContext+1)
+		if start >= 0 && from > end {
+			flush()
+		}
+		if start < 0 {
+			start = from
+		}
+		end = to
+	}
+	flush()
+
+	return result
+}
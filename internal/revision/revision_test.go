@@ -0,0 +1,161 @@
+package revision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func TestDiffLines(t *testing.T) {
+	for name, tc := range map[string]struct {
+		old, new string
+		expected []proposal.DiffHunk
+	}{
+		"equal": {
+			old:      "a\nb\n",
+			new:      "a\nb",
+			expected: []proposal.DiffHunk{},
+		},
+		"from empty": {
+			old: "",
+			new: "a\nb",
+			expected: []proposal.DiffHunk{{
+				OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 2,
+				Lines: []proposal.DiffLine{
+					{Op: proposal.DiffInsert, Text: "a", NewLine: 1},
+					{Op: proposal.DiffInsert, Text: "b", NewLine: 2},
+				},
+			}},
+		},
+		"replace with context": {
+			old: "1\n2\n3\n4\n5\n6\n7\n8\n9",
+			new: "1\n2\n3\n4\nfive\n6\n7\n8\n9",
+			expected: []proposal.DiffHunk{{
+				OldStart: 2, OldLines: 7, NewStart: 2, NewLines: 7,
+				Lines: []proposal.DiffLine{
+					{Op: proposal.DiffEqual, Text: "2", OldLine: 2, NewLine: 2},
+					{Op: proposal.DiffEqual, Text: "3", OldLine: 3, NewLine: 3},
+					{Op: proposal.DiffEqual, Text: "4", OldLine: 4, NewLine: 4},
+					{Op: proposal.DiffDelete, Text: "5", OldLine: 5},
+					{Op: proposal.DiffInsert, Text: "five", NewLine: 5},
+					{Op: proposal.DiffEqual, Text: "6", OldLine: 6, NewLine: 6},
+					{Op: proposal.DiffEqual, Text: "7", OldLine: 7, NewLine: 7},
+					{Op: proposal.DiffEqual, Text: "8", OldLine: 8, NewLine: 8},
+				},
+			}},
+		},
+		"separate hunks": {
+			old: "a\n1\n2\n3\n4\n5\n6\n7\nb",
+			new: "A\n1\n2\n3\n4\n5\n6\n7\nB",
+			expected: []proposal.DiffHunk{
+				{
+					OldStart: 1, OldLines: 4, NewStart: 1, NewLines: 4,
+					Lines: []proposal.DiffLine{
+						{Op: proposal.DiffDelete, Text: "a", OldLine: 1},
+						{Op: proposal.DiffInsert, Text: "A", NewLine: 1},
+						{Op: proposal.DiffEqual, Text: "1", OldLine: 2, NewLine: 2},
+						{Op: proposal.DiffEqual, Text: "2", OldLine: 3, NewLine: 3},
+						{Op: proposal.DiffEqual, Text: "3", OldLine: 4, NewLine: 4},
+					},
+				},
+				{
+					OldStart: 6, OldLines: 4, NewStart: 6, NewLines: 4,
+					Lines: []proposal.DiffLine{
+						{Op: proposal.DiffEqual, Text: "5", OldLine: 6, NewLine: 6},
+						{Op: proposal.DiffEqual, Text: "6", OldLine: 7, NewLine: 7},
+						{Op: proposal.DiffEqual, Text: "7", OldLine: 8, NewLine: 8},
+						{Op: proposal.DiffDelete, Text: "b", OldLine: 9},
+						{Op: proposal.DiffInsert, Text: "B", NewLine: 9},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DiffLines(tc.old, tc.new))
+		})
+	}
+}
+
+func TestStorage(t *testing.T) {
+	bucket := kvstoretest.NewBucket()
+	storage := NewStorage(bucket, config.Revisions{CacheSize: 1, MaxRevisions: 3})
+
+	pr := &proposal.Proposal{
+		ID:      "0x1",
+		Ipfs:    helpers.Ptr("ipfs1"),
+		Title:   "Title",
+		Body:    []common.Content{{Type: common.Markdown, Body: "# Motivation\n\nWe need funds"}},
+		Choices: []string{"For", "Against"},
+	}
+	storage.Record(pr)
+	storage.Record(pr)
+	changes, err := storage.LastChanges("0x1")
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+
+	pr.Ipfs = helpers.Ptr("ipfs2")
+	pr.Title = "New title"
+	pr.Body = []common.Content{{Type: common.Markdown, Body: "# Motivation\n\nWe need more funds"}}
+	storage.Record(pr)
+
+	// forgets the last version of 0x1
+	storage.Record(&proposal.Proposal{ID: "0x2", Ipfs: helpers.Ptr("ipfs3")})
+
+	// another instance sharing the bucket records the same version only once
+	shared := NewStorage(bucket, config.Revisions{CacheSize: 1, MaxRevisions: 3})
+	shared.Record(pr)
+	storage.Record(pr)
+
+	for _, s := range []*Storage{storage, shared} {
+		list, err := s.List("0x1")
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, 1, list[0].Number)
+		assert.Equal(t, "ipfs2", list[1].Ipfs)
+
+		diff, err := s.Diff("0x1", "ipfs1", "ipfs2")
+		require.NoError(t, err)
+		assert.True(t, diff.TitleChanged)
+		assert.False(t, diff.ChoicesChanged)
+		assert.Equal(t, 1, diff.Added)
+		assert.Equal(t, 1, diff.Removed)
+
+		changes, err := s.LastChanges("0x1")
+		require.NoError(t, err)
+		require.NotNil(t, changes)
+		assert.Equal(t, "ipfs1", changes.From)
+		assert.Equal(t, "ipfs2", changes.To)
+		assert.Equal(t, []proposal.DiffLine{
+			{Op: proposal.DiffDelete, Text: "We need funds", OldLine: 3},
+			{Op: proposal.DiffInsert, Text: "We need more funds", NewLine: 3},
+		}, changes.Highlights)
+	}
+
+	// numbers aren't references
+	_, err = storage.Diff("0x1", "1", "2")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	list, err := storage.List("0x3")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	for i := 0; i < 3; i++ {
+		pr.Ipfs = helpers.Ptr(pr.Title)
+		pr.Title += "!"
+		storage.Record(pr)
+	}
+
+	list, err = shared.List("0x1")
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, 3, list[0].Number)
+	assert.Equal(t, 5, list[2].Number)
+}
@@ -0,0 +1,276 @@
+package revision
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+// maxHighlights limits the number of changed lines attached to feed items
+const maxHighlights = 5
+
+var ErrNotFound = errors.New("revision not found")
+
+// Revision is the snapshot of the proposal content, the ipfs hash identifies the version.
+// Numbers are shown to users only, revisions are referenced by hashes.
+type Revision struct {
+	Number    int       `json:"number"`
+	Ipfs      string    `json:"ipfs"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Choices   []string  `json:"choices"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type history struct {
+	Revisions []Revision `json:"revisions"`
+}
+
+// Storage keeps revisions of proposals in the bucket shared by all instances, revisions of the proposal
+// are stored together. The last recorded versions are remembered up to the cache size, so loading
+// the same version again doesn't touch the bucket.
+type Storage struct {
+	histories *kvstore.Store[history]
+	cfg       config.Revisions
+
+	mu    sync.Mutex
+	seen  map[string]string
+	order []string
+}
+
+func NewStorage(bucket kvstore.Bucket, cfg config.Revisions) *Storage {
+	return &Storage{
+		histories: kvstore.New[history](bucket),
+		cfg:       cfg,
+		seen:      make(map[string]string),
+	}
+}
+
+// Record adds the revision if the version of the proposal differs from the last known one
+func (s *Storage) Record(pr *proposal.Proposal) {
+	if pr == nil {
+		return
+	}
+
+	rev := Revision{
+		Title:     pr.Title,
+		Body:      markdownBody(pr.Body),
+		Choices:   pr.Choices,
+		CreatedAt: time.Now(),
+	}
+	if pr.Ipfs != nil {
+		rev.Ipfs = *pr.Ipfs
+	}
+	if rev.Ipfs == "" {
+		rev.Ipfs = contentHash(rev)
+	}
+
+	if s.lastSeen(pr.ID) == rev.Ipfs {
+		return
+	}
+
+	_, err := s.histories.Update(historyKey(pr.ID), func(h *history) error {
+		rev.Number = 1
+		if len(h.Revisions) > 0 {
+			last := h.Revisions[len(h.Revisions)-1]
+			if last.Ipfs == rev.Ipfs || sameContent(last, rev) {
+				return kvstore.ErrUnchanged
+			}
+
+			rev.Number = last.Number + 1
+		}
+
+		h.Revisions = append(h.Revisions, rev)
+		if s.cfg.MaxRevisions > 0 && len(h.Revisions) > s.cfg.MaxRevisions {
+			h.Revisions = h.Revisions[len(h.Revisions)-s.cfg.MaxRevisions:]
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("proposal_id", pr.ID).Msg("record proposal revision")
+
+		return
+	}
+
+	s.remember(pr.ID, rev.Ipfs)
+}
+
+func (s *Storage) List(proposalID string) ([]Revision, error) {
+	h, err := s.get(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Revisions, nil
+}
+
+// Diff compares revisions referenced by ipfs hashes
+func (s *Storage) Diff(proposalID, from, to string) (proposal.RevisionDiff, error) {
+	h, err := s.get(proposalID)
+	if err != nil {
+		return proposal.RevisionDiff{}, err
+	}
+
+	a, err := h.find(from)
+	if err != nil {
+		return proposal.RevisionDiff{}, err
+	}
+
+	b, err := h.find(to)
+	if err != nil {
+		return proposal.RevisionDiff{}, err
+	}
+
+	return compare(a, b), nil
+}
+
+// LastChanges summarizes the difference between the last two revisions, it's nil if there are no edits
+func (s *Storage) LastChanges(proposalID string) (*proposal.RevisionChanges, error) {
+	h, err := s.get(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(h.Revisions) < 2 {
+		return nil, nil
+	}
+
+	diff := compare(h.Revisions[len(h.Revisions)-2], h.Revisions[len(h.Revisions)-1])
+	changes := &proposal.RevisionChanges{
+		From:           diff.From.Ipfs,
+		To:             diff.To.Ipfs,
+		TitleChanged:   diff.TitleChanged,
+		ChoicesChanged: diff.ChoicesChanged,
+		Added:          diff.Added,
+		Removed:        diff.Removed,
+		Highlights:     make([]proposal.DiffLine, 0, maxHighlights),
+	}
+
+	for _, hunk := range diff.Body {
+		for _, line := range hunk.Lines {
+			if line.Op != proposal.DiffEqual && strings.TrimSpace(line.Text) != "" && len(changes.Highlights) < maxHighlights {
+				changes.Highlights = append(changes.Highlights, line)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func (r Revision) Info() proposal.Revision {
+	return proposal.Revision{
+		Revision:  r.Number,
+		Ipfs:      r.Ipfs,
+		Title:     r.Title,
+		Choices:   r.Choices,
+		CreatedAt: *common.NewTime(r.CreatedAt),
+	}
+}
+
+func compare(a, b Revision) proposal.RevisionDiff {
+	diff := proposal.RevisionDiff{
+		From:           a.Info(),
+		To:             b.Info(),
+		TitleChanged:   a.Title != b.Title,
+		ChoicesChanged: !slices.Equal(a.Choices, b.Choices),
+		Title:          DiffLines(a.Title, b.Title),
+		Choices:        DiffLines(strings.Join(a.Choices, "\n"), strings.Join(b.Choices, "\n")),
+		Body:           DiffLines(a.Body, b.Body),
+	}
+
+	for _, hunk := range diff.Body {
+		for _, line := range hunk.Lines {
+			switch line.Op {
+			case proposal.DiffInsert:
+				diff.Added++
+			case proposal.DiffDelete:
+				diff.Removed++
+			}
+		}
+	}
+
+	return diff
+}
+
+func (h history) find(ipfs string) (Revision, error) {
+	for _, rev := range h.Revisions {
+		if rev.Ipfs == ipfs {
+			return rev, nil
+		}
+	}
+
+	return Revision{}, fmt.Errorf("%w: %s", ErrNotFound, ipfs)
+}
+
+// get returns the empty history if the proposal has no revisions
+func (s *Storage) get(proposalID string) (history, error) {
+	h, err := s.histories.Get(historyKey(proposalID))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return history{Revisions: []Revision{}}, nil
+	}
+	if err != nil {
+		return history{}, fmt.Errorf("get revisions: %s: %w", proposalID, err)
+	}
+
+	return h, nil
+}
+
+func (s *Storage) lastSeen(proposalID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.seen[proposalID]
+}
+
+// remember keeps the last recorded version, the oldest proposals are forgotten above the cache size
+func (s *Storage) remember(proposalID, ipfs string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.seen[proposalID]; !ok {
+		s.order = append(s.order, proposalID)
+	}
+	s.seen[proposalID] = ipfs
+
+	for s.cfg.CacheSize > 0 && len(s.order) > s.cfg.CacheSize {
+		delete(s.seen, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+func historyKey(proposalID string) string {
+	return kvstore.Key("proposal", proposalID)
+}
+
+func sameContent(a, b Revision) bool {
+	return a.Title == b.Title && a.Body == b.Body && slices.Equal(a.Choices, b.Choices)
+}
+
+func contentHash(rev Revision) string {
+	sum := sha256.Sum256([]byte(rev.Title + "\x00" + rev.Body + "\x00" + strings.Join(rev.Choices, "\x00")))
+
+	return "sha256-" + hex.EncodeToString(sum[:])
+}
+
+func markdownBody(body []common.Content) string {
+	for _, content := range body {
+		if content.Type == common.Markdown {
+			return content.Body
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,303 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+)
+
+const (
+	// minPrefixLength is the minimal length of the query term matched as the prefix of words,
+	// so "treas" finds "treasury"
+	minPrefixLength = 3
+	prefixWeight    = 0.5
+
+	// bm25 parameters
+	k1 = 1.2
+	b  = 0.75
+)
+
+type field int
+
+const (
+	fieldTitle field = iota
+	fieldDAO
+	fieldBody
+
+	fieldsCount
+)
+
+var fieldWeights = [fieldsCount]float64{
+	fieldTitle: 3,
+	fieldDAO:   2,
+	fieldBody:  1,
+}
+
+// Document is the indexed proposal, the body is the markdown
+type Document struct {
+	ID        string
+	Title     string
+	DAOName   string
+	Body      string
+	UpdatedAt time.Time
+}
+
+type Result struct {
+	ID       string
+	Score    float64
+	Title    string
+	Snippets []string
+}
+
+type fieldTerms struct {
+	terms  map[string]int
+	length int
+}
+
+type entry struct {
+	doc    Document
+	title  []rune
+	body   []rune
+	fields [fieldsCount]fieldTerms
+	usedAt time.Time
+}
+
+// Index keeps tokenized proposals. Documents are shared between users, the search is limited
+// by the ids of proposals from the user's feed. The least recently used documents are evicted
+// when the size is exceeded.
+type Index struct {
+	mu   sync.Mutex
+	size int
+	docs map[string]*entry
+}
+
+func NewIndex(size int) *Index {
+	return &Index{
+		size: size,
+		docs: make(map[string]*entry),
+	}
+}
+
+// Missing returns ids which aren't indexed yet or were indexed before the time of the last update
+func (i *Index) Missing(versions map[string]time.Time) []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var ids []string
+	for id, updatedAt := range versions {
+		e, ok := i.docs[id]
+		if !ok || e.doc.UpdatedAt.Before(updatedAt) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+func (i *Index) Add(docs ...Document) {
+	entries := make([]*entry, 0, len(docs))
+	for _, doc := range docs {
+		entries = append(entries, newEntry(doc))
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := time.Now()
+	for _, e := range entries {
+		e.usedAt = now
+		i.docs[e.doc.ID] = e
+	}
+
+	i.evict()
+}
+
+func (i *Index) Len() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return len(i.docs)
+}
+
+// Search ranks documents with the ids by bm25 over title, dao name and body fields. The statistics
+// are collected over the documents with the ids, so the rank doesn't depend on feeds of other users.
+func (i *Index) Search(query string, ids []string) []Result {
+	terms := Terms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	i.mu.Lock()
+	now := time.Now()
+	candidates := make([]*entry, 0, len(ids))
+	for _, id := range ids {
+		if e, ok := i.docs[id]; ok {
+			e.usedAt = now
+			candidates = append(candidates, e)
+		}
+	}
+	i.mu.Unlock()
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	var avgLength [fieldsCount]float64
+	for _, e := range candidates {
+		for f := range fieldsCount {
+			avgLength[f] += float64(e.fields[f].length)
+		}
+	}
+	for f := range fieldsCount {
+		avgLength[f] = math.Max(avgLength[f]/float64(len(candidates)), 1)
+	}
+
+	frequencies := make([][]float64, len(candidates))
+	df := make([]int, len(terms))
+	for ci, e := range candidates {
+		frequencies[ci] = make([]float64, len(terms)*int(fieldsCount))
+		for ti, term := range terms {
+			found := false
+			for f := range fieldsCount {
+				tf := e.fields[f].frequency(term)
+				frequencies[ci][ti*int(fieldsCount)+int(f)] = tf
+				found = found || tf > 0
+			}
+			if found {
+				df[ti]++
+			}
+		}
+	}
+
+	results := make([]Result, 0)
+	for ci, e := range candidates {
+		var score float64
+		matched := 0
+		for ti := range terms {
+			idf := math.Log(1 + (float64(len(candidates)-df[ti])+0.5)/(float64(df[ti])+0.5))
+
+			termScore := 0.0
+			for f := range fieldsCount {
+				tf := frequencies[ci][ti*int(fieldsCount)+int(f)]
+				if tf == 0 {
+					continue
+				}
+
+				norm := 1 - b + b*float64(e.fields[f].length)/avgLength[f]
+				termScore += fieldWeights[f] * idf * tf * (k1 + 1) / (tf + k1*norm)
+			}
+
+			if termScore > 0 {
+				matched++
+				score += termScore
+			}
+		}
+
+		if matched == 0 {
+			continue
+		}
+
+		// documents matching all terms of the query go first
+		coverage := float64(matched) / float64(len(terms))
+		results = append(results, Result{
+			ID:       e.doc.ID,
+			Score:    math.Round(score*coverage*coverage*1000) / 1000,
+			Title:    highlight(e.title, terms),
+			Snippets: snippets(e.body, terms),
+		})
+	}
+
+	slices.SortStableFunc(results, func(a, b Result) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return results
+}
+
+// evict removes the least recently used documents above the size
+func (i *Index) evict() {
+	if i.size <= 0 || len(i.docs) <= i.size {
+		return
+	}
+
+	entries := make([]*entry, 0, len(i.docs))
+	for _, e := range i.docs {
+		entries = append(entries, e)
+	}
+	slices.SortFunc(entries, func(a, b *entry) int {
+		return a.usedAt.Compare(b.usedAt)
+	})
+
+	for _, e := range entries[:len(entries)-i.size] {
+		delete(i.docs, e.doc.ID)
+	}
+}
+
+func newEntry(doc Document) *entry {
+	e := &entry{
+		doc:   doc,
+		title: []rune(doc.Title),
+		body:  []rune(helpers.MarkdownText(doc.Body)),
+	}
+	e.fields[fieldTitle] = newFieldTerms(e.title)
+	e.fields[fieldDAO] = newFieldTerms([]rune(doc.DAOName))
+	e.fields[fieldBody] = newFieldTerms(e.body)
+
+	// the body isn't needed after tokenizing, the plain text is kept for snippets
+	e.doc.Body = ""
+
+	return e
+}
+
+func newFieldTerms(text []rune) fieldTerms {
+	tokens := tokenize(text)
+	ft := fieldTerms{
+		terms:  make(map[string]int, len(tokens)),
+		length: len(tokens),
+	}
+	for _, t := range tokens {
+		ft.terms[t.term]++
+	}
+
+	return ft
+}
+
+// frequency counts exact matches of the term and matches by prefix with the lower weight
+func (ft fieldTerms) frequency(term string) float64 {
+	tf := float64(ft.terms[term])
+	if len([]rune(term)) < minPrefixLength {
+		return tf
+	}
+
+	for t, count := range ft.terms {
+		if t != term && strings.HasPrefix(t, term) {
+			tf += prefixWeight * float64(count)
+		}
+	}
+
+	return tf
+}
+
+// matches reports whether the word matches one of the query terms
+func matches(word string, terms []string) bool {
+	for _, term := range terms {
+		if word == term || len([]rune(term)) >= minPrefixLength && strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+
+	return false
+}
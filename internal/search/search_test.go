@@ -0,0 +1,66 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"aave", "treasury", "2024"}, Terms("  Aave the TREASURY, aave 2024 "))
+	assert.Empty(t, Terms("the - of"))
+}
+
+func TestIndexSearch(t *testing.T) {
+	index := NewIndex(10)
+	updated := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	index.Add(
+		Document{ID: "1", Title: "Treasury diversification", DAOName: "Aave", Body: "Sell part of the **treasury** for stablecoins.", UpdatedAt: updated},
+		Document{ID: "2", Title: "Grants program", DAOName: "Aave", Body: "Fund grants from the community treasury.", UpdatedAt: updated},
+		Document{ID: "3", Title: "Treasury report", DAOName: "Uniswap", Body: "Quarterly numbers.", UpdatedAt: updated},
+	)
+
+	results := index.Search("aave treasury", []string{"1", "2", "3"})
+	require.Len(t, results, 3)
+	assert.Equal(t, "1", results[0].ID)
+	assert.Equal(t, "<mark>Treasury</mark> diversification", results[0].Title)
+	assert.Equal(t, []string{"Sell part of the <mark>treasury</mark> for stablecoins."}, results[0].Snippets)
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	// the search is limited by ids and the last term matches by prefix
+	results = index.Search("treas", []string{"2"})
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"Fund grants from the community <mark>treasury</mark>."}, results[0].Snippets)
+
+	// documents updated after indexing are reindexed
+	assert.Equal(t, []string{"2", "4"}, index.Missing(map[string]time.Time{"1": updated, "2": updated.Add(time.Hour), "4": {}}))
+}
+
+func TestIndexEvict(t *testing.T) {
+	index := NewIndex(2)
+	index.Add(Document{ID: "1"}, Document{ID: "2"})
+	index.Search("x", []string{"1"})
+	index.Add(Document{ID: "3"})
+
+	assert.Equal(t, 2, index.Len())
+	assert.Equal(t, []string{"2"}, index.Missing(map[string]time.Time{"1": {}, "2": {}, "3": {}}))
+}
+
+func TestSnippets(t *testing.T) {
+	text := []rune("Intro <b>words</b> go here. " +
+		"Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. " +
+		"Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. " +
+		"The budget is approved.")
+
+	result := snippets(text, []string{"budget"})
+	require.Len(t, result, 1)
+	assert.Equal(t, "…et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. The <mark>budget</mark> is approved.", result[0])
+
+	result = snippets(text, []string{"missing"})
+	require.Len(t, result, 1)
+	assert.Contains(t, result[0], "Intro &lt;b&gt;words&lt;/b&gt; go here.")
+	assert.True(t, strings.HasSuffix(result[0], ellipsis))
+}
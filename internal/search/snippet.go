@@ -0,0 +1,178 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	snippetLength  = 160
+	snippetContext = 40
+	snippetsCount  = 2
+
+	markOpen  = "<mark>"
+	markClose = "</mark>"
+	ellipsis  = "…"
+)
+
+// window is the part of the text in runes with the number of distinct terms it contains
+type window struct {
+	start, end int
+	score      int
+}
+
+// snippets returns up to snippetsCount fragments of the text around matches of the terms, matches
+// are wrapped with <mark> and the rest of the text is escaped. The beginning of the text is returned
+// if there are no matches.
+func snippets(text []rune, terms []string) []string {
+	if len(text) == 0 {
+		return []string{}
+	}
+
+	var hits []token
+	for _, t := range tokenize(text) {
+		if matches(t.term, terms) {
+			hits = append(hits, t)
+		}
+	}
+
+	if len(hits) == 0 {
+		end := wordBoundary(text, min(snippetLength, len(text)), 0)
+		return []string{render(text, 0, end, nil)}
+	}
+
+	var windows []window
+	for i, hit := range hits {
+		// matches at the end of the text get more context before them
+		start := wordStart(text, max(0, min(hit.start-snippetContext, len(text)-snippetLength)), hit.start)
+		end := wordBoundary(text, min(len(text), start+snippetLength), hit.end)
+
+		distinct := make(map[string]struct{})
+		for _, next := range hits[i:] {
+			if next.end > end {
+				break
+			}
+			distinct[next.term] = struct{}{}
+		}
+
+		windows = append(windows, window{start: start, end: end, score: len(distinct)})
+	}
+
+	var selected []window
+	for len(selected) < snippetsCount {
+		best := -1
+		for i, w := range windows {
+			if overlaps(selected, w) {
+				continue
+			}
+			if best < 0 || w.score > windows[best].score {
+				best = i
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		selected = append(selected, windows[best])
+	}
+
+	// fragments are returned in the order of the text
+	if len(selected) == 2 && selected[1].start < selected[0].start {
+		selected[0], selected[1] = selected[1], selected[0]
+	}
+
+	result := make([]string, 0, len(selected))
+	for _, w := range selected {
+		result = append(result, render(text, w.start, w.end, hits))
+	}
+
+	return result
+}
+
+// highlight wraps matches of the terms in the whole text
+func highlight(text []rune, terms []string) string {
+	var hits []token
+	for _, t := range tokenize(text) {
+		if matches(t.term, terms) {
+			hits = append(hits, t)
+		}
+	}
+
+	var sb strings.Builder
+	writeMarked(&sb, text, 0, len(text), hits)
+
+	return sb.String()
+}
+
+func render(text []rune, start, end int, hits []token) string {
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString(ellipsis)
+	}
+
+	writeMarked(&sb, text, start, end, hits)
+
+	if end < len(text) {
+		sb.WriteString(ellipsis)
+	}
+
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+func writeMarked(sb *strings.Builder, text []rune, start, end int, hits []token) {
+	pos := start
+	for _, hit := range hits {
+		if hit.start < start || hit.end > end {
+			continue
+		}
+
+		sb.WriteString(html.EscapeString(string(text[pos:hit.start])))
+		sb.WriteString(markOpen)
+		sb.WriteString(html.EscapeString(string(text[hit.start:hit.end])))
+		sb.WriteString(markClose)
+		pos = hit.end
+	}
+	sb.WriteString(html.EscapeString(string(text[pos:end])))
+}
+
+func overlaps(selected []window, w window) bool {
+	for _, s := range selected {
+		if w.start < s.end && s.start < w.end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wordStart moves the position forward up to the limit to the beginning of the word, so snippets don't start
+// in the middle of words
+func wordStart(text []rune, pos, limit int) int {
+	if pos == 0 {
+		return 0
+	}
+
+	for i := pos; i <= limit; i++ {
+		if unicode.IsSpace(text[i-1]) {
+			return i
+		}
+	}
+
+	return pos
+}
+
+// wordBoundary moves the position back down to the limit to the end of the word
+func wordBoundary(text []rune, pos, limit int) int {
+	if pos >= len(text) {
+		return len(text)
+	}
+
+	for i := pos; i > limit; i-- {
+		if unicode.IsSpace(text[i]) {
+			return i
+		}
+	}
+
+	return pos
+}
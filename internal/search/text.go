@@ -0,0 +1,64 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// token is the normalized term with its position in the source text in runes
+type token struct {
+	term       string
+	start, end int
+}
+
+var stopWords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "for": {}, "from": {},
+	"in": {}, "is": {}, "it": {}, "of": {}, "on": {}, "or": {}, "that": {}, "the": {}, "to": {}, "with": {},
+}
+
+func tokenize(text []rune) []token {
+	var (
+		tokens []token
+		start  = -1
+	)
+
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+
+		term := strings.ToLower(string(text[start:end]))
+		if _, ok := stopWords[term]; !ok {
+			tokens = append(tokens, token{term: term, start: start, end: end})
+		}
+		start = -1
+	}
+
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			if start < 0 {
+				start = i
+			}
+
+			continue
+		}
+
+		flush(i)
+	}
+	flush(len(text))
+
+	return tokens
+}
+
+// Terms returns unique normalized terms of the query
+func Terms(query string) []string {
+	var terms []string
+	for _, t := range tokenize([]rune(query)) {
+		if !slices.Contains(terms, t.term) {
+			terms = append(terms, t.term)
+		}
+	}
+
+	return terms
+}
@@ -0,0 +1,93 @@
+package snooze
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxPeriod limits how long the item can be snoozed
+const MaxPeriod = 90 * 24 * time.Hour
+
+var (
+	ErrTimePassed   = errors.New("snooze time has already passed")
+	ErrTooLong      = errors.New("snooze period is too long")
+	ErrNoVotingTime = errors.New("voting time is unknown")
+)
+
+// Preset is the snooze time relative to now or to the voting window of the proposal
+type Preset string
+
+const (
+	PresetHour                Preset = "1h"
+	PresetThreeHours          Preset = "3h"
+	PresetDay                 Preset = "1d"
+	PresetWeek                Preset = "1w"
+	PresetVotingStarts        Preset = "voting_starts"
+	PresetHourBeforeVotingEnd Preset = "1h_before_voting_ends"
+	PresetDayBeforeVotingEnd  Preset = "24h_before_voting_ends"
+)
+
+var Presets = []Preset{
+	PresetHour,
+	PresetThreeHours,
+	PresetDay,
+	PresetWeek,
+	PresetVotingStarts,
+	PresetHourBeforeVotingEnd,
+	PresetDayBeforeVotingEnd,
+}
+
+// RequiresVoting reports if the preset depends on the voting window of the proposal
+func (p Preset) RequiresVoting() bool {
+	switch p {
+	case PresetVotingStarts, PresetHourBeforeVotingEnd, PresetDayBeforeVotingEnd:
+		return true
+	default:
+		return false
+	}
+}
+
+// Resolve returns the time of resurfacing, voting times are used by voting presets only
+func (p Preset) Resolve(now, votingStart, votingEnd time.Time) (time.Time, error) {
+	var until time.Time
+	switch p {
+	case PresetHour:
+		until = now.Add(time.Hour)
+	case PresetThreeHours:
+		until = now.Add(3 * time.Hour)
+	case PresetDay:
+		until = now.Add(24 * time.Hour)
+	case PresetWeek:
+		until = now.Add(7 * 24 * time.Hour)
+	case PresetVotingStarts:
+		if votingStart.IsZero() {
+			return time.Time{}, ErrNoVotingTime
+		}
+		until = votingStart
+	case PresetHourBeforeVotingEnd, PresetDayBeforeVotingEnd:
+		if votingEnd.IsZero() {
+			return time.Time{}, ErrNoVotingTime
+		}
+
+		before := time.Hour
+		if p == PresetDayBeforeVotingEnd {
+			before = 24 * time.Hour
+		}
+		until = votingEnd.Add(-before)
+	}
+
+	return until, Validate(now, until)
+}
+
+// Validate checks the time of resurfacing is in the allowed range
+func Validate(now, until time.Time) error {
+	if !until.After(now) {
+		return ErrTimePassed
+	}
+
+	if until.Sub(now) > MaxPeriod {
+		return ErrTooLong
+	}
+
+	return nil
+}
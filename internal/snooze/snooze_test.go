@@ -0,0 +1,172 @@
+package snooze
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/feedstream"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+)
+
+func TestStorage(t *testing.T) {
+	bucket := kvstoretest.NewBucket()
+	storage := NewStorage(bucket)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := auth.UserID(uuid.New())
+
+	require.NoError(t, storage.Snooze(Item{UserID: userID, FeedItemID: "a", Until: now.Add(time.Hour)}))
+	require.NoError(t, storage.Snooze(Item{UserID: userID, FeedItemID: "b", Until: now.Add(-time.Minute)}))
+	require.NoError(t, storage.Snooze(Item{UserID: userID, FeedItemID: "a", Until: now.Add(-time.Hour)}))
+	require.NoError(t, storage.Snooze(Item{UserID: auth.UserID(uuid.New()), FeedItemID: "c", Until: now.Add(-time.Second)}))
+
+	due, err := storage.Due(now)
+	require.NoError(t, err)
+	require.Len(t, due, 3)
+	assert.Equal(t, "a", due[0].FeedItemID)
+	assert.Equal(t, "b", due[1].FeedItemID)
+	assert.Equal(t, "c", due[2].FeedItemID)
+
+	// the item snoozed again after it was selected as due is kept
+	require.NoError(t, storage.Snooze(Item{UserID: userID, FeedItemID: "a", Until: now.Add(time.Hour)}))
+	require.NoError(t, storage.Remove(due[0]))
+	require.NoError(t, storage.Remove(due[1]))
+
+	// snoozes are visible to other instances
+	shared := NewStorage(bucket)
+	list, err := shared.List(userID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "a", list[0].FeedItemID)
+
+	_, err = shared.Cancel(userID, "b")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, shared.CancelAll(userID, []string{"a", "b"}))
+	list, err = storage.List(userID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestStorageIndex(t *testing.T) {
+	bucket := kvstoretest.NewBucket()
+	storage := NewStorage(bucket)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	userID, deleted := auth.UserID(uuid.New()), auth.UserID(uuid.New())
+
+	require.NoError(t, storage.Snooze(Item{UserID: userID, FeedItemID: "a", Until: now.Add(-time.Minute)}))
+	require.NoError(t, storage.Snooze(Item{UserID: userID, FeedItemID: "b", Until: now.Add(3 * time.Hour)}))
+	require.NoError(t, storage.Snooze(Item{UserID: deleted, FeedItemID: "c", Until: now.Add(-time.Minute)}))
+	require.NoError(t, storage.DeleteUser(deleted))
+
+	// the item snoozed again is moved to another slot of the index
+	require.NoError(t, storage.Snooze(Item{UserID: userID, FeedItemID: "b", Until: now.Add(-2 * time.Hour)}))
+
+	due, err := storage.Due(now)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, "b", due[0].FeedItemID)
+	assert.Equal(t, "a", due[1].FeedItemID)
+
+	for _, item := range due {
+		require.NoError(t, storage.Remove(item))
+	}
+
+	// empty lists and index slots are deleted, the entry of the deleted user is dropped on lookup
+	keys, err := bucket.Keys()
+	assert.ErrorIs(t, err, nats.ErrNoKeysFound, keys)
+}
+
+func TestPresetResolve(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	start, end := now.Add(2*time.Hour), now.Add(48*time.Hour)
+
+	until, err := PresetHourBeforeVotingEnd.Resolve(now, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, end.Add(-time.Hour), until)
+
+	until, err = PresetVotingStarts.Resolve(now, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, start, until)
+
+	until, err = PresetDay.Resolve(now, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(24*time.Hour), until)
+
+	_, err = PresetDayBeforeVotingEnd.Resolve(now, start, now.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrTimePassed)
+
+	_, err = PresetVotingStarts.Resolve(now, time.Time{}, end)
+	assert.ErrorIs(t, err, ErrNoVotingTime)
+
+	assert.ErrorIs(t, Validate(now, now.Add(MaxPeriod+time.Second)), ErrTooLong)
+}
+
+type feedClientMock struct {
+	unread     []string
+	unarchived []string
+}
+
+func (m *feedClientMock) MarkAsUnread(_ context.Context, in *inboxapi.MarkAsUnreadRequest, _ ...grpc.CallOption) (*inboxapi.UnreadStats, error) {
+	m.unread = append(m.unread, in.GetIds()...)
+
+	return &inboxapi.UnreadStats{UnreadCount: 3}, nil
+}
+
+func (m *feedClientMock) MarkAsUnarchived(_ context.Context, in *inboxapi.MarkAsUnarchivedRequest, _ ...grpc.CallOption) (*inboxapi.UnreadStats, error) {
+	m.unarchived = append(m.unarchived, in.GetIds()...)
+
+	return &inboxapi.UnreadStats{}, nil
+}
+
+type publisherMock struct {
+	subjects []string
+}
+
+func (m *publisherMock) PublishJSON(_ context.Context, subject string, _ any) error {
+	m.subjects = append(m.subjects, subject)
+
+	return nil
+}
+
+type statesMock struct {
+	states []feedstream.State
+}
+
+func (m *statesMock) PublishState(payload feedstream.StatePayload) {
+	m.states = append(m.states, payload.State)
+}
+
+func TestWorkerResurface(t *testing.T) {
+	storage := NewStorage(kvstoretest.NewBucket())
+
+	now := time.Now()
+	userID := auth.UserID(uuid.New())
+	require.NoError(t, storage.Snooze(Item{UserID: userID, FeedItemID: "due", Until: now.Add(-time.Second), Remind: true}))
+	require.NoError(t, storage.Snooze(Item{UserID: userID, FeedItemID: "later", Until: now.Add(time.Hour), Remind: true}))
+
+	feed, publisher, states := &feedClientMock{}, &publisherMock{}, &statesMock{}
+	worker := NewWorker(storage, kvstore.NewLease(kvstoretest.NewBucket(), "snooze"), feed, publisher, states, config.Snooze{Reminders: true})
+	worker.resurface(context.Background(), now)
+
+	assert.Equal(t, []string{"due"}, feed.unarchived)
+	assert.Equal(t, []string{"due"}, feed.unread)
+	assert.Equal(t, []feedstream.State{feedstream.StateUnarchived, feedstream.StateUnread}, states.states)
+	assert.Len(t, publisher.subjects, 1)
+
+	list, err := storage.List(userID)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
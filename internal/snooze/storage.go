@@ -0,0 +1,299 @@
+package snooze
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+const (
+	dueKeyPrefix = "due."
+	// dueSlot is the period of resurfacing times indexed together
+	dueSlot = time.Hour
+)
+
+var ErrNotFound = errors.New("snoozed item not found")
+
+// Item is the feed item hidden until the time
+type Item struct {
+	UserID     auth.UserID `json:"user_id"`
+	FeedItemID string      `json:"feed_item_id"`
+	ProposalID string      `json:"proposal_id,omitempty"`
+	// Title is used in the reminder push
+	Title     string    `json:"title,omitempty"`
+	Until     time.Time `json:"until"`
+	Remind    bool      `json:"remind"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// dueEntry points to the snoozed item from the index of resurfacing times
+type dueEntry struct {
+	UserID     auth.UserID `json:"user_id"`
+	FeedItemID string      `json:"feed_item_id"`
+	Until      time.Time   `json:"until"`
+}
+
+func (e dueEntry) matches(item Item) bool {
+	return e.FeedItemID == item.FeedItemID && e.Until.Equal(item.Until)
+}
+
+// Storage keeps snoozed items in the bucket shared by all instances, items of the user are stored together.
+// Items are indexed by the hour of resurfacing, so due items are found without reading lists of all users.
+// The index is updated separately from lists: entries left by failed writes are dropped on lookup of due items.
+type Storage struct {
+	items *kvstore.Store[[]Item]
+	due   *kvstore.Store[[]dueEntry]
+}
+
+func NewStorage(bucket kvstore.Bucket) *Storage {
+	return &Storage{
+		items: kvstore.New[[]Item](bucket),
+		due:   kvstore.New[[]dueEntry](bucket),
+	}
+}
+
+// Snooze stores the item, the previous snooze of the same feed item is replaced
+func (s *Storage) Snooze(item Item) error {
+	if err := s.index(item); err != nil {
+		return err
+	}
+
+	var previous *Item
+	_, err := s.items.Update(userKey(item.UserID), func(list *[]Item) error {
+		previous = nil
+		if idx := slices.IndexFunc(*list, func(i Item) bool { return i.FeedItemID == item.FeedItemID }); idx >= 0 {
+			replaced := (*list)[idx]
+			previous = &replaced
+			*list = slices.Delete(*list, idx, idx+1)
+		}
+		*list = append(*list, item)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if previous != nil && !previous.Until.Equal(item.Until) {
+		return s.unindex(*previous)
+	}
+
+	return nil
+}
+
+// Cancel removes the snooze of the feed item and returns it
+func (s *Storage) Cancel(userID auth.UserID, feedItemID string) (Item, error) {
+	var item Item
+	_, err := s.items.Update(userKey(userID), func(list *[]Item) error {
+		idx := slices.IndexFunc(*list, func(i Item) bool { return i.FeedItemID == feedItemID })
+		if idx < 0 {
+			return fmt.Errorf("%w: %s", ErrNotFound, feedItemID)
+		}
+
+		item = (*list)[idx]
+
+		return removeItems(list, func(i Item) bool { return i.FeedItemID == feedItemID })
+	})
+	if err != nil {
+		return Item{}, err
+	}
+
+	if err := s.unindex(item); err != nil {
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+// CancelAll removes snoozes of feed items, items without snoozes are skipped
+func (s *Storage) CancelAll(userID auth.UserID, feedItemIDs []string) error {
+	var removed []Item
+	_, err := s.items.Update(userKey(userID), func(list *[]Item) error {
+		removed = nil
+		for _, item := range *list {
+			if slices.Contains(feedItemIDs, item.FeedItemID) {
+				removed = append(removed, item)
+			}
+		}
+
+		return removeItems(list, func(i Item) bool { return slices.Contains(feedItemIDs, i.FeedItemID) })
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range removed {
+		if err := s.unindex(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// List returns snoozed items of the user ordered by the time of resurfacing
+func (s *Storage) List(userID auth.UserID) ([]Item, error) {
+	list, err := s.items.Get(userKey(userID))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return []Item{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sortItems(list)
+
+	return list, nil
+}
+
+// Due returns items of all users which have to be resurfaced at the time. Only index slots up to the time
+// and lists of their users are read, index entries of items which aren't snoozed anymore are dropped.
+func (s *Storage) Due(now time.Time) ([]Item, error) {
+	keys, err := s.due.Keys(dueKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	due := make([]Item, 0)
+	lists := make(map[auth.UserID][]Item)
+	for _, key := range keys {
+		slot, err := strconv.ParseInt(strings.TrimPrefix(key, dueKeyPrefix), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse due slot %s: %w", key, err)
+		}
+		if time.Unix(slot, 0).After(now) {
+			continue
+		}
+
+		entries, err := s.due.Get(key)
+		if errors.Is(err, kvstore.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.Until.After(now) {
+				continue
+			}
+
+			list, ok := lists[entry.UserID]
+			if !ok {
+				if list, err = s.List(entry.UserID); err != nil {
+					return nil, err
+				}
+				lists[entry.UserID] = list
+			}
+
+			idx := slices.IndexFunc(list, entry.matches)
+			if idx < 0 {
+				if err := s.unindex(Item{UserID: entry.UserID, FeedItemID: entry.FeedItemID, Until: entry.Until}); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+
+			due = append(due, list[idx])
+		}
+	}
+	sortItems(due)
+
+	return due, nil
+}
+
+// Remove deletes the resurfaced item unless it was snoozed again in the meantime
+func (s *Storage) Remove(item Item) error {
+	_, err := s.items.Update(userKey(item.UserID), func(list *[]Item) error {
+		return removeItems(list, func(i Item) bool {
+			return i.FeedItemID == item.FeedItemID && i.Until.Equal(item.Until)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.unindex(item)
+}
+
+// DeleteUser removes snoozed items of the user, their index entries are dropped on lookup of due items
+func (s *Storage) DeleteUser(userID auth.UserID) error {
+	return s.items.Delete(userKey(userID))
+}
+
+func (s *Storage) index(item Item) error {
+	entry := dueEntry{UserID: item.UserID, FeedItemID: item.FeedItemID, Until: item.Until}
+	_, err := s.due.Update(dueKey(item.Until), func(entries *[]dueEntry) error {
+		if slices.ContainsFunc(*entries, func(e dueEntry) bool { return e.UserID == item.UserID && e.matches(item) }) {
+			return kvstore.ErrUnchanged
+		}
+
+		*entries = append(*entries, entry)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("index snoozed item: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) unindex(item Item) error {
+	_, err := s.due.Update(dueKey(item.Until), func(entries *[]dueEntry) error {
+		size := len(*entries)
+		*entries = slices.DeleteFunc(*entries, func(e dueEntry) bool {
+			return e.UserID == item.UserID && e.matches(item)
+		})
+
+		switch {
+		case len(*entries) == 0:
+			return kvstore.ErrDelete
+		case len(*entries) == size:
+			return kvstore.ErrUnchanged
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unindex snoozed item: %w", err)
+	}
+
+	return nil
+}
+
+// removeItems skips writing if nothing is removed and deletes the empty list
+func removeItems(list *[]Item, del func(Item) bool) error {
+	size := len(*list)
+	*list = slices.DeleteFunc(*list, del)
+
+	switch {
+	case len(*list) == 0:
+		return kvstore.ErrDelete
+	case len(*list) == size:
+		return kvstore.ErrUnchanged
+	}
+
+	return nil
+}
+
+func userKey(userID auth.UserID) string {
+	return kvstore.Key("user", userID.String())
+}
+
+func dueKey(until time.Time) string {
+	return kvstore.Key("due", strconv.FormatInt(until.Truncate(dueSlot).Unix(), 10))
+}
+
+func sortItems(list []Item) {
+	slices.SortFunc(list, func(a, b Item) int {
+		return a.Until.Compare(b.Until)
+	})
+}
@@ -0,0 +1,173 @@
+package snooze
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	events "github.com/goverland-labs/goverland-platform-events/events/inbox"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/feedstream"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+const defaultReminderBody = "The snoozed item is back in your inbox"
+
+type FeedClient interface {
+	MarkAsUnread(ctx context.Context, in *inboxapi.MarkAsUnreadRequest, opts ...grpc.CallOption) (*inboxapi.UnreadStats, error)
+	MarkAsUnarchived(ctx context.Context, in *inboxapi.MarkAsUnarchivedRequest, opts ...grpc.CallOption) (*inboxapi.UnreadStats, error)
+}
+
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
+type StatePublisher interface {
+	PublishState(payload feedstream.StatePayload)
+}
+
+type reminderPayload struct {
+	FeedItemID string `json:"feed_item_id"`
+	ProposalID string `json:"proposal_id,omitempty"`
+}
+
+// Worker resurfaces snoozed items as unread and sends reminders, only the instance holding the lease does it
+type Worker struct {
+	storage   *Storage
+	lease     *kvstore.Lease
+	feed      FeedClient
+	publisher Publisher
+	states    StatePublisher
+	cfg       config.Snooze
+}
+
+func NewWorker(storage *Storage, lease *kvstore.Lease, feed FeedClient, publisher Publisher, states StatePublisher, cfg config.Snooze) *Worker {
+	return &Worker{
+		storage:   storage,
+		lease:     lease,
+		feed:      feed,
+		publisher: publisher,
+		states:    states,
+		cfg:       cfg,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	defer func() {
+		if err := w.lease.Release(); err != nil {
+			log.Warn().Err(err).Msg("release snooze lease")
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			held, err := w.lease.Acquire()
+			if err != nil {
+				log.Error().Err(err).Msg("acquire snooze lease")
+			}
+			if held {
+				w.resurface(ctx, now)
+			}
+		}
+	}
+}
+
+// resurface returns due items to the feed. Items failed because of temporary errors are retried on the next tick.
+func (w *Worker) resurface(ctx context.Context, now time.Time) {
+	due, err := w.storage.Due(now)
+	if err != nil {
+		log.Error().Err(err).Msg("get due snoozed items")
+
+		return
+	}
+
+	for _, item := range due {
+		if err := w.resurfaceItem(ctx, item); err != nil {
+			if status.Code(err) != codes.NotFound && status.Code(err) != codes.InvalidArgument {
+				log.Error().Err(err).Str("feed_item_id", item.FeedItemID).Msg("resurface snoozed item")
+
+				continue
+			}
+
+			log.Warn().Err(err).Str("feed_item_id", item.FeedItemID).Msg("drop snoozed item")
+		}
+
+		if err := w.storage.Remove(item); err != nil {
+			log.Error().Err(err).Str("feed_item_id", item.FeedItemID).Msg("remove resurfaced item")
+		}
+	}
+}
+
+func (w *Worker) resurfaceItem(ctx context.Context, item Item) error {
+	ids := []string{item.FeedItemID}
+	if _, err := w.feed.MarkAsUnarchived(ctx, &inboxapi.MarkAsUnarchivedRequest{
+		SubscriberId: item.UserID.String(),
+		Ids:          ids,
+	}); err != nil {
+		return err
+	}
+
+	resp, err := w.feed.MarkAsUnread(ctx, &inboxapi.MarkAsUnreadRequest{
+		SubscriberId: item.UserID.String(),
+		Ids:          ids,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, state := range []feedstream.State{feedstream.StateUnarchived, feedstream.StateUnread} {
+		w.states.PublishState(feedstream.StatePayload{
+			UserID:      uuid.UUID(item.UserID),
+			IDs:         ids,
+			State:       state,
+			UnreadCount: int(resp.GetUnreadCount()),
+		})
+	}
+
+	if item.Remind && w.cfg.Reminders {
+		w.remind(ctx, item)
+	}
+
+	return nil
+}
+
+// remind sends the push, the item is already resurfaced, so errors are logged only
+func (w *Worker) remind(ctx context.Context, item Item) {
+	body := item.Title
+	if body == "" {
+		body = defaultReminderBody
+	}
+
+	payload, err := json.Marshal(reminderPayload{
+		FeedItemID: item.FeedItemID,
+		ProposalID: item.ProposalID,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("marshal reminder payload")
+
+		return
+	}
+
+	if err := w.publisher.PublishJSON(ctx, events.SubjectPushCreated, events.PushPayload{
+		Title:         "Reminder",
+		Body:          body,
+		UserID:        uuid.UUID(item.UserID),
+		CustomPayload: payload,
+		Version:       events.PushVersionV2,
+	}); err != nil {
+		log.Error().Err(err).Str("feed_item_id", item.FeedItemID).Msg("publish snooze reminder")
+	}
+}
@@ -0,0 +1,97 @@
+package syndication
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+)
+
+const timelineTimeLayout = "Jan 2, 2006 15:04 MST"
+
+var eventTitles = map[proposal.Event]string{
+	proposal.Created:             "Proposal created",
+	proposal.Updated:             "Proposal updated",
+	proposal.VotingStartsSoon:    "Voting starts soon",
+	proposal.VotingEndsSoon:      "Voting ends soon",
+	proposal.VotingStarted:       "Voting started",
+	proposal.VotingReachedQuorum: "Quorum reached",
+	proposal.VotingEnded:         "Voting ended",
+}
+
+// NewEntry converts the feed item with the proposal to the feed entry.
+// The link is the address of the proposal in the app.
+func NewEntry(item feed.Item, link string) Entry {
+	entry := Entry{
+		ID:        "urn:uuid:" + item.ID.String(),
+		Link:      link,
+		Category:  item.Action,
+		Published: timeOf(item.CreatedAt),
+		Updated:   timeOf(item.UpdatedAt),
+	}
+
+	pr := item.Proposal
+	if pr == nil {
+		entry.Title = item.Action
+
+		return entry
+	}
+
+	entry.Title = pr.Title
+	if pr.DAO.Name != "" {
+		entry.Title = fmt.Sprintf("%s: %s", pr.DAO.Name, pr.Title)
+	}
+
+	entry.Author = string(pr.Author.Address)
+	if pr.Author.ResolvedName != nil && *pr.Author.ResolvedName != "" {
+		entry.Author = *pr.Author.ResolvedName
+	}
+
+	var sb strings.Builder
+	if len(pr.Timeline) > 0 {
+		sb.WriteString("<ul>")
+		for _, event := range pr.Timeline {
+			title, ok := eventTitles[event.Event]
+			if !ok {
+				title = string(event.Event)
+			}
+
+			fmt.Fprintf(&sb, "<li>%s: %s</li>", html.EscapeString(title), timeOf(event.CreatedAt).UTC().Format(timelineTimeLayout))
+		}
+		sb.WriteString("</ul>\n")
+	}
+	sb.WriteString(renderBody(pr.Body))
+	entry.Content = sb.String()
+
+	return entry
+}
+
+// renderBody prefers the html version of the body and compiles the markdown one otherwise
+func renderBody(body []common.Content) string {
+	for _, content := range body {
+		if content.Type == common.HTML {
+			return content.Body
+		}
+	}
+
+	for _, content := range body {
+		if content.Type == common.Markdown {
+			return helpers.CompileSafeMarkdown(content.Body)
+		}
+	}
+
+	return ""
+}
+
+func timeOf(t common.Time) time.Time {
+	if t.Time == nil {
+		return time.Time{}
+	}
+
+	return *t.Time
+}
@@ -0,0 +1,120 @@
+package syndication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/common"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/dao"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/feed"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/entities/proposal"
+)
+
+func newTestFeed() Feed {
+	updated := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	return Feed{
+		ID:       "urn:uuid:6f0c4a3e-6b8e-4c55-9f5e-6c1c4f1a8d11",
+		Title:    "Goverland inbox",
+		Link:     "https://app.goverland.xyz",
+		SelfLink: "https://api.example.com/feed/gvf_secret.atom",
+		Updated:  updated,
+		Entries: []Entry{{
+			ID:        "urn:uuid:0b9f1a4c-1c0e-4a55-8f7e-2d3c4b5a6978",
+			Title:     "Aave: Add <new> market",
+			Link:      "https://app.goverland.xyz/proposals/0x1",
+			Author:    "alice.eth",
+			Category:  "proposal.voting.started",
+			Published: updated.Add(-time.Hour),
+			Updated:   updated,
+			Content:   "<p>Body &amp; more</p>",
+		}},
+	}
+}
+
+func TestRenderAtom(t *testing.T) {
+	out, err := RenderAtom(newTestFeed())
+	require.NoError(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <id>urn:uuid:6f0c4a3e-6b8e-4c55-9f5e-6c1c4f1a8d11</id>
+  <title>Goverland inbox</title>
+  <updated>2024-03-10T12:00:00Z</updated>
+  <generator>Goverland</generator>
+  <link href="https://api.example.com/feed/gvf_secret.atom" rel="self" type="application/atom+xml"></link>
+  <link href="https://app.goverland.xyz" rel="alternate" type="text/html"></link>
+  <entry>
+    <id>urn:uuid:0b9f1a4c-1c0e-4a55-8f7e-2d3c4b5a6978</id>
+    <title>Aave: Add &lt;new&gt; market</title>
+    <link href="https://app.goverland.xyz/proposals/0x1" rel="alternate" type="text/html"></link>
+    <published>2024-03-10T11:00:00Z</published>
+    <updated>2024-03-10T12:00:00Z</updated>
+    <author>
+      <name>alice.eth</name>
+    </author>
+    <category term="proposal.voting.started"></category>
+    <content type="html">&lt;p&gt;Body &amp;amp; more&lt;/p&gt;</content>
+  </entry>
+</feed>`
+	assert.Equal(t, expected, string(out))
+}
+
+func TestRenderRSS(t *testing.T) {
+	out, err := RenderRSS(newTestFeed())
+	require.NoError(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+  <channel>
+    <title>Goverland inbox</title>
+    <link>https://app.goverland.xyz</link>
+    <description>Goverland inbox</description>
+    <generator>Goverland</generator>
+    <lastBuildDate>Sun, 10 Mar 2024 12:00:00 +0000</lastBuildDate>
+    <atom:link href="https://api.example.com/feed/gvf_secret.atom" rel="self" type="application/rss+xml"></atom:link>
+    <item>
+      <title>Aave: Add &lt;new&gt; market</title>
+      <link>https://app.goverland.xyz/proposals/0x1</link>
+      <guid isPermaLink="false">urn:uuid:0b9f1a4c-1c0e-4a55-8f7e-2d3c4b5a6978</guid>
+      <pubDate>Sun, 10 Mar 2024 12:00:00 +0000</pubDate>
+      <author>alice.eth</author>
+      <category>proposal.voting.started</category>
+      <description>&lt;p&gt;Body &amp;amp; more&lt;/p&gt;</description>
+    </item>
+  </channel>
+</rss>`
+	assert.Equal(t, expected, string(out))
+}
+
+func TestNewEntry(t *testing.T) {
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	id := uuid.New()
+
+	entry := NewEntry(feed.Item{
+		ID:        id,
+		CreatedAt: *common.NewTime(created),
+		UpdatedAt: *common.NewTime(created.Add(time.Hour)),
+		Action:    "proposal.voting.started",
+		Proposal: &proposal.Proposal{
+			Title:  "Add market",
+			DAO:    dao.ShortDAO{Name: "Aave"},
+			Author: common.User{Address: "0xabc"},
+			Body:   []common.Content{{Type: common.Markdown, Body: "**bold**"}},
+			Timeline: []proposal.Timeline{
+				{CreatedAt: *common.NewTime(created), Event: proposal.Created},
+				{CreatedAt: *common.NewTime(created.Add(time.Hour)), Event: proposal.VotingStarted},
+			},
+		},
+	}, "https://app.goverland.xyz/proposals/0x1")
+
+	assert.Equal(t, "urn:uuid:"+id.String(), entry.ID)
+	assert.Equal(t, "Aave: Add market", entry.Title)
+	assert.Equal(t, "0xabc", entry.Author)
+	assert.Equal(t, created.Add(time.Hour), entry.Updated)
+	assert.Equal(t, "<ul><li>Proposal created: Mar 1, 2024 10:00 UTC</li><li>Voting started: Mar 1, 2024 11:00 UTC</li></ul>\n<p><strong>bold</strong></p>\n", entry.Content)
+}
@@ -0,0 +1,149 @@
+package upgrade
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+)
+
+const userKeyPrefix = "user."
+
+// Snooze is the guest snooze of the feed item
+type Snooze struct {
+	Subject    string    `json:"subject"`
+	ProposalID string    `json:"proposal_id,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	Until      time.Time `json:"until"`
+	Remind     bool      `json:"remind"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// State is the guest feed state which isn't applied to the regular account yet. Feed items are personal,
+// so they are referenced by the subject: the same proposal or discussion in the regular feed.
+type State struct {
+	Read      []string  `json:"read,omitempty"`
+	Archived  []string  `json:"archived,omitempty"`
+	Snoozed   []Snooze  `json:"snoozed,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s State) empty() bool {
+	return len(s.Read) == 0 && len(s.Archived) == 0 && len(s.Snoozed) == 0
+}
+
+// Storage keeps pending states of upgraded accounts in the bucket shared by all instances
+type Storage struct {
+	states *kvstore.Store[State]
+	ttl    time.Duration
+}
+
+func NewStorage(bucket kvstore.Bucket, ttl time.Duration) *Storage {
+	return &Storage{
+		states: kvstore.New[State](bucket),
+		ttl:    ttl,
+	}
+}
+
+// Add merges the state into the pending one of the user, so states of several guests upgraded to the same account
+// are kept together
+func (s *Storage) Add(userID auth.UserID, state State) error {
+	if state.empty() {
+		return nil
+	}
+
+	_, err := s.states.Update(userKey(userID), func(current *State) error {
+		if current.CreatedAt.IsZero() {
+			current.CreatedAt = state.CreatedAt
+		}
+
+		current.Read = appendMissing(current.Read, state.Read...)
+		current.Archived = appendMissing(current.Archived, state.Archived...)
+		for _, snooze := range state.Snoozed {
+			current.Snoozed = slices.DeleteFunc(current.Snoozed, func(s Snooze) bool { return s.Subject == snooze.Subject })
+			current.Snoozed = append(current.Snoozed, snooze)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// Get returns kvstore.ErrNotFound if the user has no pending state
+func (s *Storage) Get(userID auth.UserID) (State, error) {
+	return s.states.Get(userKey(userID))
+}
+
+// Users returns users with pending states
+func (s *Storage) Users() ([]auth.UserID, error) {
+	keys, err := s.states.Keys(userKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]auth.UserID, 0, len(keys))
+	for _, key := range keys {
+		id, err := uuid.Parse(strings.TrimPrefix(key, userKeyPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("parse user of %s: %w", key, err)
+		}
+
+		users = append(users, auth.UserID(id))
+	}
+
+	return users, nil
+}
+
+// Remove drops the applied part of the pending state. The state is deleted when it's fully applied
+// or it's older than the TTL.
+func (s *Storage) Remove(userID auth.UserID, applied State, now time.Time) error {
+	_, err := s.states.Update(userKey(userID), func(current *State) error {
+		if current.CreatedAt.IsZero() {
+			return kvstore.ErrUnchanged
+		}
+
+		size := len(current.Read) + len(current.Archived) + len(current.Snoozed)
+		current.Read = slices.DeleteFunc(current.Read, func(subject string) bool {
+			return slices.Contains(applied.Read, subject)
+		})
+		current.Archived = slices.DeleteFunc(current.Archived, func(subject string) bool {
+			return slices.Contains(applied.Archived, subject)
+		})
+		current.Snoozed = slices.DeleteFunc(current.Snoozed, func(snooze Snooze) bool {
+			return slices.ContainsFunc(applied.Snoozed, func(s Snooze) bool {
+				return s.Subject == snooze.Subject && s.Until.Equal(snooze.Until)
+			})
+		})
+
+		if current.empty() || now.Sub(current.CreatedAt) > s.ttl {
+			return kvstore.ErrDelete
+		}
+		if len(current.Read)+len(current.Archived)+len(current.Snoozed) == size {
+			return kvstore.ErrUnchanged
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+
+	return list
+}
+
+func userKey(userID auth.UserID) string {
+	return kvstore.Key("user", userID.String())
+}
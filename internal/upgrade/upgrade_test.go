@@ -0,0 +1,113 @@
+package upgrade
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/helpers"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore/kvstoretest"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/snooze"
+)
+
+type feedClientMock struct {
+	items    []*inboxapi.FeedItem
+	read     []string
+	archived []string
+}
+
+func (m *feedClientMock) GetUserFeed(_ context.Context, in *inboxapi.GetUserFeedRequest, _ ...grpc.CallOption) (*inboxapi.FeedList, error) {
+	offset := min(int(in.GetOffset()), len(m.items))
+	end := min(offset+int(in.GetLimit()), len(m.items))
+
+	return &inboxapi.FeedList{List: m.items[offset:end], TotalCount: uint32(len(m.items))}, nil
+}
+
+func (m *feedClientMock) MarkAsRead(_ context.Context, in *inboxapi.MarkAsReadRequest, _ ...grpc.CallOption) (*inboxapi.UnreadStats, error) {
+	m.read = append(m.read, in.GetIds()...)
+
+	return &inboxapi.UnreadStats{}, nil
+}
+
+func (m *feedClientMock) MarkAsArchived(_ context.Context, in *inboxapi.MarkAsArchivedRequest, _ ...grpc.CallOption) (*inboxapi.UnreadStats, error) {
+	m.archived = append(m.archived, in.GetIds()...)
+
+	return &inboxapi.UnreadStats{}, nil
+}
+
+func feedItem(id, proposalID string) *inboxapi.FeedItem {
+	return &inboxapi.FeedItem{Id: id, DaoId: "dao", ProposalId: helpers.Ptr(proposalID)}
+}
+
+func TestWorkerApply(t *testing.T) {
+	bucket := kvstoretest.NewBucket()
+	storage, snoozes := NewStorage(bucket, time.Hour), snooze.NewStorage(kvstoretest.NewBucket())
+	feed := &feedClientMock{items: []*inboxapi.FeedItem{feedItem("r1", "0x1")}}
+	worker := NewWorker(storage, snoozes, kvstore.NewLease(kvstoretest.NewBucket(), "upgrade"), feed, config.Upgrade{})
+
+	now := time.Now()
+	userID := auth.UserID(uuid.New())
+	until := now.Add(time.Hour)
+	require.NoError(t, worker.Schedule(context.Background(), userID, State{
+		Read:      []string{Subject(feedItem("g1", "0x1")), Subject(feedItem("g2", "0x2"))},
+		Archived:  []string{Subject(feedItem("g2", "0x2"))},
+		Snoozed:   []Snooze{{Subject: Subject(feedItem("g2", "0x2")), ProposalID: "0x2", Until: until, Remind: true}},
+		CreatedAt: now,
+	}))
+
+	// existing items are marked at once
+	assert.Equal(t, []string{"r1"}, feed.read)
+	assert.Empty(t, feed.archived)
+
+	// the item created after subscribing is marked by the worker
+	feed.items = append(feed.items, feedItem("r2", "0x2"))
+	worker.applyAll(context.Background(), now)
+
+	assert.Equal(t, []string{"r1", "r2"}, feed.read)
+	assert.Equal(t, []string{"r2"}, feed.archived)
+
+	list, err := snoozes.List(userID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "r2", list[0].FeedItemID)
+	assert.True(t, list[0].Until.Equal(until))
+
+	// the applied state is deleted
+	_, err = storage.Get(userID)
+	assert.ErrorIs(t, err, kvstore.ErrNotFound)
+}
+
+func TestStorageExpired(t *testing.T) {
+	storage := NewStorage(kvstoretest.NewBucket(), time.Hour)
+
+	now := time.Now()
+	userID := auth.UserID(uuid.New())
+	require.NoError(t, storage.Add(userID, State{Read: []string{"a"}, CreatedAt: now}))
+	require.NoError(t, storage.Add(userID, State{Read: []string{"a", "b"}, CreatedAt: now.Add(time.Minute)}))
+
+	state, err := storage.Get(userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, state.Read)
+	assert.True(t, state.CreatedAt.Equal(now))
+
+	users, err := storage.Users()
+	require.NoError(t, err)
+	assert.Equal(t, []auth.UserID{userID}, users)
+
+	require.NoError(t, storage.Remove(userID, State{}, now.Add(time.Minute)))
+	_, err = storage.Get(userID)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.Remove(userID, State{}, now.Add(2*time.Hour)))
+	_, err = storage.Get(userID)
+	assert.ErrorIs(t, err, kvstore.ErrNotFound)
+}
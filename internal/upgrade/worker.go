@@ -0,0 +1,206 @@
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/auth"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/config"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/kvstore"
+	"github.com/goverland-labs/goverland-inbox-web-api/internal/snooze"
+)
+
+const (
+	feedPageSize = 100
+	// feedLimit limits the number of the regular feed items matched with the pending state
+	feedLimit = 1000
+)
+
+type FeedClient interface {
+	GetUserFeed(ctx context.Context, in *inboxapi.GetUserFeedRequest, opts ...grpc.CallOption) (*inboxapi.FeedList, error)
+	MarkAsRead(ctx context.Context, in *inboxapi.MarkAsReadRequest, opts ...grpc.CallOption) (*inboxapi.UnreadStats, error)
+	MarkAsArchived(ctx context.Context, in *inboxapi.MarkAsArchivedRequest, opts ...grpc.CallOption) (*inboxapi.UnreadStats, error)
+}
+
+// Worker applies the guest feed state to the regular account. Feed items of the regular account are created
+// asynchronously after subscribing, so the state is kept until the same items appear in the feed or the TTL expires.
+// Only the instance holding the lease checks pending states.
+type Worker struct {
+	storage *Storage
+	snoozes *snooze.Storage
+	lease   *kvstore.Lease
+	feed    FeedClient
+	cfg     config.Upgrade
+}
+
+func NewWorker(storage *Storage, snoozes *snooze.Storage, lease *kvstore.Lease, feed FeedClient, cfg config.Upgrade) *Worker {
+	return &Worker{
+		storage: storage,
+		snoozes: snoozes,
+		lease:   lease,
+		feed:    feed,
+		cfg:     cfg,
+	}
+}
+
+// Schedule stores the guest state of the upgraded account and applies it to feed items which already exist
+func (w *Worker) Schedule(ctx context.Context, userID auth.UserID, state State) error {
+	if err := w.storage.Add(userID, state); err != nil {
+		return fmt.Errorf("store guest state: %w", err)
+	}
+
+	// the rest of the state is applied by the worker
+	if err := w.Apply(ctx, userID, time.Now()); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("apply guest state")
+	}
+
+	return nil
+}
+
+func (w *Worker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	defer func() {
+		if err := w.lease.Release(); err != nil {
+			log.Warn().Err(err).Msg("release upgrade lease")
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			held, err := w.lease.Acquire()
+			if err != nil {
+				log.Error().Err(err).Msg("acquire upgrade lease")
+			}
+			if held {
+				w.applyAll(ctx, now)
+			}
+		}
+	}
+}
+
+func (w *Worker) applyAll(ctx context.Context, now time.Time) {
+	users, err := w.storage.Users()
+	if err != nil {
+		log.Error().Err(err).Msg("get upgraded users")
+
+		return
+	}
+
+	for _, userID := range users {
+		if err := w.Apply(ctx, userID, now); err != nil {
+			log.Error().Err(err).Str("user_id", userID.String()).Msg("apply guest state")
+		}
+	}
+}
+
+// Apply marks feed items matching the pending state of the user and removes the applied part of the state
+func (w *Worker) Apply(ctx context.Context, userID auth.UserID, now time.Time) error {
+	state, err := w.storage.Get(userID)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ids, err := w.feedItems(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list feed items: %w", err)
+	}
+
+	var applied State
+	read, archived := make([]string, 0), make([]string, 0)
+	for _, subject := range state.Read {
+		if id, ok := ids[subject]; ok {
+			read = append(read, id)
+			applied.Read = append(applied.Read, subject)
+		}
+	}
+	for _, subject := range state.Archived {
+		if id, ok := ids[subject]; ok {
+			archived = append(archived, id)
+			applied.Archived = append(applied.Archived, subject)
+		}
+	}
+
+	if len(read) > 0 {
+		_, err := w.feed.MarkAsRead(ctx, &inboxapi.MarkAsReadRequest{SubscriberId: userID.String(), Ids: read})
+		if err != nil {
+			return fmt.Errorf("mark as read: %w", err)
+		}
+	}
+
+	if len(archived) > 0 {
+		_, err := w.feed.MarkAsArchived(ctx, &inboxapi.MarkAsArchivedRequest{SubscriberId: userID.String(), Ids: archived})
+		if err != nil {
+			return fmt.Errorf("mark as archived: %w", err)
+		}
+	}
+
+	for _, item := range state.Snoozed {
+		id, ok := ids[item.Subject]
+		if !ok {
+			continue
+		}
+
+		err := w.snoozes.Snooze(snooze.Item{
+			UserID:     userID,
+			FeedItemID: id,
+			ProposalID: item.ProposalID,
+			Title:      item.Title,
+			Until:      item.Until,
+			Remind:     item.Remind,
+			CreatedAt:  item.CreatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("snooze %s: %w", id, err)
+		}
+
+		applied.Snoozed = append(applied.Snoozed, item)
+	}
+
+	return w.storage.Remove(userID, applied, now)
+}
+
+// feedItems returns identifiers of the regular feed items by their subjects
+func (w *Worker) feedItems(ctx context.Context, userID auth.UserID) (map[string]string, error) {
+	ids := make(map[string]string)
+	for offset := 0; offset < feedLimit; offset += feedPageSize {
+		resp, err := w.feed.GetUserFeed(ctx, &inboxapi.GetUserFeedRequest{
+			SubscriberId:  userID.String(),
+			ReadState:     inboxapi.GetUserFeedRequest_Include,
+			ArchivedState: inboxapi.GetUserFeedRequest_Include,
+			Limit:         feedPageSize,
+			Offset:        uint32(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range resp.GetList() {
+			ids[Subject(item)] = item.GetId()
+		}
+
+		if offset+feedPageSize >= int(resp.GetTotalCount()) || len(resp.GetList()) == 0 {
+			break
+		}
+	}
+
+	return ids, nil
+}
+
+// Subject identifies the proposal or the discussion of the feed item, it's the same in feeds of all users
+func Subject(item *inboxapi.FeedItem) string {
+	return fmt.Sprintf("%s:%s:%s", item.GetDaoId(), item.GetProposalId(), item.GetDiscussionId())
+}